@@ -56,6 +56,12 @@ const (
 const (
 	MAX_STARTUP_ATTEMPT = "pls.maxStartupAttempt"
 )
+
+// background job enforcing the retention policies of the inventories, an interval of 0 disables it
+const (
+	RETENTION_JOB_NAME                = "retention-job"
+	RETENTION_JOB_INTERVAL_IN_MINUTES = "retention.jobIntervalInMinutes"
+)
 const (
 	LoggerLevelKey = "log.level"
 )
@@ -63,5 +69,105 @@ const (
 const (
 	InventoryConfigurationCollectionName = "InventoryConfiguration"
 	MongoDuplicateEntryErrorCode         = 11000
+	MongoBadValueErrorCode               = 2
+	MongoFailedToParseErrorCode          = 9
+	MongoDocumentValidationErrorCode     = 121
 	InventoryCollectionNamePrefix        = "Inventory-"
+	TopicsInventoryName                  = "topics"
+)
+
+const (
+	InventoryConfigurationRevisionCollectionName = "InventoryConfigurationRevision"
+	InventoryIndexPrefix                         = "idx_"
+	DefaultValidationLevel                       = "strict"
+	DefaultValidationAction                      = "error"
+	SchemaValidationSampleSize                   = 10
+)
+
+// server managed fields stamped on every inventory item
+const (
+	ItemIdField        = "id"
+	ItemCreatedAtField = "created_at"
+	ItemUpdatedAtField = "updated_at"
+	ItemCreatedByField = "created_by"
+	ItemUpdatedByField = "updated_by"
+	ItemVersionField   = "version"
+	ItemIsDeletedField = "is_deleted"
+)
+
+// deletion metadata kept on soft deleted items until they are restored
+const (
+	ItemDeletedAtField      = "deleted_at"
+	ItemDeletedByField      = "deleted_by"
+	ItemDeletionReasonField = "deletion_reason"
+)
+
+// upper bounds of the items accepted by one bulk insert request and of its body, the body is cut before it is decoded
+const (
+	MaxBulkInsertItems    = 10000
+	MaxBulkInsertBodySize = 32 << 20
+)
+
+// defaults used when a configuration has no pagination settings
+const (
+	DefaultPageSize      = 10
+	DefaultMaxPageSize   = 100
+	DefaultSortField     = "created_at"
+	DefaultSortDirection = "desc"
+)
+
+// full text search over the searchable fields of an inventory, backed by the idx_search text index
+const (
+	SearchIdentifierKey = "search"
+	SearchScoreField    = "search_score"
+)
+
+// limits of an aggregation request, the pipeline is aborted after AggregateMaxTimeMs
+const (
+	MaxAggregateGroupFields = 5
+	MaxAggregateMetrics     = 10
+	DefaultAggregateLimit   = 100
+	MaxAggregateLimit       = 1000
+	AggregateMaxTimeMs      = 10000
+)
+
+// limits of the filtered item queries, the finds of a list are aborted after ListMaxTimeMs and the find of an export after
+// ExportMaxTimeMs. Regex filters are run by mongo as PCRE and their pattern is at most MaxFilterRegexLength characters
+const (
+	ListMaxTimeMs        = 10000
+	ExportMaxTimeMs      = 300000
+	MaxFilterRegexLength = 256
+)
+
+// limits of a facet request, Limit caps the values returned per facet
+const (
+	MaxFacetFields    = 10
+	DefaultFacetLimit = 20
+	MaxFacetLimit     = 100
+)
+
+// exports read the items from the cursor ExportBatchSize at a time
+const (
+	ExportBatchSize = 500
+)
+
+// imports write ImportBatchSize rows at a time and list at most MaxImportRejections rejected rows. The request body is cut
+// at MaxImportRequestSize, the file and the multipart framing around it, before the form is parsed
+const (
+	ImportBatchSize      = 500
+	MaxImportRejections  = 1000
+	MaxImportFileSize    = 32 << 20
+	MaxImportRequestSize = MaxImportFileSize + 1<<20
+)
+
+// revisions of the items of an inventory are kept in InventoryHistory-<inventoryName>
+const (
+	InventoryHistoryCollectionNamePrefix = "InventoryHistory-"
+	ItemRevisionIndexName                = "idx_item_revision"
+)
+
+const (
+	InventorySequenceCollectionName = "InventorySequence"
+	CallerIdHeader                  = "X-User-Id"
+	AnonymousCaller                 = "anonymous"
 )
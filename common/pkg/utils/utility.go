@@ -2,6 +2,8 @@ package utils
 
 import (
 	"encoding/json"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func Contains(a []string, x string) bool {
@@ -25,3 +27,27 @@ func TypeConverter[R any](data any) (*R, error) {
 	}
 	return &result, nil
 }
+
+// AsMap : returns the document as map regardless of whether it was decoded from json or bson
+func AsMap(value interface{}) (map[string]interface{}, bool) {
+	switch document := value.(type) {
+	case map[string]interface{}:
+		return document, true
+	case primitive.M:
+		return document, true
+	case primitive.D:
+		return document.Map(), true
+	}
+	return nil, false
+}
+
+// AsSlice : returns the array as slice regardless of whether it was decoded from json or bson
+func AsSlice(value interface{}) ([]interface{}, bool) {
+	switch array := value.(type) {
+	case []interface{}:
+		return array, true
+	case primitive.A:
+		return array, true
+	}
+	return nil, false
+}
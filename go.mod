@@ -6,6 +6,7 @@ require (
 	bitbucket.org/kodnest/go-common-libraries v1.0.7
 	github.com/aws/aws-sdk-go v1.44.163
 	github.com/aws/aws-sdk-go-v2/config v1.18.13
+	github.com/aws/aws-sdk-go-v2/credentials v1.13.13
 	github.com/aws/aws-sdk-go-v2/service/secretsmanager v1.28.6
 	github.com/gin-gonic/gin v1.8.1
 	github.com/go-playground/validator/v10 v10.10.0
@@ -20,13 +21,11 @@ require (
 	github.com/swaggo/swag v1.8.7
 	go.mongodb.org/mongo-driver v1.10.3
 	go.uber.org/zap v1.23.0
-
 )
 
 require (
 	github.com/KyleBanks/depth v1.2.1 // indirect
 	github.com/aws/aws-sdk-go-v2 v1.26.1 // indirect
-	github.com/aws/aws-sdk-go-v2/credentials v1.13.13 // indirect
 	github.com/aws/aws-sdk-go-v2/feature/ec2/imds v1.12.22 // indirect
 	github.com/aws/aws-sdk-go-v2/internal/configsources v1.3.5 // indirect
 	github.com/aws/aws-sdk-go-v2/internal/endpoints/v2 v2.6.5 // indirect
@@ -7,4 +7,5 @@ import (
 var PropertiesMap = map[string]interface{}{
 	commonConstants.MAX_OPTMISTIC_LOCKING_RETRY_COUNT: 3,
 	commonConstants.MAX_STARTUP_ATTEMPT:               3,
+	commonConstants.RETENTION_JOB_INTERVAL_IN_MINUTES: 60,
 }
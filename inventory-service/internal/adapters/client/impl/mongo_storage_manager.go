@@ -2,15 +2,22 @@ package impl
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"inventory-system/common/pkg/constants"
 	"inventory-system/common/pkg/dto"
 	"inventory-system/common/pkg/logger"
+	"inventory-system/common/pkg/utils"
 	"inventory-system/inventory-service/internal/adapters/db"
+	commonDto "inventory-system/inventory-service/internal/common/dto"
 	"inventory-system/inventory-service/internal/common/dto/request_dto"
 	"inventory-system/inventory-service/internal/common/status_code"
+	"regexp"
+	"sort"
+	"strings"
 )
 
 type MongoStorageManager struct {
@@ -30,8 +37,8 @@ func (s MongoStorageManager) CreateCollection(ctx context.Context, collectionStr
 
 	collectionValidatorOptions := options.CreateCollection()
 	collectionValidatorOptions.SetValidator(validation)
-	collectionValidatorOptions.SetValidationLevel("strict")
-	collectionValidatorOptions.SetValidationAction("error")
+	collectionValidatorOptions.SetValidationLevel(constants.DefaultValidationLevel)
+	collectionValidatorOptions.SetValidationAction(constants.DefaultValidationAction)
 
 	err := db.GetDb().CreateCollection(ctx, collectionName, collectionValidatorOptions)
 	if err != nil {
@@ -49,23 +56,530 @@ func (s MongoStorageManager) CreateCollection(ctx context.Context, collectionStr
 		}
 	}
 
+	//every item has at most one revision per version
+	historyCollectionName := constants.InventoryHistoryCollectionNamePrefix + collectionString
+	revisionIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "item_id", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().SetName(constants.ItemRevisionIndexName).SetUnique(true),
+	}
+	_, err = db.GetDb().Collection(historyCollectionName).Indexes().CreateOne(ctx, revisionIndex)
+	if err != nil {
+		log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while creating index for collectionName: " + historyCollectionName)
+		ClientErr.SetError(status_code.IMS103)
+		return &ClientErr
+	}
+
+	return nil
+}
+
+// UpdateCollection : applies the new validator through collMod and syncs the idx_ indexes with the given identifiers
+func (s MongoStorageManager) UpdateCollection(ctx context.Context, collectionString string, validation bson.M, validationLevel string, inventoryIdentifiers []request_dto.InventoryIdentifier) *dto.ErrorResponseDto {
+	methodName := "UpdateCollection"
+	log := logger.GetLogger()
+	var ClientErr dto.ErrorResponseDto
+
+	collectionName := constants.InventoryCollectionNamePrefix + collectionString
+
+	collModCommand := bson.D{
+		{Key: "collMod", Value: collectionName},
+		{Key: "validator", Value: validation},
+		{Key: "validationLevel", Value: validationLevel},
+		{Key: "validationAction", Value: constants.DefaultValidationAction},
+	}
+	err := db.GetDb().RunCommand(ctx, collModCommand).Err()
+	if err != nil {
+		log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while updating validator for collection: " + collectionName)
+		ClientErr.SetError(status_code.IMS120)
+		return &ClientErr
+	}
+
+	existingIndexes, listErr := ListInventoryIndexes(ctx, collectionName)
+	if listErr != nil {
+		return listErr
+	}
+
+	desiredIndexes := make(map[string]request_dto.InventoryIdentifier)
+	for _, inventoryIdentifier := range inventoryIdentifiers {
+		desiredIndexes[constants.InventoryIndexPrefix+inventoryIdentifier.Key] = inventoryIdentifier
+	}
+
+	//Drop indexes which are no longer identifiers or whose definition changed
+	for indexName, existingIndex := range existingIndexes {
+		inventoryIdentifier, ok := desiredIndexes[indexName]
+		if ok && IsIndexUpToDate(existingIndex, inventoryIdentifier) {
+			continue
+		}
+		_, err = db.GetDb().Collection(collectionName).Indexes().DropOne(ctx, indexName)
+		if err != nil {
+			log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while dropping index: " + indexName + " for collectionName: " + collectionName)
+			ClientErr.SetError(status_code.IMS121)
+			return &ClientErr
+		}
+		log.Info("Inside " + methodName + " dropped index: " + indexName + " for collectionName: " + collectionName)
+	}
+
+	for indexName, inventoryIdentifier := range desiredIndexes {
+		existingIndex, ok := existingIndexes[indexName]
+		if ok && IsIndexUpToDate(existingIndex, inventoryIdentifier) {
+			continue
+		}
+		_, err = db.GetDb().Collection(collectionName).Indexes().CreateOne(ctx, GetInventoryIndexes(inventoryIdentifier))
+		if err != nil {
+			log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while creating index: " + indexName + " for collectionName: " + collectionName)
+			ClientErr.SetError(status_code.IMS103)
+			return &ClientErr
+		}
+		log.Info("Inside " + methodName + " created index: " + indexName + " for collectionName: " + collectionName)
+	}
+
+	return nil
+}
+
+// InventoryIndexSpecification : subset of listIndexes output needed to compare an index with an identifier
+type InventoryIndexSpecification struct {
+	Name                    string `bson:"name"`
+	Key                     bson.D `bson:"key"`
+	Unique                  bool   `bson:"unique"`
+	ExpireAfterSeconds      *int32 `bson:"expireAfterSeconds"`
+	PartialFilterExpression bson.M `bson:"partialFilterExpression"`
+	Weights                 bson.M `bson:"weights"`
+}
+
+// ListInventoryIndexes : returns the idx_ indexes present on the collection by name
+func ListInventoryIndexes(ctx context.Context, collectionName string) (map[string]InventoryIndexSpecification, *dto.ErrorResponseDto) {
+	methodName := "ListInventoryIndexes"
+	log := logger.GetLogger()
+	var ClientErr dto.ErrorResponseDto
+
+	cur, err := db.GetDb().Collection(collectionName).Indexes().List(ctx)
+	if err != nil {
+		log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while listing indexes for collectionName: " + collectionName)
+		ClientErr.SetError(status_code.IMS120)
+		return nil, &ClientErr
+	}
+	var indexes []InventoryIndexSpecification
+	err = cur.All(ctx, &indexes)
+	if err != nil {
+		log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while decoding indexes for collectionName: " + collectionName)
+		ClientErr.SetError(status_code.IMS120)
+		return nil, &ClientErr
+	}
+
+	inventoryIndexes := make(map[string]InventoryIndexSpecification)
+	for _, index := range indexes {
+		if !strings.HasPrefix(index.Name, constants.InventoryIndexPrefix) {
+			continue
+		}
+		inventoryIndexes[index.Name] = index
+	}
+	return inventoryIndexes, nil
+}
+
+// IsIndexUpToDate : compares an existing index with the index the identifier would build
+func IsIndexUpToDate(existingIndex InventoryIndexSpecification, inventoryIdentifier request_dto.InventoryIdentifier) bool {
+	desiredIndex := GetInventoryIndexes(inventoryIdentifier)
+	desiredKeys := desiredIndex.Keys.(bson.D)
+
+	if inventoryIdentifier.IsText() {
+		if len(existingIndex.Weights) != len(desiredKeys) {
+			return false
+		}
+		for _, key := range desiredKeys {
+			if _, ok := existingIndex.Weights[key.Key]; !ok {
+				return false
+			}
+		}
+	} else {
+		if len(existingIndex.Key) != len(desiredKeys) {
+			return false
+		}
+		for index, key := range desiredKeys {
+			if existingIndex.Key[index].Key != key.Key || fmt.Sprint(existingIndex.Key[index].Value) != fmt.Sprint(key.Value) {
+				return false
+			}
+		}
+	}
+
+	if existingIndex.Unique != (desiredIndex.Options.Unique != nil && *desiredIndex.Options.Unique) {
+		return false
+	}
+	if (existingIndex.PartialFilterExpression != nil) != inventoryIdentifier.ExcludeDeleted {
+		return false
+	}
+	if (existingIndex.ExpireAfterSeconds == nil) != (inventoryIdentifier.ExpireAfterSeconds == nil) {
+		return false
+	}
+	if existingIndex.ExpireAfterSeconds != nil && *existingIndex.ExpireAfterSeconds != *inventoryIdentifier.ExpireAfterSeconds {
+		return false
+	}
+	return true
+}
+
+// DropCollection : drops the inventory collection and the history of its items along with all of their indexes
+func (s MongoStorageManager) DropCollection(ctx context.Context, collectionString string) *dto.ErrorResponseDto {
+	methodName := "DropCollection"
+	log := logger.GetLogger()
+	var ClientErr dto.ErrorResponseDto
+
+	collectionName := constants.InventoryCollectionNamePrefix + collectionString
+	err := db.GetDb().Collection(collectionName).Drop(ctx)
+	if err != nil {
+		log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while dropping collection: " + collectionName)
+		ClientErr.SetError(status_code.IMS129)
+		return &ClientErr
+	}
+	historyCollectionName := constants.InventoryHistoryCollectionNamePrefix + collectionString
+	err = db.GetDb().Collection(historyCollectionName).Drop(ctx)
+	if err != nil {
+		log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while dropping collection: " + historyCollectionName)
+		ClientErr.SetError(status_code.IMS129)
+		return &ClientErr
+	}
+	log.Info("Inside " + methodName + " dropped collection: " + collectionName)
 	return nil
 }
 
+// ListInventoryCollections : returns the inventory names of every Inventory- collection in the database
+func (s MongoStorageManager) ListInventoryCollections(ctx context.Context) ([]string, *dto.ErrorResponseDto) {
+	methodName := "ListInventoryCollections"
+	log := logger.GetLogger()
+	var ClientErr dto.ErrorResponseDto
+
+	filter := bson.M{"name": bson.M{"$regex": "^" + regexp.QuoteMeta(constants.InventoryCollectionNamePrefix)}}
+	collectionNames, err := db.GetDb().ListCollectionNames(ctx, filter)
+	if err != nil {
+		log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while listing inventory collections")
+		ClientErr.SetError(status_code.IMS134)
+		return nil, &ClientErr
+	}
+
+	inventoryNames := make([]string, 0, len(collectionNames))
+	for _, collectionName := range collectionNames {
+		inventoryNames = append(inventoryNames, strings.TrimPrefix(collectionName, constants.InventoryCollectionNamePrefix))
+	}
+	sort.Strings(inventoryNames)
+	return inventoryNames, nil
+}
+
+// InspectCollection : compares the validator and idx_ indexes of the collection with the given configuration without changing it
+func (s MongoStorageManager) InspectCollection(ctx context.Context, collectionString string, validation bson.M, inventoryIdentifiers []request_dto.InventoryIdentifier) (*commonDto.InventoryDrift, *dto.ErrorResponseDto) {
+	methodName := "InspectCollection"
+	log := logger.GetLogger()
+	var ClientErr dto.ErrorResponseDto
+
+	collectionName := constants.InventoryCollectionNamePrefix + collectionString
+	drift := commonDto.InventoryDrift{
+		InventoryName:   collectionString,
+		MissingIndexes:  []string{},
+		ExtraIndexes:    []string{},
+		OutdatedIndexes: []string{},
+	}
+
+	cur, err := db.GetDb().ListCollections(ctx, bson.M{"name": collectionName})
+	if err != nil {
+		log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while fetching options for collection: " + collectionName)
+		ClientErr.SetError(status_code.IMS135)
+		return nil, &ClientErr
+	}
+	var collectionSpecifications []struct {
+		Options struct {
+			Validator bson.M `bson:"validator"`
+		} `bson:"options"`
+	}
+	err = cur.All(ctx, &collectionSpecifications)
+	if err != nil || len(collectionSpecifications) == 0 {
+		log.Error("Inside " + methodName + " error occurred while decoding options for collection: " + collectionName)
+		ClientErr.SetError(status_code.IMS135)
+		return nil, &ClientErr
+	}
+	drift.IsValidatorDrift = !IsValidatorUpToDate(collectionSpecifications[0].Options.Validator, validation)
+
+	existingIndexes, listErr := ListInventoryIndexes(ctx, collectionName)
+	if listErr != nil {
+		return nil, listErr
+	}
+	desiredIndexes := make(map[string]request_dto.InventoryIdentifier)
+	for _, inventoryIdentifier := range inventoryIdentifiers {
+		indexName := constants.InventoryIndexPrefix + inventoryIdentifier.Key
+		desiredIndexes[indexName] = inventoryIdentifier
+		existingIndex, ok := existingIndexes[indexName]
+		if !ok {
+			drift.MissingIndexes = append(drift.MissingIndexes, indexName)
+		} else if !IsIndexUpToDate(existingIndex, inventoryIdentifier) {
+			drift.OutdatedIndexes = append(drift.OutdatedIndexes, indexName)
+		}
+	}
+	for indexName := range existingIndexes {
+		if _, ok := desiredIndexes[indexName]; !ok {
+			drift.ExtraIndexes = append(drift.ExtraIndexes, indexName)
+		}
+	}
+	sort.Strings(drift.MissingIndexes)
+	sort.Strings(drift.ExtraIndexes)
+	sort.Strings(drift.OutdatedIndexes)
+	return &drift, nil
+}
+
+// IsValidatorUpToDate : compares two validators independent of key order
+func IsValidatorUpToDate(existingValidator bson.M, desiredValidator bson.M) bool {
+	if len(existingValidator) == 0 && len(desiredValidator) == 0 {
+		return true
+	}
+	existingJson, existingErr := json.Marshal(existingValidator)
+	desiredJson, desiredErr := json.Marshal(desiredValidator)
+	if existingErr != nil || desiredErr != nil {
+		return false
+	}
+	return string(existingJson) == string(desiredJson)
+}
+
+// ValidateCollection : reports which existing documents would fail the given validator and which unique identifiers hold duplicates, without changing the collection
+func (s MongoStorageManager) ValidateCollection(ctx context.Context, collectionString string, validation bson.M, inventoryIdentifiers []request_dto.InventoryIdentifier) (*commonDto.SchemaValidationReport, *dto.ErrorResponseDto) {
+	methodName := "ValidateCollection"
+	log := logger.GetLogger()
+	var ClientErr dto.ErrorResponseDto
+
+	collectionName := constants.InventoryCollectionNamePrefix + collectionString
+	collection := db.GetDb().Collection(collectionName)
+	report := commonDto.SchemaValidationReport{
+		SampleInvalidDocuments: []commonDto.InvalidDocumentSample{},
+		FieldFailures:          []commonDto.FieldValidationFailure{},
+		DuplicateIndexes:       []commonDto.DuplicateIndexReport{},
+	}
+
+	totalDocuments, err := collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while counting documents for collection: " + collectionName)
+		ClientErr.SetError(status_code.IMS126)
+		return nil, &ClientErr
+	}
+	report.TotalDocuments = totalDocuments
+
+	invalidFilter := bson.M{"$nor": bson.A{validation}}
+	report.InvalidDocuments, err = collection.CountDocuments(ctx, invalidFilter)
+	if err != nil {
+		log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while applying validator on collection: " + collectionName)
+		ClientErr.SetError(status_code.IMS126)
+		if serverErr, ok := err.(mongo.ServerError); ok && (serverErr.HasErrorCode(constants.MongoBadValueErrorCode) || serverErr.HasErrorCode(constants.MongoFailedToParseErrorCode)) {
+			ClientErr.SetError(status_code.IMS125)
+		}
+		return nil, &ClientErr
+	}
+
+	if report.InvalidDocuments > 0 {
+		sampleOptions := options.Find().SetLimit(constants.SchemaValidationSampleSize).SetProjection(bson.M{"_id": 1, "id": 1})
+		cur, err := collection.Find(ctx, invalidFilter, sampleOptions)
+		if err != nil {
+			log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while sampling invalid documents for collection: " + collectionName)
+			ClientErr.SetError(status_code.IMS126)
+			return nil, &ClientErr
+		}
+		var samples []bson.M
+		err = cur.All(ctx, &samples)
+		if err != nil {
+			log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while decoding invalid documents for collection: " + collectionName)
+			ClientErr.SetError(status_code.IMS126)
+			return nil, &ClientErr
+		}
+
+		sampleObjectIds := bson.A{}
+		sampleIndex := make(map[interface{}]int)
+		for index, sample := range samples {
+			sampleObjectIds = append(sampleObjectIds, sample["_id"])
+			sampleIndex[sample["_id"]] = index
+			sampleId := sample["id"]
+			if sampleId == nil {
+				sampleId = sample["_id"]
+			}
+			report.SampleInvalidDocuments = append(report.SampleInvalidDocuments, commonDto.InvalidDocumentSample{
+				Id:            sampleId,
+				FailingFields: []string{},
+			})
+		}
+
+		for _, fieldCheck := range GetFieldValidationChecks(validation) {
+			count, err := collection.CountDocuments(ctx, fieldCheck.Filter)
+			if err != nil {
+				log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while validating field: " + fieldCheck.Field + " for collection: " + collectionName)
+				ClientErr.SetError(status_code.IMS126)
+				return nil, &ClientErr
+			}
+			if count == 0 {
+				continue
+			}
+			report.FieldFailures = append(report.FieldFailures, commonDto.FieldValidationFailure{
+				Field:   fieldCheck.Field,
+				Keyword: fieldCheck.Keyword,
+				Count:   count,
+			})
+
+			sampleFilter := bson.M{"$and": bson.A{bson.M{"_id": bson.M{"$in": sampleObjectIds}}, fieldCheck.Filter}}
+			cur, err := collection.Find(ctx, sampleFilter, options.Find().SetProjection(bson.M{"_id": 1}))
+			if err != nil {
+				log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while validating sample field: " + fieldCheck.Field + " for collection: " + collectionName)
+				ClientErr.SetError(status_code.IMS126)
+				return nil, &ClientErr
+			}
+			var failingSamples []bson.M
+			err = cur.All(ctx, &failingSamples)
+			if err != nil {
+				log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while decoding sample field: " + fieldCheck.Field + " for collection: " + collectionName)
+				ClientErr.SetError(status_code.IMS126)
+				return nil, &ClientErr
+			}
+			for _, failingSample := range failingSamples {
+				index := sampleIndex[failingSample["_id"]]
+				report.SampleInvalidDocuments[index].FailingFields = append(report.SampleInvalidDocuments[index].FailingFields, fieldCheck.Field+": "+fieldCheck.Keyword)
+			}
+		}
+	}
+
+	for _, inventoryIdentifier := range inventoryIdentifiers {
+		if !inventoryIdentifier.IsUnique {
+			continue
+		}
+		duplicateReport, duplicateErr := FindDuplicateValues(ctx, collectionName, inventoryIdentifier)
+		if duplicateErr != nil {
+			return nil, duplicateErr
+		}
+		if duplicateReport != nil {
+			report.DuplicateIndexes = append(report.DuplicateIndexes, *duplicateReport)
+		}
+	}
+
+	report.IsApplicable = report.InvalidDocuments == 0 && len(report.DuplicateIndexes) == 0
+	return &report, nil
+}
+
+// FieldValidationCheck : query matching documents which violate a single top level keyword of a $jsonSchema validator
+type FieldValidationCheck struct {
+	Field   string
+	Keyword string
+	Filter  bson.M
+}
+
+// GetFieldValidationChecks : splits the $jsonSchema of a validator into one check per required field and per property
+func GetFieldValidationChecks(validation bson.M) []FieldValidationCheck {
+	var fieldChecks []FieldValidationCheck
+	jsonSchema, ok := utils.AsMap(validation["$jsonSchema"])
+	if !ok {
+		return fieldChecks
+	}
+
+	if required, ok := utils.AsSlice(jsonSchema["required"]); ok {
+		for _, field := range required {
+			fieldName, _ := field.(string)
+			fieldChecks = append(fieldChecks, FieldValidationCheck{
+				Field:   fieldName,
+				Keyword: "required",
+				Filter:  bson.M{fieldName: bson.M{"$exists": false}},
+			})
+		}
+	}
+
+	if properties, ok := utils.AsMap(jsonSchema["properties"]); ok {
+		for fieldName, propertySchema := range properties {
+			fieldChecks = append(fieldChecks, FieldValidationCheck{
+				Field:   fieldName,
+				Keyword: "properties",
+				Filter: bson.M{"$nor": bson.A{
+					bson.M{"$jsonSchema": bson.M{"properties": bson.M{fieldName: propertySchema}}},
+				}},
+			})
+		}
+	}
+	return fieldChecks
+}
+
+// FindDuplicateValues : returns the values of the identifier fields shared by more than one document, nil when the unique index can be built
+func FindDuplicateValues(ctx context.Context, collectionName string, inventoryIdentifier request_dto.InventoryIdentifier) (*commonDto.DuplicateIndexReport, *dto.ErrorResponseDto) {
+	methodName := "FindDuplicateValues"
+	log := logger.GetLogger()
+	var ClientErr dto.ErrorResponseDto
+
+	fields := inventoryIdentifier.Fields()
+	key := strings.Join(fields, ",")
+	var groupId interface{} = "$" + fields[0]
+	if len(fields) > 1 {
+		compoundGroupId := bson.D{}
+		for _, field := range fields {
+			compoundGroupId = append(compoundGroupId, bson.E{Key: field, Value: "$" + field})
+		}
+		groupId = compoundGroupId
+	}
+
+	pipeline := mongo.Pipeline{}
+	if inventoryIdentifier.ExcludeDeleted {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"is_deleted": false}}})
+	}
+	pipeline = append(pipeline, mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": groupId, "count": bson.M{"$sum": 1}}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+		{{Key: "$facet", Value: bson.M{
+			"total":   bson.A{bson.M{"$count": "count"}},
+			"samples": bson.A{bson.M{"$limit": constants.SchemaValidationSampleSize}},
+		}}},
+	}...)
+	cur, err := db.GetDb().Collection(collectionName).Aggregate(ctx, pipeline)
+	if err != nil {
+		log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while finding duplicates of: " + key + " for collection: " + collectionName)
+		ClientErr.SetError(status_code.IMS126)
+		return nil, &ClientErr
+	}
+	var result []struct {
+		Total   []struct{ Count int64 } `bson:"total"`
+		Samples []bson.M                `bson:"samples"`
+	}
+	err = cur.All(ctx, &result)
+	if err != nil {
+		log.Error("Inside " + methodName + " error: " + err.Error() + " occurred while decoding duplicates of: " + key + " for collection: " + collectionName)
+		ClientErr.SetError(status_code.IMS126)
+		return nil, &ClientErr
+	}
+	if len(result) == 0 || len(result[0].Total) == 0 {
+		return nil, nil
+	}
+
+	duplicateReport := commonDto.DuplicateIndexReport{
+		IndexName:       constants.InventoryIndexPrefix + inventoryIdentifier.Key,
+		Key:             key,
+		DuplicateValues: result[0].Total[0].Count,
+		SampleValues:    []interface{}{},
+	}
+	for _, sample := range result[0].Samples {
+		duplicateReport.SampleValues = append(duplicateReport.SampleValues, sample["_id"])
+	}
+	return &duplicateReport, nil
+}
+
+// GetInventoryIndexes : builds the idx_<key> index model for an identifier
 func GetInventoryIndexes(inventoryIdentifier request_dto.InventoryIdentifier) mongo.IndexModel {
-	indexName := "idx_" + inventoryIdentifier.Key
-	isUnique := inventoryIdentifier.IsUnique
+	indexName := constants.InventoryIndexPrefix + inventoryIdentifier.Key
+	isUnique := inventoryIdentifier.IsUnique && !inventoryIdentifier.IsText()
 	indexOptions := options.IndexOptions{
 		Unique: &isUnique,
 		Name:   &indexName,
 	}
+	if inventoryIdentifier.ExcludeDeleted {
+		indexOptions.SetPartialFilterExpression(bson.M{"is_deleted": false})
+	}
+	if inventoryIdentifier.ExpireAfterSeconds != nil {
+		indexOptions.SetExpireAfterSeconds(*inventoryIdentifier.ExpireAfterSeconds)
+	}
+
+	var direction interface{} = 1
+	switch inventoryIdentifier.IndexType {
+	case request_dto.IndexTypeDescending:
+		direction = -1
+	case request_dto.IndexTypeText:
+		direction = request_dto.IndexTypeText
+	}
+	keys := bson.D{}
+	for _, field := range inventoryIdentifier.Fields() {
+		keys = append(keys, bson.E{Key: field, Value: direction})
+	}
 	return mongo.IndexModel{
-		Keys: bson.D{
-			{
-				Key:   inventoryIdentifier.Key,
-				Value: 1,
-			},
-		},
+		Keys:    keys,
 		Options: &indexOptions,
 	}
 
@@ -7,6 +7,8 @@ package mocks
 import (
 	context "context"
 	dto "inventory-system/common/pkg/dto"
+	dto0 "inventory-system/inventory-service/internal/common/dto"
+	request_dto "inventory-system/inventory-service/internal/common/dto/request_dto"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -37,7 +39,7 @@ func (m *MockIMongoStorageManager) EXPECT() *MockIMongoStorageManagerMockRecorde
 }
 
 // CreateCollection mocks base method.
-func (m *MockIMongoStorageManager) CreateCollection(arg0 context.Context, arg1 string, arg2 primitive.M, arg3 []string) *dto.ErrorResponseDto {
+func (m *MockIMongoStorageManager) CreateCollection(arg0 context.Context, arg1 string, arg2 primitive.M, arg3 []request_dto.InventoryIdentifier) *dto.ErrorResponseDto {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateCollection", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(*dto.ErrorResponseDto)
@@ -49,3 +51,76 @@ func (mr *MockIMongoStorageManagerMockRecorder) CreateCollection(arg0, arg1, arg
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockIMongoStorageManager)(nil).CreateCollection), arg0, arg1, arg2, arg3)
 }
+
+// DropCollection mocks base method.
+func (m *MockIMongoStorageManager) DropCollection(arg0 context.Context, arg1 string) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropCollection", arg0, arg1)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// DropCollection indicates an expected call of DropCollection.
+func (mr *MockIMongoStorageManagerMockRecorder) DropCollection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropCollection", reflect.TypeOf((*MockIMongoStorageManager)(nil).DropCollection), arg0, arg1)
+}
+
+// InspectCollection mocks base method.
+func (m *MockIMongoStorageManager) InspectCollection(arg0 context.Context, arg1 string, arg2 primitive.M, arg3 []request_dto.InventoryIdentifier) (*dto0.InventoryDrift, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectCollection", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto0.InventoryDrift)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// InspectCollection indicates an expected call of InspectCollection.
+func (mr *MockIMongoStorageManagerMockRecorder) InspectCollection(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectCollection", reflect.TypeOf((*MockIMongoStorageManager)(nil).InspectCollection), arg0, arg1, arg2, arg3)
+}
+
+// ListInventoryCollections mocks base method.
+func (m *MockIMongoStorageManager) ListInventoryCollections(arg0 context.Context) ([]string, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInventoryCollections", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// ListInventoryCollections indicates an expected call of ListInventoryCollections.
+func (mr *MockIMongoStorageManagerMockRecorder) ListInventoryCollections(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInventoryCollections", reflect.TypeOf((*MockIMongoStorageManager)(nil).ListInventoryCollections), arg0)
+}
+
+// UpdateCollection mocks base method.
+func (m *MockIMongoStorageManager) UpdateCollection(arg0 context.Context, arg1 string, arg2 primitive.M, arg3 string, arg4 []request_dto.InventoryIdentifier) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCollection", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// UpdateCollection indicates an expected call of UpdateCollection.
+func (mr *MockIMongoStorageManagerMockRecorder) UpdateCollection(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCollection", reflect.TypeOf((*MockIMongoStorageManager)(nil).UpdateCollection), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ValidateCollection mocks base method.
+func (m *MockIMongoStorageManager) ValidateCollection(arg0 context.Context, arg1 string, arg2 primitive.M, arg3 []request_dto.InventoryIdentifier) (*dto0.SchemaValidationReport, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateCollection", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto0.SchemaValidationReport)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// ValidateCollection indicates an expected call of ValidateCollection.
+func (mr *MockIMongoStorageManagerMockRecorder) ValidateCollection(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCollection", reflect.TypeOf((*MockIMongoStorageManager)(nil).ValidateCollection), arg0, arg1, arg2, arg3)
+}
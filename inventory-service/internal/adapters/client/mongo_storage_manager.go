@@ -4,10 +4,16 @@ import (
 	"context"
 	"go.mongodb.org/mongo-driver/bson"
 	"inventory-system/common/pkg/dto"
+	commonDto "inventory-system/inventory-service/internal/common/dto"
 	"inventory-system/inventory-service/internal/common/dto/request_dto"
 )
 
 //go:generate mockgen -destination=mocks/mock_mongo_storage_manager.go -package=mocks . IMongoStorageManager
 type IMongoStorageManager interface {
 	CreateCollection(ctx context.Context, collectionString string, validation bson.M, inventoryIdentifier []request_dto.InventoryIdentifier) *dto.ErrorResponseDto
+	ValidateCollection(ctx context.Context, collectionString string, validation bson.M, inventoryIdentifiers []request_dto.InventoryIdentifier) (*commonDto.SchemaValidationReport, *dto.ErrorResponseDto)
+	DropCollection(ctx context.Context, collectionString string) *dto.ErrorResponseDto
+	UpdateCollection(ctx context.Context, collectionString string, validation bson.M, validationLevel string, inventoryIdentifiers []request_dto.InventoryIdentifier) *dto.ErrorResponseDto
+	ListInventoryCollections(ctx context.Context) ([]string, *dto.ErrorResponseDto)
+	InspectCollection(ctx context.Context, collectionString string, validation bson.M, inventoryIdentifiers []request_dto.InventoryIdentifier) (*commonDto.InventoryDrift, *dto.ErrorResponseDto)
 }
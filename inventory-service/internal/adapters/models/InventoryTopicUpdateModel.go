@@ -1,8 +1,12 @@
 package models
 
+import "time"
+
 type InventoryTopicUpdateRequest struct {
 	TopicName   string         `json:"topic_name" bson:"topic_name"`
 	Resources   []*interface{} `json:"resources" bson:"resources"`
 	Description string         `json:"description" bson:"description"`
 	Assessments []*interface{} `json:"assessments" bson:"assessments"`
+	UpdatedAt   time.Time      `json:"updated_at" bson:"updated_at"`
+	UpdatedBy   string         `json:"updated_by" bson:"updated_by"`
 }
@@ -2,4 +2,5 @@ package models
 
 type RemoveInventoryItem struct {
 	ItemId string `json:"item_id" bson:"item_id"`
+	Reason string `json:"reason,omitempty" bson:"reason,omitempty"`
 }
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"inventory-system/inventory-service/internal/common/dto/request_dto"
+	"time"
+)
+
+type InventoryConfigurationRevision struct {
+	InventoryName        string                            `bson:"inventory_name" json:"inventory_name"`
+	Version              int64                             `bson:"version" json:"version"`
+	JsonSchema           bson.M                            `bson:"json_schema" json:"json_schema"`
+	SchemaDialect        string                            `bson:"schema_dialect,omitempty" json:"schema_dialect,omitempty"`
+	SourceJsonSchema     bson.M                            `bson:"source_json_schema,omitempty" json:"source_json_schema,omitempty"`
+	UnenforcedKeywords   []string                          `bson:"unenforced_keywords,omitempty" json:"unenforced_keywords,omitempty"`
+	IdStrategy           string                            `bson:"id_strategy,omitempty" json:"id_strategy,omitempty"`
+	IdPrefix             string                            `bson:"id_prefix,omitempty" json:"id_prefix,omitempty"`
+	Pagination           bool                              `bson:"pagination" json:"pagination"`
+	PaginationSettings   *request_dto.PaginationSettings   `bson:"pagination_settings,omitempty" json:"pagination_settings,omitempty"`
+	RetentionPolicy      *request_dto.RetentionPolicy      `bson:"retention_policy,omitempty" json:"retention_policy,omitempty"`
+	SearchableFields     []string                          `bson:"searchable_fields,omitempty" json:"searchable_fields,omitempty"`
+	InventoryIdentifiers []request_dto.InventoryIdentifier `bson:"inventory_identifiers" json:"inventory_identifiers"`
+	ValidationLevel      string                            `bson:"validation_level" json:"validation_level"`
+	CreatedBy            string                            `bson:"created_by" json:"created_by"`
+	CreatedOn            time.Time                         `bson:"created_on" json:"created_on"`
+}
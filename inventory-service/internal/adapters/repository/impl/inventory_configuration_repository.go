@@ -38,6 +38,8 @@ func (c InventoryConfigurationRepository) CreateNewConfiguration(ctx context.Con
 	}
 	createdOn := time.Now()
 	configurationModel.CreatedOn = createdOn
+	configurationModel.ValidationLevel = constants.DefaultValidationLevel
+	configurationModel.Version = 1
 
 	_, err = db.GetDb().Collection(constants.InventoryConfigurationCollectionName).InsertOne(ctx, configurationModel)
 	if err != nil {
@@ -53,6 +55,23 @@ func (c InventoryConfigurationRepository) CreateNewConfiguration(ctx context.Con
 		return &adapterErr
 	}
 
+	//Version 1 is recorded as a revision as well so the history holds every version of the configuration
+	createdConfiguration, err := utils.TypeConverter[ConfigurationServiceDto.InventoryConfiguration](configurationModel)
+	if err != nil {
+		log.Error("Inside " + methodName + " error when converting model to dto, json marshal/unmarshal failed: " + baseConfiguration.InventoryName)
+		adapterErr.SetError(status_code.IMS500)
+		c.RemoveInventoryConfigurationByName(ctx, baseConfiguration.InventoryName)
+		return &adapterErr
+	}
+	createdConfiguration.UpdatedBy = createdConfiguration.CreatedBy
+	createdConfiguration.UpdatedOn = createdOn
+	revisionErr := c.CreateInventoryConfigurationRevision(ctx, *createdConfiguration)
+	if revisionErr != nil {
+		log.Error("Inside " + methodName + " error while saving first revision, removing inventory configuration: " + baseConfiguration.InventoryName)
+		c.RemoveInventoryConfigurationByName(ctx, baseConfiguration.InventoryName)
+		return revisionErr
+	}
+
 	return nil
 }
 func (c InventoryConfigurationRepository) FetchInventoryConfigurationByName(ctx context.Context, inventoryName string) (*ConfigurationServiceDto.InventoryConfiguration, *dto.ErrorResponseDto) {
@@ -136,3 +155,157 @@ func (c InventoryConfigurationRepository) DeleteInventoryConfigurationByName(ctx
 	log.Info("Inside ", methodName, " success while deleting inventory configuration for inventoryName: ", inventoryName)
 	return nil
 }
+
+// UpdateInventoryConfigurationByName : updates the schema and identifiers only if the stored version still matches currentVersion
+func (c InventoryConfigurationRepository) UpdateInventoryConfigurationByName(ctx context.Context, inventoryName string, currentVersion int64, updateConfiguration request_dto.UpdateConfigurationRequestBody) (*ConfigurationServiceDto.InventoryConfiguration, *dto.ErrorResponseDto) {
+	methodName := "UpdateInventoryConfigurationByName"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+
+	filter := bson.M{"inventory_name": inventoryName, "is_deleted": false, "version": currentVersion}
+	if currentVersion == 0 {
+		//configurations created before versioning have no version field
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
+	}
+	updateBody := bson.M{
+		"$set": bson.M{
+			"json_schema":           updateConfiguration.JsonSchema,
+			"schema_dialect":        updateConfiguration.SchemaDialect,
+			"source_json_schema":    updateConfiguration.SourceJsonSchema,
+			"unenforced_keywords":   updateConfiguration.UnenforcedKeywords,
+			"inventory_identifiers": updateConfiguration.InventoryIdentifiers,
+			"validation_level":      updateConfiguration.ValidationLevel,
+			"id_strategy":           updateConfiguration.IdStrategy,
+			"id_prefix":             updateConfiguration.IdPrefix,
+			"pagination":            updateConfiguration.Pagination,
+			"pagination_settings":   updateConfiguration.PaginationSettings,
+			"retention_policy":      updateConfiguration.RetentionPolicy,
+			"searchable_fields":     updateConfiguration.SearchableFields,
+			"updated_by":            updateConfiguration.UpdatedBy,
+			"updated_on":            time.Now(),
+			"version":               currentVersion + 1,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After).SetProjection(bson.M{"_id": 0})
+
+	var inventoryConfiguration ConfigurationServiceDto.InventoryConfiguration
+	err := db.GetDb().Collection(constants.InventoryConfigurationCollectionName).FindOneAndUpdate(ctx, filter, updateBody, opts).Decode(&inventoryConfiguration)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			log.Info("Inside "+methodName+" version mismatch while updating inventory configuration for filter : ", filter)
+			adapterErr.SetError(status_code.IMS123)
+			return nil, &adapterErr
+		}
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while updating inventory configuration: ", inventoryName)
+		adapterErr.SetError(status_code.IMS122)
+		return nil, &adapterErr
+	}
+	return &inventoryConfiguration, nil
+}
+
+func (c InventoryConfigurationRepository) CreateInventoryConfigurationRevision(ctx context.Context, inventoryConfiguration ConfigurationServiceDto.InventoryConfiguration) *dto.ErrorResponseDto {
+	methodName := "CreateInventoryConfigurationRevision"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+
+	revision := models.InventoryConfigurationRevision{
+		InventoryName:        inventoryConfiguration.InventoryName,
+		Version:              inventoryConfiguration.Version,
+		JsonSchema:           inventoryConfiguration.JsonSchema,
+		SchemaDialect:        inventoryConfiguration.SchemaDialect,
+		SourceJsonSchema:     inventoryConfiguration.SourceJsonSchema,
+		UnenforcedKeywords:   inventoryConfiguration.UnenforcedKeywords,
+		InventoryIdentifiers: inventoryConfiguration.InventoryIdentifiers,
+		ValidationLevel:      inventoryConfiguration.ValidationLevel,
+		IdStrategy:           inventoryConfiguration.IdStrategy,
+		IdPrefix:             inventoryConfiguration.IdPrefix,
+		Pagination:           inventoryConfiguration.Pagination,
+		PaginationSettings:   inventoryConfiguration.PaginationSettings,
+		RetentionPolicy:      inventoryConfiguration.RetentionPolicy,
+		SearchableFields:     inventoryConfiguration.SearchableFields,
+		CreatedBy:            inventoryConfiguration.UpdatedBy,
+		CreatedOn:            inventoryConfiguration.UpdatedOn,
+	}
+	_, err := db.GetDb().Collection(constants.InventoryConfigurationRevisionCollectionName).InsertOne(ctx, revision)
+	if err != nil {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while saving revision: ", revision.Version, " for inventory configuration: ", revision.InventoryName)
+		adapterErr.SetError(status_code.IMS124)
+		return &adapterErr
+	}
+	return nil
+}
+
+func (c InventoryConfigurationRepository) RestoreInventoryConfigurationByName(ctx context.Context, inventoryName string) *dto.ErrorResponseDto {
+	methodName := "RestoreInventoryConfigurationByName"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+
+	filter := bson.M{"inventory_name": inventoryName, "is_deleted": true}
+	updateBody := bson.M{"$set": bson.M{"is_deleted": false}}
+	resp, err := db.GetDb().Collection(constants.InventoryConfigurationCollectionName).UpdateOne(ctx, filter, updateBody)
+	if err != nil {
+		log.Error("Inside ", methodName, " error: ", err.Error(), " while restoring inventory configuration for inventoryName: ", inventoryName)
+		adapterErr.SetError(status_code.IMS130)
+		return &adapterErr
+	}
+	if resp.MatchedCount == 0 {
+		log.Info("Inside ", methodName, " no deleted inventory configuration found for inventoryName: ", inventoryName)
+		adapterErr.SetError(status_code.IMS127)
+		return &adapterErr
+	}
+	log.Info("Inside ", methodName, " success while restoring inventory configuration for inventoryName: ", inventoryName)
+	return nil
+}
+
+// PurgeInventoryConfigurationByName : permanently removes a soft deleted configuration along with its revisions
+func (c InventoryConfigurationRepository) PurgeInventoryConfigurationByName(ctx context.Context, inventoryName string) *dto.ErrorResponseDto {
+	methodName := "PurgeInventoryConfigurationByName"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+
+	filter := bson.M{"inventory_name": inventoryName, "is_deleted": true}
+	resp, err := db.GetDb().Collection(constants.InventoryConfigurationCollectionName).DeleteOne(ctx, filter)
+	if err != nil {
+		log.Error("Inside ", methodName, " error: ", err.Error(), " while purging inventory configuration for inventoryName: ", inventoryName)
+		adapterErr.SetError(status_code.IMS131)
+		return &adapterErr
+	}
+	if resp.DeletedCount == 0 {
+		log.Info("Inside ", methodName, " no deleted inventory configuration found for inventoryName: ", inventoryName)
+		adapterErr.SetError(status_code.IMS127)
+		return &adapterErr
+	}
+
+	_, err = db.GetDb().Collection(constants.InventoryConfigurationRevisionCollectionName).DeleteMany(ctx, bson.M{"inventory_name": inventoryName})
+	if err != nil {
+		log.Error("Inside ", methodName, " error: ", err.Error(), " while purging revisions for inventoryName: ", inventoryName)
+		adapterErr.SetError(status_code.IMS131)
+		return &adapterErr
+	}
+	log.Info("Inside ", methodName, " success while purging inventory configuration for inventoryName: ", inventoryName)
+	return nil
+}
+
+// RemoveInventoryConfigurationByName : removes the configuration document and its revisions regardless of its state, used to
+// roll back a failed create
+func (c InventoryConfigurationRepository) RemoveInventoryConfigurationByName(ctx context.Context, inventoryName string) *dto.ErrorResponseDto {
+	methodName := "RemoveInventoryConfigurationByName"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+
+	filter := bson.M{"inventory_name": inventoryName}
+	_, err := db.GetDb().Collection(constants.InventoryConfigurationCollectionName).DeleteOne(ctx, filter)
+	if err != nil {
+		log.Error("Inside ", methodName, " error: ", err.Error(), " while removing inventory configuration for inventoryName: ", inventoryName)
+		adapterErr.SetError(status_code.IMS133)
+		return &adapterErr
+	}
+	_, err = db.GetDb().Collection(constants.InventoryConfigurationRevisionCollectionName).DeleteMany(ctx, filter)
+	if err != nil {
+		log.Error("Inside ", methodName, " error: ", err.Error(), " while removing revisions for inventoryName: ", inventoryName)
+		adapterErr.SetError(status_code.IMS133)
+		return &adapterErr
+	}
+	log.Info("Inside ", methodName, " success while removing inventory configuration for inventoryName: ", inventoryName)
+	return nil
+}
@@ -7,9 +7,11 @@ import (
 	"inventory-system/common/pkg/logger"
 	"inventory-system/inventory-service/internal/adapters/db"
 	"inventory-system/inventory-service/internal/adapters/models"
+	"inventory-system/inventory-service/internal/common/cursor"
 	commonDto "inventory-system/inventory-service/internal/common/dto"
 	"inventory-system/inventory-service/internal/common/status_code"
 	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -58,15 +60,100 @@ func (c InventoryRepository) CreateNewInventoryGivenInventoryName(ctx context.Co
 	return nil
 }
 
-func (c InventoryRepository) FetchInventory(ctx context.Context, inventoryName string, uniqueKey string, uniqueValue string) (bson.M, *dto.ErrorResponseDto) {
+// BulkInsertInventory : writes the items with one bulk write and returns the error of every item that was not written, keyed by its position in items.
+// In ordered mode the write stops at the first failure
+func (c InventoryRepository) BulkInsertInventory(ctx context.Context, items []interface{}, inventoryName string, ordered bool) (map[int]*dto.ErrorResponseDto, *dto.ErrorResponseDto) {
+	methodName := "BulkInsertInventory"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+
+	collectionName := constants.InventoryCollectionNamePrefix + inventoryName
+	writeModels := make([]mongo.WriteModel, 0, len(items))
+	for _, item := range items {
+		writeModels = append(writeModels, mongo.NewInsertOneModel().SetDocument(item))
+	}
+
+	_, err := db.GetDb().Collection(collectionName).BulkWrite(ctx, writeModels, options.BulkWrite().SetOrdered(ordered))
+	itemErrors, isItemErrors := bulkWriteItemErrors(methodName, collectionName, err)
+	if !isItemErrors {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while bulk inserting into: ", collectionName)
+		adapterErr.SetError(status_code.IMS101)
+		return nil, &adapterErr
+	}
+	return itemErrors, nil
+}
+
+// BulkWriteInventory : unordered bulk write of inserts and replaces of live items, a replace keeps the id and creation
+// stamps of the item and increments its version. Returns the errors of the failed writes by their position
+func (c InventoryRepository) BulkWriteInventory(ctx context.Context, inventoryName string, writes []commonDto.ItemWrite) (map[int]*dto.ErrorResponseDto, *dto.ErrorResponseDto) {
+	methodName := "BulkWriteInventory"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+
+	collectionName := constants.InventoryCollectionNamePrefix + inventoryName
+	writeModels := make([]mongo.WriteModel, 0, len(writes))
+	for _, write := range writes {
+		if write.Filter == nil {
+			writeModels = append(writeModels, mongo.NewInsertOneModel().SetDocument(write.Item))
+			continue
+		}
+		filter := bson.M{"is_deleted": false}
+		for key, value := range write.Filter {
+			filter[key] = value
+		}
+		writeModels = append(writeModels, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(replacementPipeline(write.Item)))
+	}
+
+	_, err := db.GetDb().Collection(collectionName).BulkWrite(ctx, writeModels, options.BulkWrite().SetOrdered(false))
+	itemErrors, isItemErrors := bulkWriteItemErrors(methodName, collectionName, err)
+	if !isItemErrors {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while bulk writing into: ", collectionName)
+		adapterErr.SetError(status_code.IMS101)
+		return nil, &adapterErr
+	}
+	return itemErrors, nil
+}
+
+// bulkWriteItemErrors : errors of the failed writes of a bulk write by their position, false when the whole bulk write failed
+func bulkWriteItemErrors(methodName string, collectionName string, err error) (map[int]*dto.ErrorResponseDto, bool) {
+	log := logger.GetLogger()
+	itemErrors := map[int]*dto.ErrorResponseDto{}
+	if err == nil {
+		return itemErrors, true
+	}
+	bulkWriteErr, isBulkWriteErr := err.(mongo.BulkWriteException)
+	if !isBulkWriteErr || bulkWriteErr.WriteConcernError != nil {
+		return nil, false
+	}
+	for _, writeErr := range bulkWriteErr.WriteErrors {
+		var itemErr dto.ErrorResponseDto
+		switch writeErr.Code {
+		case constants.MongoDuplicateEntryErrorCode:
+			itemErr.SetError(status_code.IMS108)
+		case constants.MongoDocumentValidationErrorCode:
+			itemErr.SetError(status_code.IMS109)
+		default:
+			itemErr.SetError(status_code.IMS101)
+		}
+		log.Error("Inside "+methodName+" error: ", writeErr.Message, " while writing item ", writeErr.Index, " into: ", collectionName)
+		itemErrors[writeErr.Index] = &itemErr
+	}
+	return itemErrors, true
+}
+
+// FetchInventory : the live item matching the filter, a nil projection returns the whole item
+func (c InventoryRepository) FetchInventory(ctx context.Context, inventoryName string, uniqueFilter bson.M, projection bson.M) (bson.M, *dto.ErrorResponseDto) {
 	methodName := "FetchInventory"
 	log := logger.GetLogger()
 	var adapterErr dto.ErrorResponseDto
 	collectionName := constants.InventoryCollectionNamePrefix + inventoryName
 
-	filter := bson.M{uniqueKey: uniqueValue, "is_deleted": false}
+	filter := bson.M{"is_deleted": false}
+	for key, value := range uniqueFilter {
+		filter[key] = value
+	}
 	var item bson.M
-	err := db.GetDb().Collection(collectionName).FindOne(ctx, filter, options.FindOne().SetProjection(bson.M{"_id": 0})).Decode(&item)
+	err := db.GetDb().Collection(collectionName).FindOne(ctx, filter, options.FindOne().SetProjection(itemProjection(projection))).Decode(&item)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			log.Info("Inside "+methodName+" no documents exists in inventory for filter : ", filter)
@@ -80,7 +167,170 @@ func (c InventoryRepository) FetchInventory(ctx context.Context, inventoryName s
 	return item, nil
 }
 
-func (c InventoryRepository) RemoveItemFromInventory(ctx context.Context, RemoveItemModel *models.RemoveInventoryItem, InventoryName string) *dto.ErrorResponseDto {
+// FetchInventoryById : the item with the id whether it is deleted or not
+func (c InventoryRepository) FetchInventoryById(ctx context.Context, inventoryName string, id string) (bson.M, *dto.ErrorResponseDto) {
+	methodName := "FetchInventoryById"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+	collectionName := constants.InventoryCollectionNamePrefix + inventoryName
+
+	var item bson.M
+	err := db.GetDb().Collection(collectionName).FindOne(ctx, bson.M{"id": id}, options.FindOne().SetProjection(bson.M{"_id": 0})).Decode(&item)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			log.Info("Inside "+methodName+" no item exists in inventory with id : ", id)
+			adapterErr.SetError(status_code.IMS404)
+			return nil, &adapterErr
+		}
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while fetching item: ", id)
+		adapterErr.SetError(status_code.IMS110)
+		return nil, &adapterErr
+	}
+	return item, nil
+}
+
+// ReplaceInventoryItem : atomically replaces the live item matching the filter with the given fields, keeping its id, creation stamps and incrementing its version.
+// Returns the stored item, or nil when no item matched
+func (c InventoryRepository) ReplaceInventoryItem(ctx context.Context, inventoryName string, uniqueFilter bson.M, item bson.M) (bson.M, *dto.ErrorResponseDto) {
+	methodName := "ReplaceInventoryItem"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+	collectionName := constants.InventoryCollectionNamePrefix + inventoryName
+
+	filter := bson.M{"is_deleted": false}
+	for key, value := range uniqueFilter {
+		filter[key] = value
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After).SetProjection(bson.M{"_id": 0})
+	var storedItem bson.M
+	err := db.GetDb().Collection(collectionName).FindOneAndUpdate(ctx, filter, replacementPipeline(item), opts).Decode(&storedItem)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			log.Error("Inside "+methodName+" error duplicate entry occurred when trying to replace item ", filter)
+			adapterErr.SetError(status_code.IMS108)
+			return nil, &adapterErr
+		}
+		if strings.Contains(err.Error(), "Document failed validation") {
+			log.Error("Inside ", methodName, "error : ", err.Error(), " occurred while replacing item: ", filter)
+			adapterErr.SetError(status_code.IMS109)
+			return nil, &adapterErr
+		}
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while replacing the item: ", filter)
+		adapterErr.SetError(status_code.IMS101)
+		return nil, &adapterErr
+	}
+	return storedItem, nil
+}
+
+// replacementPipeline : update replacing an item by the given one, keeping its id and creation stamps and incrementing its version
+func replacementPipeline(item bson.M) mongo.Pipeline {
+	preservedFields := bson.M{"_id": "$_id"}
+	for _, field := range []string{constants.ItemIdField, constants.ItemCreatedAtField, constants.ItemCreatedByField} {
+		preservedFields[field] = "$" + field
+	}
+	preservedFields[constants.ItemVersionField] = bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$" + constants.ItemVersionField, 0}}, 1}}
+	//client values are wrapped in $literal so strings starting with $ are not read as field paths
+	return mongo.Pipeline{{{Key: "$replaceWith", Value: bson.M{"$mergeObjects": bson.A{bson.M{"$literal": item}, preservedFields}}}}}
+}
+
+// RemoveItemsUpdatedBefore : marks the live items last written before the given time, or never stamped, as deleted with the
+// given deletion metadata. Items are removed by id ImportBatchSize at a time, an item written again since it was read is kept.
+// Each batch is passed to removed as it was before
+func (c InventoryRepository) RemoveItemsUpdatedBefore(ctx context.Context, inventoryName string, updatedBefore time.Time, updateMetadata bson.M, removed func(items []bson.M)) (int64, *dto.ErrorResponseDto) {
+	methodName := "RemoveItemsUpdatedBefore"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+	collectionName := constants.InventoryCollectionNamePrefix + inventoryName
+	collection := db.GetDb().Collection(collectionName)
+
+	removeFields := bson.M{"is_deleted": true}
+	for key, value := range updateMetadata {
+		removeFields[key] = value
+	}
+	isStale := bson.M{"$not": bson.M{"$gte": updatedBefore}}
+	query := bson.M{"is_deleted": false, constants.ItemUpdatedAtField: isStale}
+	cur, err := collection.Find(ctx, query, options.Find().SetProjection(bson.M{"_id": 0}).SetBatchSize(constants.ImportBatchSize))
+	if err != nil {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while finding the items to remove from: ", inventoryName)
+		adapterErr.SetError(status_code.IMS110)
+		return 0, &adapterErr
+	}
+	defer cur.Close(ctx)
+
+	var removedCount int64
+	var batch []bson.M
+	removeBatch := func() *dto.ErrorResponseDto {
+		ids := make(bson.A, 0, len(batch))
+		for _, item := range batch {
+			ids = append(ids, item[constants.ItemIdField])
+		}
+		result, err := collection.UpdateMany(ctx, bson.M{constants.ItemIdField: bson.M{"$in": ids}, "is_deleted": false, constants.ItemUpdatedAtField: isStale}, bson.M{"$set": removeFields, "$inc": bson.M{constants.ItemVersionField: 1}})
+		if err != nil {
+			log.Error("Inside "+methodName+" error: ", err.Error(), " while removing items from: ", inventoryName)
+			adapterErr.SetError(status_code.IMS500)
+			return &adapterErr
+		}
+		removedCount += result.ModifiedCount
+		if result.ModifiedCount < int64(len(batch)) {
+			//items written since they were read are still live, they are not reported as removed
+			var liveItems []bson.M
+			liveCur, err := collection.Find(ctx, bson.M{constants.ItemIdField: bson.M{"$in": ids}, "is_deleted": false}, options.Find().SetProjection(bson.M{"_id": 0, constants.ItemIdField: 1}))
+			if err == nil {
+				err = liveCur.All(ctx, &liveItems)
+			}
+			if err != nil {
+				log.Error("Inside "+methodName+" error: ", err.Error(), " while reading the items kept in: ", inventoryName)
+				adapterErr.SetError(status_code.IMS306)
+				return &adapterErr
+			}
+			liveIds := map[interface{}]bool{}
+			for _, item := range liveItems {
+				liveIds[item[constants.ItemIdField]] = true
+			}
+			removedItems := batch[:0]
+			for _, item := range batch {
+				if !liveIds[item[constants.ItemIdField]] {
+					removedItems = append(removedItems, item)
+				}
+			}
+			batch = removedItems
+		}
+		removed(batch)
+		batch = nil
+		return nil
+	}
+	for cur.Next(ctx) {
+		item := bson.M{}
+		if err = cur.Decode(&item); err != nil {
+			log.Error("Inside "+methodName+" error: ", err.Error(), " while decoding item to remove from: ", inventoryName)
+			adapterErr.SetError(status_code.IMS306)
+			return removedCount, &adapterErr
+		}
+		batch = append(batch, item)
+		if len(batch) == constants.ImportBatchSize {
+			if errDto := removeBatch(); errDto != nil {
+				return removedCount, errDto
+			}
+		}
+	}
+	if err = cur.Err(); err != nil {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while reading items to remove from: ", inventoryName)
+		adapterErr.SetError(status_code.IMS306)
+		return removedCount, &adapterErr
+	}
+	if len(batch) > 0 {
+		if errDto := removeBatch(); errDto != nil {
+			return removedCount, errDto
+		}
+	}
+	return removedCount, nil
+}
+
+// RemoveItemFromInventory : marks the live item as deleted with the given deletion metadata and returns it as it was before, nil when there is no such item
+func (c InventoryRepository) RemoveItemFromInventory(ctx context.Context, RemoveItemModel *models.RemoveInventoryItem, InventoryName string, updateMetadata bson.M) (bson.M, *dto.ErrorResponseDto) {
 	methodName := "RemoveItemFromInventory"
 	log := logger.GetLogger()
 	log.Info("Inside " + methodName)
@@ -90,14 +340,23 @@ func (c InventoryRepository) RemoveItemFromInventory(ctx context.Context, Remove
 	log.Info("Inventory Name", InventoryName)
 	collectionName := constants.InventoryCollectionNamePrefix + InventoryName
 	log.Info("Collection Name", collectionName)
-	isDeleted, Err := db.GetDb().Collection(collectionName).UpdateOne(ctx, bson.M{"id": RemoveItemModel.ItemId}, bson.M{"$set": bson.M{"is_deleted": true}})
+	removeFields := bson.M{"is_deleted": true}
+	for key, value := range updateMetadata {
+		removeFields[key] = value
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before).SetProjection(bson.M{"_id": 0})
+	var removedItem bson.M
+	Err := db.GetDb().Collection(collectionName).FindOneAndUpdate(ctx, bson.M{"id": RemoveItemModel.ItemId, "is_deleted": false}, bson.M{"$set": removeFields, "$inc": bson.M{constants.ItemVersionField: 1}}, opts).Decode(&removedItem)
 	if Err != nil {
+		if Err == mongo.ErrNoDocuments {
+			log.Info("Inside "+methodName+" no item to remove with id : ", RemoveItemModel.ItemId)
+			return nil, nil
+		}
 		log.Info("Error while removing the item from the Inventory with Inventory "+InventoryName, "And error is ", Err)
 		adapterErr.SetError(status_code.IMS500)
-		return &adapterErr
+		return nil, &adapterErr
 	}
-	log.Info("Delete Feedback", isDeleted)
-	return nil
+	return removedItem, nil
 }
 func (c InventoryRepository) RemoveSubjectTopicsByLessonNameAndSubjectId(ctx context.Context, SubjectRemoveModel *models.RemoveSubjectRequestModel, Type string) *dto.ErrorResponseDto {
 	methodName := "RemoveSubjectTopicsByLessonNameAndSubjectId"
@@ -122,7 +381,7 @@ func RemoveSubjectPartially(ctx context.Context, SubjectRemoveModel *models.Remo
 	log := logger.GetLogger()
 	log.Info("Inside " + methodName)
 	var adapterErr dto.ErrorResponseDto
-	collectionName := constants.InventoryCollectionNamePrefix + "topics"
+	collectionName := constants.InventoryCollectionNamePrefix + constants.TopicsInventoryName
 
 	isDeleted, Err := db.GetDb().Collection(collectionName).DeleteMany(ctx, bson.M{"lesson_name": SubjectRemoveModel.LessonName, "subject_id": SubjectRemoveModel.SubjectId})
 	if Err != nil {
@@ -144,7 +403,7 @@ func RemoveSubjectFull(ctx context.Context, SubjectRemoveModel *models.RemoveSub
 	log := logger.GetLogger()
 	log.Info("Inside " + methodName)
 	var adapterErr dto.ErrorResponseDto
-	collectionName := constants.InventoryCollectionNamePrefix + "topics"
+	collectionName := constants.InventoryCollectionNamePrefix + constants.TopicsInventoryName
 	isSubjectRemoved, DbErr := db.GetDb().Collection("Inventory-subjects").DeleteOne(ctx, bson.M{"id": SubjectRemoveModel.SubjectId})
 	if DbErr != nil {
 		log.Info("Error while removing Subject by its id", SubjectRemoveModel.SubjectId)
@@ -173,17 +432,18 @@ func RemoveSubjectFull(ctx context.Context, SubjectRemoveModel *models.RemoveSub
 	return nil
 }
 
-func (c InventoryRepository) UpdateInventoryTopic(ctx context.Context, InventoryTopicUpdateModel *models.InventoryTopicUpdateRequest, TopicId string) *dto.ErrorResponseDto {
+// UpdateInventoryTopic : updates the topic while it is still at currentVersion, IMS141 when it changed since it was read
+func (c InventoryRepository) UpdateInventoryTopic(ctx context.Context, InventoryTopicUpdateModel *models.InventoryTopicUpdateRequest, TopicId string, currentVersion interface{}) *dto.ErrorResponseDto {
 	methodName := "UpdateInventoryTopic"
 	log := logger.GetLogger()
 	log.Info("Inside " + methodName)
 	var adapterErr dto.ErrorResponseDto
 	log.Info("Topic Update request", InventoryTopicUpdateModel)
-	collectionName := constants.InventoryCollectionNamePrefix + "topics"
+	collectionName := constants.InventoryCollectionNamePrefix + constants.TopicsInventoryName
 	log.Info("Collection Name", collectionName)
 
 	log.Info("Topic Id", TopicId)
-	Update, DbErr := db.GetDb().Collection(collectionName).UpdateOne(ctx, bson.M{"id": TopicId}, bson.M{"$set": bson.M{"resources": InventoryTopicUpdateModel.Resources, "topic_name": InventoryTopicUpdateModel.TopicName, "description": InventoryTopicUpdateModel.Description, "assessments": InventoryTopicUpdateModel.Assessments}})
+	Update, DbErr := db.GetDb().Collection(collectionName).UpdateOne(ctx, bson.M{"id": TopicId, constants.ItemVersionField: currentVersion}, bson.M{"$set": bson.M{"resources": InventoryTopicUpdateModel.Resources, "topic_name": InventoryTopicUpdateModel.TopicName, "description": InventoryTopicUpdateModel.Description, "assessments": InventoryTopicUpdateModel.Assessments, "updated_at": InventoryTopicUpdateModel.UpdatedAt, "updated_by": InventoryTopicUpdateModel.UpdatedBy}, "$inc": bson.M{constants.ItemVersionField: 1}})
 	if DbErr != nil {
 		log.Info("Error while Updating Topic with topic id", TopicId)
 		adapterErr.SetError(status_code.IMS500)
@@ -193,8 +453,8 @@ func (c InventoryRepository) UpdateInventoryTopic(ctx context.Context, Inventory
 	log.Info("Update", Update)
 
 	if Update.ModifiedCount != 1 && Update.MatchedCount != 1 {
-
-		adapterErr.SetError(status_code.IMS117)
+		log.Info("Inside "+methodName+" topic changed since it was read : ", TopicId)
+		adapterErr.SetError(status_code.IMS141)
 		return &adapterErr
 	}
 
@@ -202,25 +462,65 @@ func (c InventoryRepository) UpdateInventoryTopic(ctx context.Context, Inventory
 	return nil
 }
 
-func (c InventoryRepository) UpdateInventory(Id string, InventoryName string, UpdateRequest *interface{}) *dto.ErrorResponseDto {
+// UpdateInventory : sets the fields of the item while it is still at currentVersion, IMS141 when it changed since it was read.
+// A nil currentVersion matches items written before versions were stamped
+func (c InventoryRepository) UpdateInventory(Id string, InventoryName string, UpdateRequest *interface{}, currentVersion interface{}) *dto.ErrorResponseDto {
 	methodName := "UpdateInventoryTopic"
 	log := logger.GetLogger()
 	log.Info("Inside " + methodName)
 	var adapterErr dto.ErrorResponseDto
 	collectionName := constants.InventoryCollectionNamePrefix + InventoryName
 	log.Info("Collection Name", collectionName)
-	updateRes, dbErr := db.GetDb().Collection(collectionName).UpdateOne(context.Background(), bson.M{"id": Id}, bson.M{"$set": UpdateRequest})
+	updateRes, dbErr := db.GetDb().Collection(collectionName).UpdateOne(context.Background(), bson.M{"id": Id, constants.ItemVersionField: currentVersion}, bson.M{"$set": UpdateRequest, "$inc": bson.M{constants.ItemVersionField: 1}})
 	if dbErr != nil {
 		log.Info("There is an error while updating the inventory", dbErr)
 		adapterErr.SetError(status_code.IMS101)
 		return &adapterErr
 	}
+	if updateRes.MatchedCount == 0 {
+		log.Info("Inside "+methodName+" item changed since it was read : ", Id)
+		adapterErr.SetError(status_code.IMS141)
+		return &adapterErr
+	}
 
 	log.Info(updateRes)
 	return nil
 }
 
-func (c InventoryRepository) ActivateResourceById(ctx context.Context, InventoryName string, Id string) *dto.ErrorResponseDto {
+// ReplaceInventory : replaces the item while it is still at currentVersion, IMS141 when it changed since it was read
+func (c InventoryRepository) ReplaceInventory(Id string, InventoryName string, item map[string]interface{}, currentVersion interface{}) *dto.ErrorResponseDto {
+	methodName := "ReplaceInventory"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+	collectionName := constants.InventoryCollectionNamePrefix + InventoryName
+
+	replaceRes, dbErr := db.GetDb().Collection(collectionName).ReplaceOne(context.Background(), bson.M{"id": Id, constants.ItemVersionField: currentVersion}, item)
+	if dbErr != nil {
+		if mongo.IsDuplicateKeyError(dbErr) {
+			log.Error("Inside "+methodName+" error duplicate entry occurred when trying to replace item ", Id)
+			adapterErr.SetError(status_code.IMS108)
+			return &adapterErr
+		}
+		if strings.Contains(dbErr.Error(), "Document failed validation") {
+			log.Error("Inside ", methodName, "error : ", dbErr.Error(), " occurred while replacing item: ", Id)
+			adapterErr.SetError(status_code.IMS109)
+			return &adapterErr
+		}
+		log.Error("Inside "+methodName+" error: ", dbErr.Error(), " while replacing the item: ", Id)
+		adapterErr.SetError(status_code.IMS101)
+		return &adapterErr
+	}
+	if replaceRes.MatchedCount == 0 {
+		log.Info("Inside "+methodName+" item changed since it was read : ", Id)
+		adapterErr.SetError(status_code.IMS141)
+		return &adapterErr
+	}
+	return nil
+}
+
+// ActivateResourceById : marks the deleted item as not deleted, clears its deletion metadata and returns it as it was before.
+// IMS147 when the item is not deleted, IMS148 when a live item holds one of its unique identifier values
+func (c InventoryRepository) ActivateResourceById(ctx context.Context, InventoryName string, Id string, updateMetadata bson.M) (bson.M, *dto.ErrorResponseDto) {
 	methodName := "ActivateResourceById"
 	log := logger.GetLogger()
 	log.Info("Inside " + methodName)
@@ -230,53 +530,61 @@ func (c InventoryRepository) ActivateResourceById(ctx context.Context, Inventory
 	log.Info("Collection Name", collectionName)
 
 	log.Info("Id", Id)
-	Update, DbErr := db.GetDb().Collection(collectionName).UpdateOne(ctx, bson.M{"id": Id}, bson.M{"$set": bson.M{"is_deleted": false}})
+	activateFields := bson.M{"is_deleted": false}
+	for key, value := range updateMetadata {
+		activateFields[key] = value
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before).SetProjection(bson.M{"_id": 0})
+	var activatedItem bson.M
+	deletionFields := bson.M{constants.ItemDeletedAtField: "", constants.ItemDeletedByField: "", constants.ItemDeletionReasonField: ""}
+	DbErr := db.GetDb().Collection(collectionName).FindOneAndUpdate(ctx, bson.M{"id": Id, "is_deleted": true}, bson.M{"$set": activateFields, "$unset": deletionFields, "$inc": bson.M{constants.ItemVersionField: 1}}, opts).Decode(&activatedItem)
 	if DbErr != nil {
+		if DbErr == mongo.ErrNoDocuments {
+			adapterErr.SetError(status_code.IMS147)
+			return nil, &adapterErr
+		}
+		if mongo.IsDuplicateKeyError(DbErr) {
+			log.Error("Inside "+methodName+" restoring item : ", Id, " conflicts with a live item")
+			adapterErr.SetError(status_code.IMS148)
+			return nil, &adapterErr
+		}
 		log.Info("Error while Updating with id", Id)
 		adapterErr.SetError(status_code.IMS600)
-		return &adapterErr
+		return nil, &adapterErr
 	}
+	return activatedItem, nil
+}
 
-	log.Info("Update", Update)
-
-	if Update.ModifiedCount != 1 && Update.MatchedCount != 1 {
-
-		adapterErr.SetError(status_code.IMS119)
-		return &adapterErr
+// itemProjection : the projection, by default every field but the mongo _id
+func itemProjection(projection bson.M) bson.M {
+	if projection == nil {
+		return bson.M{"_id": 0}
 	}
-
-	log.Info("Update Feedback", Update)
-	return nil
+	return projection
 }
 
-func (c InventoryRepository) FetchInventoryList(ctx context.Context, from string, to string, inventoryName string, filterMap map[string][]string, pagination commonDto.Pagination) ([]bson.M, *commonDto.PaginationResponse, *dto.ErrorResponseDto) {
+// FetchInventoryList : live items matching the filter query, created between from and to when both are given. Items are
+// in the pagination order, a nil projection returns whole items. The queries are aborted after ListMaxTimeMs
+func (c InventoryRepository) FetchInventoryList(ctx context.Context, from string, to string, inventoryName string, filter bson.M, pagination commonDto.Pagination, projection bson.M) ([]bson.M, *commonDto.PaginationResponse, *dto.ErrorResponseDto) {
 	methodName := "FetchInventoryList"
 	log := logger.GetLogger()
 	var adapterErr dto.ErrorResponseDto
 	collectionName := constants.InventoryCollectionNamePrefix + inventoryName
 
-	query := bson.M{"is_deleted": false}
-
-	if from != "" && to != "" {
-		log.Info("FROM", from)
-		log.Info("TO", to)
-
-		query = bson.M{"created_at": bson.M{"$gte": from, "$lte": to}}
-
+	log.Info("FROM", from)
+	log.Info("TO", to)
+	conditions := liveItemConditions(from, to, filter)
+	if pagination.SortField == "" {
+		pagination.SortField, pagination.SortDirection = constants.DefaultSortField, -1
 	}
-
-	log.Info("Filter Map", filterMap)
-	for key, values := range filterMap {
-		log.Info("key")
-		if len(values) == 1 {
-			query[key] = values[0]
-		} else {
-			query[key] = bson.M{"$in": values}
-		}
+	if pagination.IsCursor {
+		return fetchInventoryPage(ctx, collectionName, conditions, pagination, projection)
 	}
+	query := bson.M{"$and": conditions}
 
-	opts := options.Find()
-	opts.SetSort(bson.D{{"created_at", -1}}).SetProjection(bson.M{"_id": 0})
+	//id breaks ties so items do not move between pages
+	opts := options.Find().SetMaxTime(constants.ListMaxTimeMs * time.Millisecond)
+	opts.SetSort(cursor.Sort(pagination.Order(), false)).SetProjection(itemProjection(projection))
 
 	if pagination.Pagination {
 		opts.SetSkip(int64(pagination.PageSize) * int64(pagination.PageNumber)).SetLimit(int64(pagination.PageSize))
@@ -304,7 +612,7 @@ func (c InventoryRepository) FetchInventoryList(ctx context.Context, from string
 		return nil, nil, &adapterErr
 	}
 
-	count, err2 := db.GetDb().Collection(collectionName).CountDocuments(ctx, query)
+	count, err2 := db.GetDb().Collection(collectionName).CountDocuments(ctx, query, options.Count().SetMaxTime(constants.ListMaxTimeMs*time.Millisecond))
 	if err2 != nil {
 		log.Error("Inside " + methodName + " error while counting all inventory items")
 		adapterErr.SetError(status_code.IMS306)
@@ -317,6 +625,7 @@ func (c InventoryRepository) FetchInventoryList(ctx context.Context, from string
 		paginationResp = &commonDto.PaginationResponse{
 			Count:      count,
 			PageNumber: pagination.PageNumber,
+			PageSize:   pagination.PageSize,
 		}
 	}
 	if pagination.Pagination {
@@ -325,6 +634,66 @@ func (c InventoryRepository) FetchInventoryList(ctx context.Context, from string
 	return itemList, nil, nil
 }
 
+// fetchInventoryPage : page of items after the pagination cursor, or before it for a backward cursor. One extra item is read
+// to tell whether the list continues past the page, no count is made
+func fetchInventoryPage(ctx context.Context, collectionName string, conditions bson.A, pagination commonDto.Pagination, projection bson.M) ([]bson.M, *commonDto.PaginationResponse, *dto.ErrorResponseDto) {
+	methodName := "fetchInventoryPage"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+
+	isBefore := pagination.Cursor != nil && pagination.Cursor.IsBefore
+	if pagination.Cursor != nil {
+		conditions = append(conditions, pagination.Cursor.Filter())
+	}
+	query := bson.M{"$and": conditions}
+	opts := options.Find().SetSort(cursor.Sort(pagination.Order(), isBefore)).SetLimit(pagination.PageSize + 1).SetProjection(itemProjection(projection)).
+		SetMaxTime(constants.ListMaxTimeMs * time.Millisecond)
+
+	itemList := []bson.M{}
+	cur, err := db.GetDb().Collection(collectionName).Find(ctx, query, opts)
+	if err != nil {
+		log.Info("Inside "+methodName+" error inventory for filter : ", query)
+		adapterErr.SetError(status_code.IMS110)
+		return nil, nil, &adapterErr
+	}
+	if err = cur.All(ctx, &itemList); err != nil {
+		log.Error("Inside " + methodName + " error while decoding inventory items")
+		adapterErr.SetError(status_code.IMS306)
+		return nil, nil, &adapterErr
+	}
+
+	hasMore := int64(len(itemList)) > pagination.PageSize
+	if hasMore {
+		itemList = itemList[:pagination.PageSize]
+	}
+	if isBefore {
+		for i, j := 0, len(itemList)-1; i < j; i, j = i+1, j-1 {
+			itemList[i], itemList[j] = itemList[j], itemList[i]
+		}
+	}
+
+	paginationResp := &commonDto.PaginationResponse{PageSize: pagination.PageSize}
+	if len(itemList) == 0 {
+		return itemList, paginationResp, nil
+	}
+	hasNext, hasPrev := hasMore, pagination.Cursor != nil
+	if isBefore {
+		hasNext, hasPrev = true, hasMore
+	}
+	if hasNext {
+		paginationResp.NextCursor, err = cursor.Encode(cursor.At(itemList[len(itemList)-1], pagination.Order(), false))
+	}
+	if hasPrev && err == nil {
+		paginationResp.PrevCursor, err = cursor.Encode(cursor.At(itemList[0], pagination.Order(), true))
+	}
+	if err != nil {
+		log.Error("Inside "+methodName+" error while encoding cursor : ", err)
+		adapterErr.SetError(status_code.IMS306)
+		return nil, nil, &adapterErr
+	}
+	return itemList, paginationResp, nil
+}
+
 func (c InventoryRepository) GetInventoryFilter(ctx context.Context, InventoryName string, FilterName string, filters bson.M) ([]interface{}, *dto.ErrorResponseDto) {
 	methodName := "GetInventoryFilter"
 	log := logger.GetLogger()
@@ -343,3 +712,324 @@ func (c InventoryRepository) GetInventoryFilter(ctx context.Context, InventoryNa
 
 	return filterList, nil
 }
+
+// NextInventorySequence : atomically increments and returns the item id counter of the inventory
+func (c InventoryRepository) NextInventorySequence(ctx context.Context, inventoryName string) (int64, *dto.ErrorResponseDto) {
+	methodName := "NextInventorySequence"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var sequence struct {
+		Value int64 `bson:"value"`
+	}
+	err := db.GetDb().Collection(constants.InventorySequenceCollectionName).FindOneAndUpdate(ctx, bson.M{"_id": inventoryName}, bson.M{"$inc": bson.M{"value": 1}}, opts).Decode(&sequence)
+	if err != nil {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while incrementing item sequence for: ", inventoryName)
+		adapterErr.SetError(status_code.IMS136)
+		return 0, &adapterErr
+	}
+	return sequence.Value, nil
+}
+
+// liveItemConditions : conditions of a list query, the live items created between from and to that match the filter
+func liveItemConditions(from string, to string, filter bson.M) bson.A {
+	conditions := bson.A{bson.M{"is_deleted": false}}
+	if from != "" && to != "" {
+		conditions = append(conditions, bson.M{"created_at": bson.M{"$gte": createdAtBound(from), "$lte": createdAtBound(to)}})
+	}
+	if len(filter) > 0 {
+		conditions = append(conditions, filter)
+	}
+	return conditions
+}
+
+// ExportInventory : streams the live items of a list query to write one at a time in the given order, so the items are
+// never all held in memory. Stops at the first write error, the find is aborted after ExportMaxTimeMs
+func (c InventoryRepository) ExportInventory(ctx context.Context, from string, to string, inventoryName string, filter bson.M, order []cursor.SortKey, projection bson.M, write func(item map[string]interface{}) error) *dto.ErrorResponseDto {
+	methodName := "ExportInventory"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+	collectionName := constants.InventoryCollectionNamePrefix + inventoryName
+
+	query := bson.M{"$and": liveItemConditions(from, to, filter)}
+	opts := options.Find().SetSort(cursor.Sort(order, false)).SetProjection(itemProjection(projection)).SetBatchSize(constants.ExportBatchSize).
+		SetMaxTime(constants.ExportMaxTimeMs * time.Millisecond)
+	cur, err := db.GetDb().Collection(collectionName).Find(ctx, query, opts)
+	if err != nil {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while exporting items of: ", inventoryName)
+		adapterErr.SetError(status_code.IMS110)
+		return &adapterErr
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		item := bson.M{}
+		if err = cur.Decode(&item); err != nil {
+			log.Error("Inside "+methodName+" error: ", err.Error(), " while decoding exported item of: ", inventoryName)
+			adapterErr.SetError(status_code.IMS306)
+			return &adapterErr
+		}
+		if err = write(item); err != nil {
+			log.Error("Inside "+methodName+" error: ", err.Error(), " while writing exported item of: ", inventoryName)
+			adapterErr.SetError(status_code.IMS161)
+			adapterErr.Message = adapterErr.Message + " : " + err.Error()
+			return &adapterErr
+		}
+	}
+	if err = cur.Err(); err != nil {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while reading exported items of: ", inventoryName)
+		adapterErr.SetError(status_code.IMS161)
+		return &adapterErr
+	}
+	return nil
+}
+
+// createdAtBound : created_at is stored as a date, bounds given as RFC3339 timestamps or plain dates are compared as dates
+func createdAtBound(bound string) interface{} {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if boundTime, err := time.Parse(layout, bound); err == nil {
+			return boundTime
+		}
+	}
+	return bound
+}
+
+// FetchDeletedInventoryList : page of the soft deleted items of the inventory in the order of the pagination, an empty trash is not an error
+func (c InventoryRepository) FetchDeletedInventoryList(ctx context.Context, inventoryName string, pagination commonDto.Pagination) ([]bson.M, *commonDto.PaginationResponse, *dto.ErrorResponseDto) {
+	methodName := "FetchDeletedInventoryList"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+	collectionName := constants.InventoryCollectionNamePrefix + inventoryName
+
+	query := bson.M{"is_deleted": true}
+	opts := options.Find().SetSort(bson.D{{Key: pagination.SortField, Value: pagination.SortDirection}}).SetProjection(bson.M{"_id": 0})
+	opts.SetSkip(pagination.PageSize * pagination.PageNumber).SetLimit(pagination.PageSize)
+
+	cur, err := db.GetDb().Collection(collectionName).Find(ctx, query, opts)
+	if err != nil {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while fetching deleted items of: ", inventoryName)
+		adapterErr.SetError(status_code.IMS110)
+		return nil, nil, &adapterErr
+	}
+	itemList := []bson.M{}
+	if err = cur.All(ctx, &itemList); err != nil {
+		log.Error("Inside " + methodName + " error while decoding deleted inventory items")
+		adapterErr.SetError(status_code.IMS306)
+		return nil, nil, &adapterErr
+	}
+	count, err := db.GetDb().Collection(collectionName).CountDocuments(ctx, query)
+	if err != nil {
+		log.Error("Inside " + methodName + " error while counting deleted inventory items")
+		adapterErr.SetError(status_code.IMS306)
+		return nil, nil, &adapterErr
+	}
+	return itemList, &commonDto.PaginationResponse{Count: count, PageNumber: pagination.PageNumber, PageSize: pagination.PageSize}, nil
+}
+
+// SearchInventory : page of the live items matching the text search, most relevant first. The relevance of an item is
+// returned in its search_score field
+func (c InventoryRepository) SearchInventory(ctx context.Context, inventoryName string, search string, pagination commonDto.Pagination) ([]bson.M, *commonDto.PaginationResponse, *dto.ErrorResponseDto) {
+	methodName := "SearchInventory"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+	collectionName := constants.InventoryCollectionNamePrefix + inventoryName
+
+	query := bson.M{"$text": bson.M{"$search": search}, "is_deleted": false}
+	textScore := bson.M{"$meta": "textScore"}
+	opts := options.Find().SetSort(bson.D{{Key: constants.SearchScoreField, Value: textScore}, {Key: constants.ItemIdField, Value: 1}})
+	opts.SetProjection(bson.M{"_id": 0, constants.SearchScoreField: textScore})
+	opts.SetSkip(pagination.PageSize * pagination.PageNumber).SetLimit(pagination.PageSize)
+
+	cur, err := db.GetDb().Collection(collectionName).Find(ctx, query, opts)
+	if err != nil {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while searching items of: ", inventoryName)
+		adapterErr.SetError(status_code.IMS110)
+		return nil, nil, &adapterErr
+	}
+	itemList := []bson.M{}
+	if err = cur.All(ctx, &itemList); err != nil {
+		log.Error("Inside " + methodName + " error while decoding matching inventory items")
+		adapterErr.SetError(status_code.IMS306)
+		return nil, nil, &adapterErr
+	}
+	count, err := db.GetDb().Collection(collectionName).CountDocuments(ctx, query)
+	if err != nil {
+		log.Error("Inside " + methodName + " error while counting matching inventory items")
+		adapterErr.SetError(status_code.IMS306)
+		return nil, nil, &adapterErr
+	}
+	return itemList, &commonDto.PaginationResponse{Count: count, PageNumber: pagination.PageNumber, PageSize: pagination.PageSize}, nil
+}
+
+// AggregateInventory : result documents of the aggregation pipeline over the items of the inventory, the pipeline is aborted
+// when it runs longer than AggregateMaxTimeMs
+func (c InventoryRepository) AggregateInventory(ctx context.Context, inventoryName string, pipeline bson.A) ([]bson.M, *dto.ErrorResponseDto) {
+	methodName := "AggregateInventory"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+	collectionName := constants.InventoryCollectionNamePrefix + inventoryName
+
+	opts := options.Aggregate().SetMaxTime(constants.AggregateMaxTimeMs * time.Millisecond)
+	cur, err := db.GetDb().Collection(collectionName).Aggregate(ctx, pipeline, opts)
+	if err != nil {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while aggregating items of: ", inventoryName)
+		adapterErr.SetError(status_code.IMS158)
+		return nil, &adapterErr
+	}
+	results := []bson.M{}
+	if err = cur.All(ctx, &results); err != nil {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while decoding aggregated items of: ", inventoryName)
+		adapterErr.SetError(status_code.IMS158)
+		return nil, &adapterErr
+	}
+	return results, nil
+}
+
+// CreateItemRevisions : appends the revisions to the history collection of the inventory
+func (c InventoryRepository) CreateItemRevisions(ctx context.Context, inventoryName string, revisions []commonDto.ItemRevision) *dto.ErrorResponseDto {
+	methodName := "CreateItemRevisions"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+	collectionName := constants.InventoryHistoryCollectionNamePrefix + inventoryName
+
+	documents := make([]interface{}, 0, len(revisions))
+	for _, revision := range revisions {
+		documents = append(documents, revision)
+	}
+	_, err := db.GetDb().Collection(collectionName).InsertMany(ctx, documents)
+	if err != nil {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while saving ", len(revisions), " revisions for inventory: ", inventoryName)
+		adapterErr.SetError(status_code.IMS144)
+		return &adapterErr
+	}
+	return nil
+}
+
+// FetchItemRevisions : revisions of the item oldest first, without the stored item snapshots
+func (c InventoryRepository) FetchItemRevisions(ctx context.Context, inventoryName string, itemId string) ([]commonDto.ItemRevision, *dto.ErrorResponseDto) {
+	methodName := "FetchItemRevisions"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+	collectionName := constants.InventoryHistoryCollectionNamePrefix + inventoryName
+
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: 1}}).SetProjection(bson.M{"_id": 0, "item": 0})
+	cur, err := db.GetDb().Collection(collectionName).Find(ctx, bson.M{"item_id": itemId}, opts)
+	if err != nil {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while fetching revisions of item: ", itemId, " for inventory: ", inventoryName)
+		adapterErr.SetError(status_code.IMS144)
+		return nil, &adapterErr
+	}
+	revisions := []commonDto.ItemRevision{}
+	if err = cur.All(ctx, &revisions); err != nil {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while decoding revisions of item: ", itemId, " for inventory: ", inventoryName)
+		adapterErr.SetError(status_code.IMS144)
+		return nil, &adapterErr
+	}
+	return revisions, nil
+}
+
+// FetchItemRevision : latest revision of the item matching the filter, IMS145 when there is none
+func (c InventoryRepository) FetchItemRevision(ctx context.Context, inventoryName string, itemId string, revisionFilter bson.M) (*commonDto.ItemRevision, *dto.ErrorResponseDto) {
+	methodName := "FetchItemRevision"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+	collectionName := constants.InventoryHistoryCollectionNamePrefix + inventoryName
+
+	filter := bson.M{"item_id": itemId}
+	for key, value := range revisionFilter {
+		filter[key] = value
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}}).SetProjection(bson.M{"_id": 0})
+	var revision commonDto.ItemRevision
+	err := db.GetDb().Collection(collectionName).FindOne(ctx, filter, opts).Decode(&revision)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			log.Info("Inside "+methodName+" no revision of item : ", itemId, " matches filter : ", revisionFilter)
+			adapterErr.SetError(status_code.IMS145)
+			return nil, &adapterErr
+		}
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while fetching revision of item: ", itemId, " for inventory: ", inventoryName)
+		adapterErr.SetError(status_code.IMS144)
+		return nil, &adapterErr
+	}
+	return &revision, nil
+}
+
+// PurgeDeletedItems : hard deletes the items soft deleted before deletedBefore and returns how many were removed, a dry run only counts them.
+// Items deleted before the deletion time was recorded are aged by their last update
+func (c InventoryRepository) PurgeDeletedItems(ctx context.Context, inventoryName string, deletedBefore time.Time, dryRun bool) (int64, *dto.ErrorResponseDto) {
+	methodName := "PurgeDeletedItems"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+	collectionName := constants.InventoryCollectionNamePrefix + inventoryName
+
+	filter := bson.M{"is_deleted": true, "$or": bson.A{
+		bson.M{constants.ItemDeletedAtField: bson.M{"$lt": deletedBefore}},
+		bson.M{constants.ItemDeletedAtField: bson.M{"$exists": false}, constants.ItemUpdatedAtField: bson.M{"$lt": deletedBefore}},
+	}}
+	if dryRun {
+		count, err := db.GetDb().Collection(collectionName).CountDocuments(ctx, filter)
+		if err != nil {
+			log.Error("Inside "+methodName+" error: ", err.Error(), " while counting purgeable items of: ", inventoryName)
+			adapterErr.SetError(status_code.IMS150)
+			return 0, &adapterErr
+		}
+		return count, nil
+	}
+	result, err := db.GetDb().Collection(collectionName).DeleteMany(ctx, filter)
+	if err != nil {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while purging deleted items of: ", inventoryName)
+		adapterErr.SetError(status_code.IMS150)
+		return 0, &adapterErr
+	}
+	return result.DeletedCount, nil
+}
+
+// PurgeItemRevisions : removes all but the newest keepRevisions revisions of every item and returns how many were removed, a dry run only counts them
+func (c InventoryRepository) PurgeItemRevisions(ctx context.Context, inventoryName string, keepRevisions int64, dryRun bool) (int64, *dto.ErrorResponseDto) {
+	methodName := "PurgeItemRevisions"
+	log := logger.GetLogger()
+	var adapterErr dto.ErrorResponseDto
+	collection := db.GetDb().Collection(constants.InventoryHistoryCollectionNamePrefix + inventoryName)
+
+	//oldest version kept for every item with more revisions than the policy keeps
+	pipeline := mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "item_id", Value: 1}, {Key: "version", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$item_id", "versions": bson.M{"$push": "$version"}}}},
+		{{Key: "$project", Value: bson.M{"count": bson.M{"$size": "$versions"}, "oldest_kept": bson.M{"$arrayElemAt": bson.A{"$versions", keepRevisions - 1}}}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": keepRevisions}}}},
+	}
+	cur, err := collection.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while finding revisions to purge for: ", inventoryName)
+		adapterErr.SetError(status_code.IMS144)
+		return 0, &adapterErr
+	}
+	var prunedItems []struct {
+		ItemId     string `bson:"_id"`
+		Count      int64  `bson:"count"`
+		OldestKept int64  `bson:"oldest_kept"`
+	}
+	if err = cur.All(ctx, &prunedItems); err != nil {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while decoding revisions to purge for: ", inventoryName)
+		adapterErr.SetError(status_code.IMS144)
+		return 0, &adapterErr
+	}
+
+	var purgedCount int64
+	for _, prunedItem := range prunedItems {
+		if dryRun {
+			purgedCount += prunedItem.Count - keepRevisions
+			continue
+		}
+		result, err := collection.DeleteMany(ctx, bson.M{"item_id": prunedItem.ItemId, "version": bson.M{"$lt": prunedItem.OldestKept}})
+		if err != nil {
+			log.Error("Inside "+methodName+" error: ", err.Error(), " while purging revisions of item: ", prunedItem.ItemId, " for: ", inventoryName)
+			adapterErr.SetError(status_code.IMS150)
+			return purgedCount, &adapterErr
+		}
+		purgedCount += result.DeletedCount
+	}
+	return purgedCount, nil
+}
@@ -13,4 +13,9 @@ type IInventoryConfigurationRepository interface {
 	FetchInventoryConfigurationByName(ctx context.Context, name string) (*ConfigurationServiceDto.InventoryConfiguration, *dto.ErrorResponseDto)
 	FetchAllInventoryConfiguration(ctx context.Context) ([]ConfigurationServiceDto.InventoryConfiguration, *dto.ErrorResponseDto)
 	DeleteInventoryConfigurationByName(ctx context.Context, name string) *dto.ErrorResponseDto
+	RestoreInventoryConfigurationByName(ctx context.Context, name string) *dto.ErrorResponseDto
+	PurgeInventoryConfigurationByName(ctx context.Context, name string) *dto.ErrorResponseDto
+	RemoveInventoryConfigurationByName(ctx context.Context, name string) *dto.ErrorResponseDto
+	UpdateInventoryConfigurationByName(ctx context.Context, name string, currentVersion int64, updateConfiguration request_dto.UpdateConfigurationRequestBody) (*ConfigurationServiceDto.InventoryConfiguration, *dto.ErrorResponseDto)
+	CreateInventoryConfigurationRevision(ctx context.Context, inventoryConfiguration ConfigurationServiceDto.InventoryConfiguration) *dto.ErrorResponseDto
 }
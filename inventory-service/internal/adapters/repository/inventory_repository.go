@@ -4,19 +4,37 @@ import (
 	"context"
 	"inventory-system/common/pkg/dto"
 	"inventory-system/inventory-service/internal/adapters/models"
+	"inventory-system/inventory-service/internal/common/cursor"
 	commonDto "inventory-system/inventory-service/internal/common/dto"
+	"time"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
 //go:generate mockgen -destination=mocks/mock_inventory_repository.go -package=mocks . IInventoryRepository
 type IInventoryRepository interface {
 	CreateNewInventoryGivenInventoryName(ctx context.Context, item interface{}, inventoryName string) *dto.ErrorResponseDto
-	FetchInventory(ctx context.Context, inventoryName string, uniqueKey string, uniqueValue string) (bson.M, *dto.ErrorResponseDto)
-	FetchInventoryList(ctx context.Context, from string, to string, inventoryName string, filterMap map[string][]string,pagination commonDto.Pagination) ([]bson.M, *commonDto.PaginationResponse, *dto.ErrorResponseDto)
-	RemoveItemFromInventory(ctx context.Context, RemoveItemModel *models.RemoveInventoryItem, InventoryName string) *dto.ErrorResponseDto
+	BulkInsertInventory(ctx context.Context, items []interface{}, inventoryName string, ordered bool) (map[int]*dto.ErrorResponseDto, *dto.ErrorResponseDto)
+	BulkWriteInventory(ctx context.Context, inventoryName string, writes []commonDto.ItemWrite) (map[int]*dto.ErrorResponseDto, *dto.ErrorResponseDto)
+	FetchInventory(ctx context.Context, inventoryName string, uniqueFilter bson.M, projection bson.M) (bson.M, *dto.ErrorResponseDto)
+	FetchInventoryById(ctx context.Context, inventoryName string, id string) (bson.M, *dto.ErrorResponseDto)
+	FetchDeletedInventoryList(ctx context.Context, inventoryName string, pagination commonDto.Pagination) ([]bson.M, *commonDto.PaginationResponse, *dto.ErrorResponseDto)
+	SearchInventory(ctx context.Context, inventoryName string, search string, pagination commonDto.Pagination) ([]bson.M, *commonDto.PaginationResponse, *dto.ErrorResponseDto)
+	AggregateInventory(ctx context.Context, inventoryName string, pipeline bson.A) ([]bson.M, *dto.ErrorResponseDto)
+	ReplaceInventoryItem(ctx context.Context, inventoryName string, uniqueFilter bson.M, item bson.M) (bson.M, *dto.ErrorResponseDto)
+	FetchInventoryList(ctx context.Context, from string, to string, inventoryName string, filter bson.M,pagination commonDto.Pagination, projection bson.M) ([]bson.M, *commonDto.PaginationResponse, *dto.ErrorResponseDto)
+	ExportInventory(ctx context.Context, from string, to string, inventoryName string, filter bson.M, order []cursor.SortKey, projection bson.M, write func(item map[string]interface{}) error) *dto.ErrorResponseDto
+	RemoveItemFromInventory(ctx context.Context, RemoveItemModel *models.RemoveInventoryItem, InventoryName string, updateMetadata bson.M) (bson.M, *dto.ErrorResponseDto)
+	RemoveItemsUpdatedBefore(ctx context.Context, inventoryName string, updatedBefore time.Time, updateMetadata bson.M, removed func(items []bson.M)) (int64, *dto.ErrorResponseDto)
 	RemoveSubjectTopicsByLessonNameAndSubjectId(ctx context.Context, model *models.RemoveSubjectRequestModel, Type string) *dto.ErrorResponseDto
-	UpdateInventoryTopic(ctx context.Context, InventoryTopicUpdateModel *models.InventoryTopicUpdateRequest, TopicId string) *dto.ErrorResponseDto
-	ActivateResourceById(ctx context.Context, InventoryName string, Id string) *dto.ErrorResponseDto
-	UpdateInventory(Id string, InventoryName string, UpdateRequest *interface{}) *dto.ErrorResponseDto
+	UpdateInventoryTopic(ctx context.Context, InventoryTopicUpdateModel *models.InventoryTopicUpdateRequest, TopicId string, currentVersion interface{}) *dto.ErrorResponseDto
+	ActivateResourceById(ctx context.Context, InventoryName string, Id string, updateMetadata bson.M) (bson.M, *dto.ErrorResponseDto)
+	UpdateInventory(Id string, InventoryName string, UpdateRequest *interface{}, currentVersion interface{}) *dto.ErrorResponseDto
+	ReplaceInventory(Id string, InventoryName string, item map[string]interface{}, currentVersion interface{}) *dto.ErrorResponseDto
 	GetInventoryFilter(ctx context.Context, InventoryName string, FilterName string,filters bson.M) ([]interface{}, *dto.ErrorResponseDto)
+	NextInventorySequence(ctx context.Context, inventoryName string) (int64, *dto.ErrorResponseDto)
+	CreateItemRevisions(ctx context.Context, inventoryName string, revisions []commonDto.ItemRevision) *dto.ErrorResponseDto
+	FetchItemRevisions(ctx context.Context, inventoryName string, itemId string) ([]commonDto.ItemRevision, *dto.ErrorResponseDto)
+	FetchItemRevision(ctx context.Context, inventoryName string, itemId string, revisionFilter bson.M) (*commonDto.ItemRevision, *dto.ErrorResponseDto)
+	PurgeDeletedItems(ctx context.Context, inventoryName string, deletedBefore time.Time, dryRun bool) (int64, *dto.ErrorResponseDto)
+	PurgeItemRevisions(ctx context.Context, inventoryName string, keepRevisions int64, dryRun bool) (int64, *dto.ErrorResponseDto)
 }
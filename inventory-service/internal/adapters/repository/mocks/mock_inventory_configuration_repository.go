@@ -37,6 +37,20 @@ func (m *MockIInventoryConfigurationRepository) EXPECT() *MockIInventoryConfigur
 	return m.recorder
 }
 
+// CreateInventoryConfigurationRevision mocks base method.
+func (m *MockIInventoryConfigurationRepository) CreateInventoryConfigurationRevision(arg0 context.Context, arg1 dto0.InventoryConfiguration) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInventoryConfigurationRevision", arg0, arg1)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// CreateInventoryConfigurationRevision indicates an expected call of CreateInventoryConfigurationRevision.
+func (mr *MockIInventoryConfigurationRepositoryMockRecorder) CreateInventoryConfigurationRevision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInventoryConfigurationRevision", reflect.TypeOf((*MockIInventoryConfigurationRepository)(nil).CreateInventoryConfigurationRevision), arg0, arg1)
+}
+
 // CreateNewConfiguration mocks base method.
 func (m *MockIInventoryConfigurationRepository) CreateNewConfiguration(arg0 context.Context, arg1 request_dto.CreateNewConfigurationRequestBody) *dto.ErrorResponseDto {
 	m.ctrl.T.Helper()
@@ -94,3 +108,60 @@ func (mr *MockIInventoryConfigurationRepositoryMockRecorder) FetchInventoryConfi
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchInventoryConfigurationByName", reflect.TypeOf((*MockIInventoryConfigurationRepository)(nil).FetchInventoryConfigurationByName), arg0, arg1)
 }
+
+// PurgeInventoryConfigurationByName mocks base method.
+func (m *MockIInventoryConfigurationRepository) PurgeInventoryConfigurationByName(arg0 context.Context, arg1 string) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeInventoryConfigurationByName", arg0, arg1)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// PurgeInventoryConfigurationByName indicates an expected call of PurgeInventoryConfigurationByName.
+func (mr *MockIInventoryConfigurationRepositoryMockRecorder) PurgeInventoryConfigurationByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeInventoryConfigurationByName", reflect.TypeOf((*MockIInventoryConfigurationRepository)(nil).PurgeInventoryConfigurationByName), arg0, arg1)
+}
+
+// RemoveInventoryConfigurationByName mocks base method.
+func (m *MockIInventoryConfigurationRepository) RemoveInventoryConfigurationByName(arg0 context.Context, arg1 string) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveInventoryConfigurationByName", arg0, arg1)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// RemoveInventoryConfigurationByName indicates an expected call of RemoveInventoryConfigurationByName.
+func (mr *MockIInventoryConfigurationRepositoryMockRecorder) RemoveInventoryConfigurationByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveInventoryConfigurationByName", reflect.TypeOf((*MockIInventoryConfigurationRepository)(nil).RemoveInventoryConfigurationByName), arg0, arg1)
+}
+
+// RestoreInventoryConfigurationByName mocks base method.
+func (m *MockIInventoryConfigurationRepository) RestoreInventoryConfigurationByName(arg0 context.Context, arg1 string) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreInventoryConfigurationByName", arg0, arg1)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// RestoreInventoryConfigurationByName indicates an expected call of RestoreInventoryConfigurationByName.
+func (mr *MockIInventoryConfigurationRepositoryMockRecorder) RestoreInventoryConfigurationByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreInventoryConfigurationByName", reflect.TypeOf((*MockIInventoryConfigurationRepository)(nil).RestoreInventoryConfigurationByName), arg0, arg1)
+}
+
+// UpdateInventoryConfigurationByName mocks base method.
+func (m *MockIInventoryConfigurationRepository) UpdateInventoryConfigurationByName(arg0 context.Context, arg1 string, arg2 int64, arg3 request_dto.UpdateConfigurationRequestBody) (*dto0.InventoryConfiguration, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInventoryConfigurationByName", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto0.InventoryConfiguration)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// UpdateInventoryConfigurationByName indicates an expected call of UpdateInventoryConfigurationByName.
+func (mr *MockIInventoryConfigurationRepositoryMockRecorder) UpdateInventoryConfigurationByName(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInventoryConfigurationByName", reflect.TypeOf((*MockIInventoryConfigurationRepository)(nil).UpdateInventoryConfigurationByName), arg0, arg1, arg2, arg3)
+}
@@ -7,7 +7,11 @@ package mocks
 import (
 	context "context"
 	dto "inventory-system/common/pkg/dto"
+	models "inventory-system/inventory-service/internal/adapters/models"
+	cursor "inventory-system/inventory-service/internal/common/cursor"
+	dto0 "inventory-system/inventory-service/internal/common/dto"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	primitive "go.mongodb.org/mongo-driver/bson/primitive"
@@ -36,6 +40,80 @@ func (m *MockIInventoryRepository) EXPECT() *MockIInventoryRepositoryMockRecorde
 	return m.recorder
 }
 
+// ActivateResourceById mocks base method.
+func (m *MockIInventoryRepository) ActivateResourceById(arg0 context.Context, arg1, arg2 string, arg3 primitive.M) (primitive.M, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateResourceById", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(primitive.M)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// ActivateResourceById indicates an expected call of ActivateResourceById.
+func (mr *MockIInventoryRepositoryMockRecorder) ActivateResourceById(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateResourceById", reflect.TypeOf((*MockIInventoryRepository)(nil).ActivateResourceById), arg0, arg1, arg2, arg3)
+}
+
+// AggregateInventory mocks base method.
+func (m *MockIInventoryRepository) AggregateInventory(arg0 context.Context, arg1 string, arg2 primitive.A) ([]primitive.M, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateInventory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]primitive.M)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// AggregateInventory indicates an expected call of AggregateInventory.
+func (mr *MockIInventoryRepositoryMockRecorder) AggregateInventory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateInventory", reflect.TypeOf((*MockIInventoryRepository)(nil).AggregateInventory), arg0, arg1, arg2)
+}
+
+// BulkInsertInventory mocks base method.
+func (m *MockIInventoryRepository) BulkInsertInventory(arg0 context.Context, arg1 []interface{}, arg2 string, arg3 bool) (map[int]*dto.ErrorResponseDto, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkInsertInventory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(map[int]*dto.ErrorResponseDto)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// BulkInsertInventory indicates an expected call of BulkInsertInventory.
+func (mr *MockIInventoryRepositoryMockRecorder) BulkInsertInventory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkInsertInventory", reflect.TypeOf((*MockIInventoryRepository)(nil).BulkInsertInventory), arg0, arg1, arg2, arg3)
+}
+
+// BulkWriteInventory mocks base method.
+func (m *MockIInventoryRepository) BulkWriteInventory(arg0 context.Context, arg1 string, arg2 []dto0.ItemWrite) (map[int]*dto.ErrorResponseDto, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkWriteInventory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[int]*dto.ErrorResponseDto)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// BulkWriteInventory indicates an expected call of BulkWriteInventory.
+func (mr *MockIInventoryRepositoryMockRecorder) BulkWriteInventory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkWriteInventory", reflect.TypeOf((*MockIInventoryRepository)(nil).BulkWriteInventory), arg0, arg1, arg2)
+}
+
+// CreateItemRevisions mocks base method.
+func (m *MockIInventoryRepository) CreateItemRevisions(arg0 context.Context, arg1 string, arg2 []dto0.ItemRevision) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItemRevisions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// CreateItemRevisions indicates an expected call of CreateItemRevisions.
+func (mr *MockIInventoryRepositoryMockRecorder) CreateItemRevisions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItemRevisions", reflect.TypeOf((*MockIInventoryRepository)(nil).CreateItemRevisions), arg0, arg1, arg2)
+}
+
 // CreateNewInventoryGivenInventoryName mocks base method.
 func (m *MockIInventoryRepository) CreateNewInventoryGivenInventoryName(arg0 context.Context, arg1 interface{}, arg2 string) *dto.ErrorResponseDto {
 	m.ctrl.T.Helper()
@@ -50,8 +128,38 @@ func (mr *MockIInventoryRepositoryMockRecorder) CreateNewInventoryGivenInventory
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNewInventoryGivenInventoryName", reflect.TypeOf((*MockIInventoryRepository)(nil).CreateNewInventoryGivenInventoryName), arg0, arg1, arg2)
 }
 
+// ExportInventory mocks base method.
+func (m *MockIInventoryRepository) ExportInventory(arg0 context.Context, arg1, arg2, arg3 string, arg4 primitive.M, arg5 []cursor.SortKey, arg6 primitive.M, arg7 func(map[string]interface{}) error) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportInventory", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// ExportInventory indicates an expected call of ExportInventory.
+func (mr *MockIInventoryRepositoryMockRecorder) ExportInventory(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportInventory", reflect.TypeOf((*MockIInventoryRepository)(nil).ExportInventory), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+}
+
+// FetchDeletedInventoryList mocks base method.
+func (m *MockIInventoryRepository) FetchDeletedInventoryList(arg0 context.Context, arg1 string, arg2 dto0.Pagination) ([]primitive.M, *dto0.PaginationResponse, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDeletedInventoryList", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]primitive.M)
+	ret1, _ := ret[1].(*dto0.PaginationResponse)
+	ret2, _ := ret[2].(*dto.ErrorResponseDto)
+	return ret0, ret1, ret2
+}
+
+// FetchDeletedInventoryList indicates an expected call of FetchDeletedInventoryList.
+func (mr *MockIInventoryRepositoryMockRecorder) FetchDeletedInventoryList(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDeletedInventoryList", reflect.TypeOf((*MockIInventoryRepository)(nil).FetchDeletedInventoryList), arg0, arg1, arg2)
+}
+
 // FetchInventory mocks base method.
-func (m *MockIInventoryRepository) FetchInventory(arg0 context.Context, arg1, arg2, arg3 string) (primitive.M, *dto.ErrorResponseDto) {
+func (m *MockIInventoryRepository) FetchInventory(arg0 context.Context, arg1 string, arg2, arg3 primitive.M) (primitive.M, *dto.ErrorResponseDto) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FetchInventory", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(primitive.M)
@@ -64,3 +172,241 @@ func (mr *MockIInventoryRepositoryMockRecorder) FetchInventory(arg0, arg1, arg2,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchInventory", reflect.TypeOf((*MockIInventoryRepository)(nil).FetchInventory), arg0, arg1, arg2, arg3)
 }
+
+// FetchInventoryById mocks base method.
+func (m *MockIInventoryRepository) FetchInventoryById(arg0 context.Context, arg1, arg2 string) (primitive.M, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchInventoryById", arg0, arg1, arg2)
+	ret0, _ := ret[0].(primitive.M)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// FetchInventoryById indicates an expected call of FetchInventoryById.
+func (mr *MockIInventoryRepositoryMockRecorder) FetchInventoryById(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchInventoryById", reflect.TypeOf((*MockIInventoryRepository)(nil).FetchInventoryById), arg0, arg1, arg2)
+}
+
+// FetchInventoryList mocks base method.
+func (m *MockIInventoryRepository) FetchInventoryList(arg0 context.Context, arg1, arg2, arg3 string, arg4 primitive.M, arg5 dto0.Pagination, arg6 primitive.M) ([]primitive.M, *dto0.PaginationResponse, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchInventoryList", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].([]primitive.M)
+	ret1, _ := ret[1].(*dto0.PaginationResponse)
+	ret2, _ := ret[2].(*dto.ErrorResponseDto)
+	return ret0, ret1, ret2
+}
+
+// FetchInventoryList indicates an expected call of FetchInventoryList.
+func (mr *MockIInventoryRepositoryMockRecorder) FetchInventoryList(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchInventoryList", reflect.TypeOf((*MockIInventoryRepository)(nil).FetchInventoryList), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// FetchItemRevision mocks base method.
+func (m *MockIInventoryRepository) FetchItemRevision(arg0 context.Context, arg1, arg2 string, arg3 primitive.M) (*dto0.ItemRevision, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchItemRevision", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto0.ItemRevision)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// FetchItemRevision indicates an expected call of FetchItemRevision.
+func (mr *MockIInventoryRepositoryMockRecorder) FetchItemRevision(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchItemRevision", reflect.TypeOf((*MockIInventoryRepository)(nil).FetchItemRevision), arg0, arg1, arg2, arg3)
+}
+
+// FetchItemRevisions mocks base method.
+func (m *MockIInventoryRepository) FetchItemRevisions(arg0 context.Context, arg1, arg2 string) ([]dto0.ItemRevision, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchItemRevisions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]dto0.ItemRevision)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// FetchItemRevisions indicates an expected call of FetchItemRevisions.
+func (mr *MockIInventoryRepositoryMockRecorder) FetchItemRevisions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchItemRevisions", reflect.TypeOf((*MockIInventoryRepository)(nil).FetchItemRevisions), arg0, arg1, arg2)
+}
+
+// GetInventoryFilter mocks base method.
+func (m *MockIInventoryRepository) GetInventoryFilter(arg0 context.Context, arg1, arg2 string, arg3 primitive.M) ([]interface{}, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryFilter", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]interface{})
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// GetInventoryFilter indicates an expected call of GetInventoryFilter.
+func (mr *MockIInventoryRepositoryMockRecorder) GetInventoryFilter(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryFilter", reflect.TypeOf((*MockIInventoryRepository)(nil).GetInventoryFilter), arg0, arg1, arg2, arg3)
+}
+
+// NextInventorySequence mocks base method.
+func (m *MockIInventoryRepository) NextInventorySequence(arg0 context.Context, arg1 string) (int64, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextInventorySequence", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// NextInventorySequence indicates an expected call of NextInventorySequence.
+func (mr *MockIInventoryRepositoryMockRecorder) NextInventorySequence(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextInventorySequence", reflect.TypeOf((*MockIInventoryRepository)(nil).NextInventorySequence), arg0, arg1)
+}
+
+// PurgeDeletedItems mocks base method.
+func (m *MockIInventoryRepository) PurgeDeletedItems(arg0 context.Context, arg1 string, arg2 time.Time, arg3 bool) (int64, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedItems", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// PurgeDeletedItems indicates an expected call of PurgeDeletedItems.
+func (mr *MockIInventoryRepositoryMockRecorder) PurgeDeletedItems(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedItems", reflect.TypeOf((*MockIInventoryRepository)(nil).PurgeDeletedItems), arg0, arg1, arg2, arg3)
+}
+
+// PurgeItemRevisions mocks base method.
+func (m *MockIInventoryRepository) PurgeItemRevisions(arg0 context.Context, arg1 string, arg2 int64, arg3 bool) (int64, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeItemRevisions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// PurgeItemRevisions indicates an expected call of PurgeItemRevisions.
+func (mr *MockIInventoryRepositoryMockRecorder) PurgeItemRevisions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeItemRevisions", reflect.TypeOf((*MockIInventoryRepository)(nil).PurgeItemRevisions), arg0, arg1, arg2, arg3)
+}
+
+// RemoveItemFromInventory mocks base method.
+func (m *MockIInventoryRepository) RemoveItemFromInventory(arg0 context.Context, arg1 *models.RemoveInventoryItem, arg2 string, arg3 primitive.M) (primitive.M, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveItemFromInventory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(primitive.M)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// RemoveItemFromInventory indicates an expected call of RemoveItemFromInventory.
+func (mr *MockIInventoryRepositoryMockRecorder) RemoveItemFromInventory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItemFromInventory", reflect.TypeOf((*MockIInventoryRepository)(nil).RemoveItemFromInventory), arg0, arg1, arg2, arg3)
+}
+
+// RemoveItemsUpdatedBefore mocks base method.
+func (m *MockIInventoryRepository) RemoveItemsUpdatedBefore(arg0 context.Context, arg1 string, arg2 time.Time, arg3 primitive.M, arg4 func([]primitive.M)) (int64, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveItemsUpdatedBefore", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// RemoveItemsUpdatedBefore indicates an expected call of RemoveItemsUpdatedBefore.
+func (mr *MockIInventoryRepositoryMockRecorder) RemoveItemsUpdatedBefore(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItemsUpdatedBefore", reflect.TypeOf((*MockIInventoryRepository)(nil).RemoveItemsUpdatedBefore), arg0, arg1, arg2, arg3, arg4)
+}
+
+// RemoveSubjectTopicsByLessonNameAndSubjectId mocks base method.
+func (m *MockIInventoryRepository) RemoveSubjectTopicsByLessonNameAndSubjectId(arg0 context.Context, arg1 *models.RemoveSubjectRequestModel, arg2 string) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSubjectTopicsByLessonNameAndSubjectId", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// RemoveSubjectTopicsByLessonNameAndSubjectId indicates an expected call of RemoveSubjectTopicsByLessonNameAndSubjectId.
+func (mr *MockIInventoryRepositoryMockRecorder) RemoveSubjectTopicsByLessonNameAndSubjectId(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubjectTopicsByLessonNameAndSubjectId", reflect.TypeOf((*MockIInventoryRepository)(nil).RemoveSubjectTopicsByLessonNameAndSubjectId), arg0, arg1, arg2)
+}
+
+// ReplaceInventory mocks base method.
+func (m *MockIInventoryRepository) ReplaceInventory(arg0, arg1 string, arg2 map[string]interface{}, arg3 interface{}) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceInventory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// ReplaceInventory indicates an expected call of ReplaceInventory.
+func (mr *MockIInventoryRepositoryMockRecorder) ReplaceInventory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceInventory", reflect.TypeOf((*MockIInventoryRepository)(nil).ReplaceInventory), arg0, arg1, arg2, arg3)
+}
+
+// ReplaceInventoryItem mocks base method.
+func (m *MockIInventoryRepository) ReplaceInventoryItem(arg0 context.Context, arg1 string, arg2, arg3 primitive.M) (primitive.M, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceInventoryItem", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(primitive.M)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// ReplaceInventoryItem indicates an expected call of ReplaceInventoryItem.
+func (mr *MockIInventoryRepositoryMockRecorder) ReplaceInventoryItem(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceInventoryItem", reflect.TypeOf((*MockIInventoryRepository)(nil).ReplaceInventoryItem), arg0, arg1, arg2, arg3)
+}
+
+// SearchInventory mocks base method.
+func (m *MockIInventoryRepository) SearchInventory(arg0 context.Context, arg1, arg2 string, arg3 dto0.Pagination) ([]primitive.M, *dto0.PaginationResponse, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchInventory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]primitive.M)
+	ret1, _ := ret[1].(*dto0.PaginationResponse)
+	ret2, _ := ret[2].(*dto.ErrorResponseDto)
+	return ret0, ret1, ret2
+}
+
+// SearchInventory indicates an expected call of SearchInventory.
+func (mr *MockIInventoryRepositoryMockRecorder) SearchInventory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchInventory", reflect.TypeOf((*MockIInventoryRepository)(nil).SearchInventory), arg0, arg1, arg2, arg3)
+}
+
+// UpdateInventory mocks base method.
+func (m *MockIInventoryRepository) UpdateInventory(arg0, arg1 string, arg2 *interface{}, arg3 interface{}) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInventory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// UpdateInventory indicates an expected call of UpdateInventory.
+func (mr *MockIInventoryRepositoryMockRecorder) UpdateInventory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInventory", reflect.TypeOf((*MockIInventoryRepository)(nil).UpdateInventory), arg0, arg1, arg2, arg3)
+}
+
+// UpdateInventoryTopic mocks base method.
+func (m *MockIInventoryRepository) UpdateInventoryTopic(arg0 context.Context, arg1 *models.InventoryTopicUpdateRequest, arg2 string, arg3 interface{}) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInventoryTopic", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// UpdateInventoryTopic indicates an expected call of UpdateInventoryTopic.
+func (mr *MockIInventoryRepositoryMockRecorder) UpdateInventoryTopic(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInventoryTopic", reflect.TypeOf((*MockIInventoryRepository)(nil).UpdateInventoryTopic), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,239 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"errors"
+	"inventory-system/common/pkg/constants"
+	"inventory-system/common/pkg/utils"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrInvalidCursor : returned when a cursor token cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// SortKey : field of a list order, Direction is 1 for ascending and -1 for descending
+type SortKey struct {
+	Field     string `bson:"field" json:"field"`
+	Direction int    `bson:"direction" json:"direction"`
+}
+
+// Cursor : position of an item in a list ordered by the sort keys then id. Pages continue after the position,
+// or end just before it when IsBefore is set
+type Cursor struct {
+	Sort     []SortKey     `bson:"sort"`
+	Values   []interface{} `bson:"values"`
+	Id       string        `bson:"id"`
+	IsBefore bool          `bson:"is_before,omitempty"`
+}
+
+// At : cursor positioned on the item in the given order
+func At(item map[string]interface{}, sort []SortKey, isBefore bool) Cursor {
+	position := Cursor{Sort: sort, Values: make([]interface{}, len(sort)), IsBefore: isBefore}
+	for index, key := range sort {
+		position.Values[index] = fieldValue(item, key.Field)
+	}
+	position.Id, _ = item[constants.ItemIdField].(string)
+	return position
+}
+
+// Encode : opaque url safe token of the cursor, bson keeps the type of dates and numbers in the sort values
+func Encode(position Cursor) (string, error) {
+	data, err := bson.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// Decode : cursor of a token made by Encode, sort values must be scalars
+func Decode(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var position Cursor
+	if err = bson.Unmarshal(data, &position); err != nil || position.Id == "" || len(position.Sort) == 0 || len(position.Sort) != len(position.Values) {
+		return nil, ErrInvalidCursor
+	}
+	for _, key := range position.Sort {
+		if key.Field == "" || (key.Direction != 1 && key.Direction != -1) {
+			return nil, ErrInvalidCursor
+		}
+	}
+	//tokens are not signed, a document or regex value would be read by mongo as a query operator or pattern on the sort field
+	for _, value := range position.Values {
+		if rank := typeRank(value); value != nil && (rank < 0 || rank == documentRank || rank == arrayRank || rank == regexRank) {
+			return nil, ErrInvalidCursor
+		}
+	}
+	return &position, nil
+}
+
+// Follows : whether the cursor was made for the given order
+func (c Cursor) Follows(sort []SortKey) bool {
+	if len(c.Sort) != len(sort) {
+		return false
+	}
+	for index, key := range sort {
+		if c.Sort[index] != key {
+			return false
+		}
+	}
+	return true
+}
+
+// Sort : order by the sort keys then id so the order is total, reversed when reading the page before a cursor
+func Sort(sort []SortKey, isBefore bool) bson.D {
+	order := bson.D{}
+	for _, key := range withId(sort) {
+		direction := key.Direction
+		if isBefore {
+			direction = -direction
+		}
+		order = append(order, bson.E{Key: key.Field, Value: direction})
+	}
+	return order
+}
+
+// Filter : condition selecting the items after the cursor in the list order, or before it when IsBefore is set.
+// An item comes after when it equals the cursor on the leading keys and is past it on the next one
+func (c Cursor) Filter() bson.M {
+	keys := withId(c.Sort)
+	values := append(append([]interface{}{}, c.Values...), c.Id)
+	branches := bson.A{}
+	for index, key := range keys {
+		isLower := (key.Direction < 0) != c.IsBefore
+		branch := pastValue(key.Field, values[index], isLower)
+		if branch == nil {
+			continue
+		}
+		for previous := 0; previous < index; previous++ {
+			branch[keys[previous].Field] = values[previous]
+		}
+		branches = append(branches, branch)
+	}
+	if len(branches) == 1 {
+		return branches[0].(bson.M)
+	}
+	return bson.M{"$or": branches}
+}
+
+// typeOrder : $type aliases of the bson types in the order mongo sorts them, null and missing values sort before all of them
+var typeOrder = [][]string{
+	{"double", "int", "long", "decimal"},
+	{"string", "symbol"},
+	{"object"},
+	{"array"},
+	{"binData"},
+	{"objectId"},
+	{"bool"},
+	{"date"},
+	{"timestamp"},
+	{"regex"},
+}
+
+// pastValue : condition on the field selecting the values sorted past the given one, nil when none can be. $gt and $lt only
+// compare values of the same type so values of the types sorted past it, and null or missing ones when going lower, are added.
+// The id is always a string and is compared alone
+func pastValue(field string, value interface{}, isLower bool) bson.M {
+	operator := "$gt"
+	if isLower {
+		operator = "$lt"
+	}
+	if field == constants.ItemIdField {
+		return bson.M{field: bson.M{operator: value}}
+	}
+	if value == nil {
+		if isLower {
+			return nil
+		}
+		return bson.M{field: bson.M{"$ne": nil}}
+	}
+
+	conditions := bson.A{bson.M{field: bson.M{operator: value}}}
+	if rank := typeRank(value); rank >= 0 {
+		var pastTypes []string
+		if isLower {
+			for _, aliases := range typeOrder[:rank] {
+				pastTypes = append(pastTypes, aliases...)
+			}
+		} else {
+			for _, aliases := range typeOrder[rank+1:] {
+				pastTypes = append(pastTypes, aliases...)
+			}
+		}
+		if len(pastTypes) > 0 {
+			conditions = append(conditions, bson.M{field: bson.M{"$type": pastTypes}})
+		}
+	}
+	if isLower {
+		conditions = append(conditions, bson.M{field: nil})
+	}
+	return bson.M{"$or": conditions}
+}
+
+// positions in typeOrder of the types a cursor value cannot have
+const (
+	documentRank = 2
+	arrayRank    = 3
+	regexRank    = 9
+)
+
+// typeRank : position of the type of the value in typeOrder, -1 when it is not known
+func typeRank(value interface{}) int {
+	switch value.(type) {
+	case int, int32, int64, float32, float64, primitive.Decimal128:
+		return 0
+	case string, primitive.Symbol:
+		return 1
+	case map[string]interface{}, bson.M, bson.D:
+		return documentRank
+	case []interface{}, bson.A:
+		return arrayRank
+	case primitive.Binary:
+		return 4
+	case primitive.ObjectID:
+		return 5
+	case bool:
+		return 6
+	case primitive.DateTime, time.Time:
+		return 7
+	case primitive.Timestamp:
+		return 8
+	case primitive.Regex:
+		return regexRank
+	}
+	return -1
+}
+
+// withId : the sort keys ending with id, keys after id cannot change the order and are dropped
+func withId(sort []SortKey) []SortKey {
+	keys := []SortKey{}
+	for _, key := range sort {
+		keys = append(keys, key)
+		if key.Field == constants.ItemIdField {
+			return keys
+		}
+	}
+	direction := 1
+	if len(sort) > 0 {
+		direction = sort[len(sort)-1].Direction
+	}
+	return append(keys, SortKey{Field: constants.ItemIdField, Direction: direction})
+}
+
+func fieldValue(item map[string]interface{}, field string) interface{} {
+	var current interface{} = item
+	for _, segment := range strings.Split(field, ".") {
+		document, isMap := utils.AsMap(current)
+		if !isMap {
+			return nil
+		}
+		current = document[segment]
+	}
+	return current
+}
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"inventory-system/inventory-service/internal/common/cursor"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCursor(t *testing.T) {
+	// dates of items read from mongo are primitive.DateTime
+	startsOn := primitive.NewDateTimeFromTime(time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC))
+	item := map[string]interface{}{"id": "C2", "starts_on": startsOn, "attributes": map[string]interface{}{"level": int64(3)}}
+	byStart := []cursor.SortKey{{Field: "starts_on", Direction: -1}}
+	byLevelAndStart := []cursor.SortKey{{Field: "attributes.level", Direction: 1}, {Field: "starts_on", Direction: -1}}
+
+	t.Run("TestCursor_ShouldRoundTripTypedSortValues", func(t *testing.T) {
+		for _, position := range []cursor.Cursor{
+			cursor.At(item, byStart, false),
+			cursor.At(item, byLevelAndStart, true),
+		} {
+			token, err := cursor.Encode(position)
+			assert.Nil(t, err)
+			assert.NotContains(t, token, "=")
+
+			decoded, err := cursor.Decode(token)
+			assert.Nil(t, err)
+			assert.Equal(t, position, *decoded)
+		}
+		assert.Equal(t, []interface{}{int64(3), startsOn}, cursor.At(item, byLevelAndStart, false).Values)
+	})
+	t.Run("TestCursor_ShouldReturnInvalidCursor_WhenTokenIsMalformed", func(t *testing.T) {
+		badDirection, _ := cursor.Encode(cursor.Cursor{Sort: []cursor.SortKey{{Field: "name", Direction: 2}}, Values: []interface{}{"DSA"}, Id: "C2"})
+		missingValue, _ := cursor.Encode(cursor.Cursor{Sort: byLevelAndStart, Values: []interface{}{int64(3)}, Id: "C2"})
+		operatorValue, _ := cursor.Encode(cursor.Cursor{Sort: byStart, Values: []interface{}{bson.M{"$ne": nil}}, Id: "C2"})
+		arrayValue, _ := cursor.Encode(cursor.Cursor{Sort: byStart, Values: []interface{}{bson.A{"C1"}}, Id: "C2"})
+		regexValue, _ := cursor.Encode(cursor.Cursor{Sort: byStart, Values: []interface{}{primitive.Regex{Pattern: ".*"}}, Id: "C2"})
+		for _, token := range []string{"not a cursor", "e30", badDirection, missingValue, operatorValue, arrayValue, regexValue} {
+			_, err := cursor.Decode(token)
+			assert.ErrorIs(t, err, cursor.ErrInvalidCursor, token)
+		}
+	})
+	t.Run("TestCursor_ShouldOnlyFollowItsOrder", func(t *testing.T) {
+		position := cursor.At(item, byLevelAndStart, false)
+		assert.True(t, position.Follows([]cursor.SortKey{{Field: "attributes.level", Direction: 1}, {Field: "starts_on", Direction: -1}}))
+		assert.False(t, position.Follows(byStart))
+		assert.False(t, position.Follows([]cursor.SortKey{{Field: "attributes.level", Direction: 1}, {Field: "starts_on", Direction: 1}}))
+	})
+	t.Run("TestCursor_ShouldSelectItemsAfterCursorInListOrder", func(t *testing.T) {
+		assert.Equal(t, bson.M{"$or": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"starts_on": bson.M{"$lt": startsOn}},
+				bson.M{"starts_on": bson.M{"$type": []string{"double", "int", "long", "decimal", "string", "symbol", "object", "array", "binData", "objectId", "bool"}}},
+				bson.M{"starts_on": nil},
+			}},
+			bson.M{"starts_on": startsOn, "id": bson.M{"$lt": "C2"}},
+		}}, cursor.At(item, byStart, false).Filter())
+		assert.Equal(t, bson.M{"$or": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"attributes.level": bson.M{"$lt": int64(3)}},
+				bson.M{"attributes.level": nil},
+			}},
+			bson.M{"attributes.level": int64(3), "$or": bson.A{
+				bson.M{"starts_on": bson.M{"$gt": startsOn}},
+				bson.M{"starts_on": bson.M{"$type": []string{"timestamp", "regex"}}},
+			}},
+			bson.M{"attributes.level": int64(3), "starts_on": startsOn, "id": bson.M{"$gt": "C2"}},
+		}}, cursor.At(item, byLevelAndStart, true).Filter())
+		assert.Equal(t, bson.M{"id": bson.M{"$gt": "C2"}}, cursor.At(item, []cursor.SortKey{{Field: "id", Direction: 1}}, false).Filter())
+	})
+	t.Run("TestCursor_ShouldKeepItemsWithoutSortValue_WhenCursorItemHasNone", func(t *testing.T) {
+		// items without the sort field sort before every value, and equal to each other
+		undated := map[string]interface{}{"id": "C3", "attributes": map[string]interface{}{"level": int64(3)}}
+		byStartAscending := []cursor.SortKey{{Field: "starts_on", Direction: 1}}
+
+		assert.Equal(t, bson.M{"$or": bson.A{
+			bson.M{"starts_on": bson.M{"$ne": nil}},
+			bson.M{"starts_on": nil, "id": bson.M{"$gt": "C3"}},
+		}}, cursor.At(undated, byStartAscending, false).Filter())
+		assert.Equal(t, bson.M{"starts_on": nil, "id": bson.M{"$lt": "C3"}}, cursor.At(undated, byStart, false).Filter())
+		assert.Equal(t, bson.M{"$or": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"attributes.level": bson.M{"$lt": int64(3)}},
+				bson.M{"attributes.level": nil},
+			}},
+			bson.M{"attributes.level": int64(3), "starts_on": bson.M{"$ne": nil}},
+			bson.M{"attributes.level": int64(3), "starts_on": nil, "id": bson.M{"$gt": "C3"}},
+		}}, cursor.At(undated, byLevelAndStart, true).Filter())
+	})
+	t.Run("TestCursor_ShouldReverseSort_WhenReadingBefore", func(t *testing.T) {
+		assert.Equal(t, bson.D{{Key: "attributes.level", Value: 1}, {Key: "starts_on", Value: -1}, {Key: "id", Value: -1}}, cursor.Sort(byLevelAndStart, false))
+		assert.Equal(t, bson.D{{Key: "attributes.level", Value: -1}, {Key: "starts_on", Value: 1}, {Key: "id", Value: 1}}, cursor.Sort(byLevelAndStart, true))
+		assert.Equal(t, bson.D{{Key: "id", Value: 1}}, cursor.Sort([]cursor.SortKey{{Field: "id", Direction: -1}, {Field: "name", Direction: 1}}, true))
+	})
+}
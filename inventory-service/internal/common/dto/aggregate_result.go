@@ -0,0 +1,14 @@
+package dto
+
+// AggregateResult : groups of an aggregation in the requested order, IsTruncated when more groups exist than the limit
+type AggregateResult struct {
+	Groups      []AggregateGroup `json:"groups"`
+	IsTruncated bool             `json:"is_truncated"`
+}
+
+// AggregateGroup : values of the group fields with the item count and metrics of the group
+type AggregateGroup struct {
+	Key     map[string]interface{} `json:"key"`
+	Count   int64                  `json:"count"`
+	Metrics map[string]interface{} `json:"metrics,omitempty"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+import "inventory-system/common/pkg/dto"
+
+type BulkInsertReport struct {
+	Ordered       bool                   `json:"ordered"`
+	InsertedCount int                    `json:"inserted_count"`
+	FailedCount   int                    `json:"failed_count"`
+	SkippedCount  int                    `json:"skipped_count"`
+	Results       []BulkInsertItemResult `json:"results"`
+}
+
+// BulkInsertItemResult : outcome of one item, Index is its position in the request. Items skipped after a failure in ordered mode have no result
+type BulkInsertItemResult struct {
+	Index      int            `json:"index"`
+	Id         string         `json:"id,omitempty"`
+	StatusCode dto.StatusCode `json:"status_code"`
+	Message    string         `json:"message"`
+}
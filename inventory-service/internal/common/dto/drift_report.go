@@ -0,0 +1,23 @@
+package dto
+
+type DriftReport struct {
+	OrphanCollections   []string         `json:"orphan_collections"`
+	MissingCollections  []string         `json:"missing_collections"`
+	InventoryDrifts     []InventoryDrift `json:"inventory_drifts"`
+	IsApplied           bool             `json:"is_applied"`
+	RepairedInventories []string         `json:"repaired_inventories"`
+	RepairFailures      []string         `json:"repair_failures"`
+}
+
+type InventoryDrift struct {
+	InventoryName    string   `json:"inventory_name"`
+	MissingIndexes   []string `json:"missing_indexes"`
+	ExtraIndexes     []string `json:"extra_indexes"`
+	OutdatedIndexes  []string `json:"outdated_indexes"`
+	IsValidatorDrift bool     `json:"is_validator_drift"`
+}
+
+// HasDrift : true when the collection indexes or validator differ from the configuration
+func (d InventoryDrift) HasDrift() bool {
+	return d.IsValidatorDrift || len(d.MissingIndexes) > 0 || len(d.ExtraIndexes) > 0 || len(d.OutdatedIndexes) > 0
+}
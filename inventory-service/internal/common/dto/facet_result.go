@@ -0,0 +1,14 @@
+package dto
+
+// Facet : values of a field with their item counts, IsTruncated when the field has more values than the limit
+type Facet struct {
+	Field       string       `json:"field"`
+	Values      []FacetValue `json:"values"`
+	IsTruncated bool         `json:"is_truncated"`
+}
+
+// FacetValue : value of a facet field and the number of items having it
+type FacetValue struct {
+	Value interface{} `json:"value"`
+	Count int64       `json:"count"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"inventory-system/common/pkg/dto"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ImportReport : outcome of an import. IsApplied is false for dry runs and for replace_all imports stopped by rejected rows,
+// a replace_all import with rows rejected while writing is applied but removes nothing. Items written by the import are
+// stamped with StartedAt. Rejected lists the first rejected rows in file order while RejectedCount counts them all
+type ImportReport struct {
+	Mode          string            `json:"mode"`
+	IsDryRun      bool              `json:"is_dry_run"`
+	IsApplied     bool              `json:"is_applied"`
+	StartedAt     time.Time         `json:"started_at"`
+	TotalRows     int               `json:"total_rows"`
+	ValidCount    int               `json:"valid_count"`
+	InsertedCount int               `json:"inserted_count"`
+	ReplacedCount int               `json:"replaced_count"`
+	RemovedCount  int64             `json:"removed_count"`
+	RejectedCount int               `json:"rejected_count"`
+	Rejected      []ImportRejection `json:"rejected"`
+}
+
+// ImportRejection : row of the file that was not imported, Row is the line it starts on
+type ImportRejection struct {
+	Row        int            `json:"row"`
+	StatusCode dto.StatusCode `json:"status_code"`
+	Reason     string         `json:"reason"`
+}
+
+// ItemWrite : write of a batch, Item is inserted when Filter is nil and replaces the live item matching Filter otherwise
+type ItemWrite struct {
+	Filter bson.M
+	Item   bson.M
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"inventory-system/inventory-service/internal/common/patch"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// mutations recorded in the history of an item
+const (
+	ItemRevisionCreate  = "create"
+	ItemRevisionUpdate  = "update"
+	ItemRevisionReplace = "replace"
+	ItemRevisionDelete  = "delete"
+	ItemRevisionRestore = "restore"
+	ItemRevisionRevert  = "revert"
+)
+
+// ItemRevision : one mutation of an item, Item is the item as it was stored by the mutation and Changes the JSON Patch
+// from the previous state. RevertedTo is the version a revert restored
+type ItemRevision struct {
+	ItemId     string            `bson:"item_id" json:"item_id"`
+	Version    int64             `bson:"version" json:"version"`
+	Operation  string            `bson:"operation" json:"operation"`
+	Changes    []patch.Operation `bson:"changes" json:"changes"`
+	Item       bson.M            `bson:"item" json:"item,omitempty"`
+	RevertedTo int64             `bson:"reverted_to,omitempty" json:"reverted_to,omitempty"`
+	CreatedBy  string            `bson:"created_by" json:"created_by"`
+	CreatedAt  time.Time         `bson:"created_at" json:"created_at"`
+}
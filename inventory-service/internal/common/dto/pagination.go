@@ -1,12 +1,32 @@
 package dto
 
+import "inventory-system/inventory-service/internal/common/cursor"
+
+// Pagination : page of a list to read. In cursor mode pages are read after or before Cursor instead of by PageNumber,
+// a nil Cursor reads the first page
 type Pagination struct {
-	Pagination bool  `json:"pagination"`
-	PageNumber int64 `json:"page"`
-	PageSize   int64 `json:"page_size"`
+	Pagination    bool             `json:"pagination"`
+	PageNumber    int64            `json:"page"`
+	PageSize      int64            `json:"page_size"`
+	SortField     string           `json:"sort_field"`
+	SortDirection int              `json:"sort_direction"`
+	Sort          []cursor.SortKey `json:"sort,omitempty"`
+	IsCursor      bool             `json:"is_cursor"`
+	Cursor        *cursor.Cursor   `json:"-"`
+}
+
+// Order : sort keys of the list, the requested Sort or else the default SortField and SortDirection
+func (p Pagination) Order() []cursor.SortKey {
+	if len(p.Sort) > 0 {
+		return p.Sort
+	}
+	return []cursor.SortKey{{Field: p.SortField, Direction: p.SortDirection}}
 }
 
 type PaginationResponse struct {
-	Count      int64 `json:"count"`
-	PageNumber int64 `json:"page"`
+	Count      int64  `json:"count"`
+	PageNumber int64  `json:"page"`
+	PageSize   int64  `json:"page_size"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
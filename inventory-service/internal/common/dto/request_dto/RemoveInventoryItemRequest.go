@@ -2,4 +2,5 @@ package request_dto
 
 type RemoveInventoryItem struct {
 	ItemId string `json:"item_id"`
+	Reason string `json:"reason,omitempty"`
 }
@@ -0,0 +1,27 @@
+package request_dto
+
+// operators of an aggregation metric, they apply to numeric fields
+const (
+	MetricSum = "sum"
+	MetricAvg = "avg"
+	MetricMin = "min"
+	MetricMax = "max"
+)
+
+// AggregateRequest : groups the live items matching Filter by the GroupBy identifiers and computes the Metrics of each group,
+// the item count of a group is always returned. Groups are ordered by Sort, count, a metric name or a group field prefixed
+// with - for descending, and at most Limit groups are returned
+type AggregateRequest struct {
+	GroupBy []string               `json:"group_by"`
+	Metrics []AggregateMetric      `json:"metrics,omitempty"`
+	Filter  map[string]interface{} `json:"filter,omitempty"`
+	Sort    string                 `json:"sort,omitempty"`
+	Limit   int64                  `json:"limit,omitempty"`
+}
+
+// AggregateMetric : Operator over Field for the items of a group, returned as Name which defaults to <op>_<field>
+type AggregateMetric struct {
+	Name     string `json:"name,omitempty"`
+	Operator string `json:"op"`
+	Field    string `json:"field"`
+}
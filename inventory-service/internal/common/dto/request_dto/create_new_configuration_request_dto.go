@@ -1,15 +1,65 @@
 package request_dto
 
-import "go.mongodb.org/mongo-driver/bson"
+import (
+	"inventory-system/common/pkg/constants"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	IndexTypeAscending  = "ascending"
+	IndexTypeDescending = "descending"
+	IndexTypeText       = "text"
+)
+
+// id strategies used to generate the id of new inventory items, sequence ids are IdPrefix followed by a per inventory counter
+const (
+	IdStrategyUUID     = "uuid"
+	IdStrategyULID     = "ulid"
+	IdStrategySequence = "sequence"
+)
 
 type CreateNewConfigurationRequestBody struct {
 	InventoryName        string                `json:"inventory_name" validate:"required"`
 	CreatedBy            string                `json:"created_by" validate:"required"`
 	JsonSchema           bson.M                `json:"json_schema" validate:"required"`
-	InventoryIdentifiers []InventoryIdentifier `json:"inventory_identifiers" validate:"required"`
+	InventoryIdentifiers []InventoryIdentifier `json:"inventory_identifiers" validate:"required,dive"`
+	SchemaDialect        string                `json:"schema_dialect,omitempty" validate:"omitempty,oneof=mongo draft-07 2020-12"`
+	SourceJsonSchema     bson.M                `json:"source_json_schema,omitempty" swaggerignore:"true"`
+	UnenforcedKeywords   []string              `json:"unenforced_keywords,omitempty" swaggerignore:"true"`
+	IdStrategy           string                `json:"id_strategy,omitempty" validate:"omitempty,oneof=uuid ulid sequence"`
+	IdPrefix             string                `json:"id_prefix,omitempty" validate:"omitempty,max=32"`
+	Pagination           bool                  `json:"pagination"`
+	PaginationSettings   *PaginationSettings   `json:"pagination_settings,omitempty" validate:"omitempty"`
+	RetentionPolicy      *RetentionPolicy      `json:"retention_policy,omitempty" validate:"omitempty"`
+	SearchableFields     []string              `json:"searchable_fields,omitempty" validate:"omitempty,unique,dive,required"`
 }
 
+// InventoryIdentifier : describes one idx_<key> index of an inventory collection.
+// Keys makes it a compound index over the listed fields, ExcludeDeleted makes it partial on is_deleted: false
+// and ExpireAfterSeconds turns a single date field into a TTL index.
 type InventoryIdentifier struct {
-	Key      string `json:"key" bson:"key"`
-	IsUnique bool   `json:"is_unique" bson:"is_unique"`
+	Key                string   `json:"key" bson:"key" validate:"required"`
+	IsUnique           bool     `json:"is_unique" bson:"is_unique"`
+	Keys               []string `json:"keys,omitempty" bson:"keys,omitempty"`
+	IndexType          string   `json:"index_type,omitempty" bson:"index_type,omitempty" validate:"omitempty,oneof=ascending descending text"`
+	ExcludeDeleted     bool     `json:"exclude_deleted,omitempty" bson:"exclude_deleted,omitempty"`
+	ExpireAfterSeconds *int32   `json:"expire_after_seconds,omitempty" bson:"expire_after_seconds,omitempty" validate:"omitempty,min=0"`
+}
+
+// Fields : fields covered by the identifier, Keys for compound identifiers otherwise Key
+func (i InventoryIdentifier) Fields() []string {
+	if len(i.Keys) > 0 {
+		return i.Keys
+	}
+	return []string{i.Key}
+}
+
+// SearchIdentifier : the text index built over the searchable fields of an inventory, it is not stored with the identifiers
+func SearchIdentifier(searchableFields []string) InventoryIdentifier {
+	return InventoryIdentifier{Key: constants.SearchIdentifierKey, Keys: searchableFields, IndexType: IndexTypeText}
+}
+
+func (i InventoryIdentifier) IsText() bool {
+	return i.IndexType == IndexTypeText
 }
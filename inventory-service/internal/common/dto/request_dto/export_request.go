@@ -0,0 +1,13 @@
+package request_dto
+
+// ExportRequest : live items to export in Format, selected like the items of a list query. From and To bound the creation
+// time, Filter is a filter document, Sort orders on indexed identifiers and Fields or Exclude project the items
+type ExportRequest struct {
+	Format  string
+	From    string
+	To      string
+	Filter  map[string]interface{}
+	Sort    string
+	Fields  string
+	Exclude string
+}
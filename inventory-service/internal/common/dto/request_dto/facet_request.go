@@ -0,0 +1,10 @@
+package request_dto
+
+// FacetRequest : values of each Facets field with the count of live items matching Filter, see filter.Parse for its language.
+// The condition Filter puts on a facet field is left out of that facet so the other values of the field stay selectable.
+// At most Limit values are returned per facet, most frequent first
+type FacetRequest struct {
+	Facets []string               `json:"facets"`
+	Filter map[string]interface{} `json:"filter,omitempty"`
+	Limit  int64                  `json:"limit,omitempty"`
+}
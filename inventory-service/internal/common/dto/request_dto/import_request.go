@@ -0,0 +1,18 @@
+package request_dto
+
+// modes of an import, upsert replaces the live item having the Key value of a row and replace_all removes the live items
+// that existed before the import
+const (
+	ImportModeInsert     = "insert"
+	ImportModeUpsert     = "upsert"
+	ImportModeReplaceAll = "replace_all"
+)
+
+// ImportRequest : how the rows of an import file are written, Format defaults to the file extension and Mode to insert.
+// Key is the unique identifier matching rows to items in upsert mode, DryRun validates the rows without writing them
+type ImportRequest struct {
+	Format string
+	Mode   string
+	Key    string
+	DryRun bool
+}
@@ -0,0 +1,15 @@
+package request_dto
+
+const (
+	SortDirectionAscending  = "asc"
+	SortDirectionDescending = "desc"
+)
+
+// PaginationSettings : page size limits and default ordering applied when listing the items of an inventory.
+// Zero values fall back to the service defaults.
+type PaginationSettings struct {
+	DefaultPageSize      int64  `json:"default_page_size,omitempty" bson:"default_page_size,omitempty" validate:"omitempty,min=1"`
+	MaxPageSize          int64  `json:"max_page_size,omitempty" bson:"max_page_size,omitempty" validate:"omitempty,min=1"`
+	DefaultSortField     string `json:"default_sort_field,omitempty" bson:"default_sort_field,omitempty"`
+	DefaultSortDirection string `json:"default_sort_direction,omitempty" bson:"default_sort_direction,omitempty" validate:"omitempty,oneof=asc desc"`
+}
@@ -0,0 +1,9 @@
+package request_dto
+
+// RetentionPolicy : limits how long an inventory keeps what it no longer serves. Soft deleted items are hard deleted
+// PurgeDeletedAfterDays days after their deletion and only the newest MaxRevisions revisions of an item are kept.
+// Zero values keep everything.
+type RetentionPolicy struct {
+	PurgeDeletedAfterDays int64 `json:"purge_deleted_after_days,omitempty" bson:"purge_deleted_after_days,omitempty" validate:"omitempty,min=1"`
+	MaxRevisions          int64 `json:"max_revisions,omitempty" bson:"max_revisions,omitempty" validate:"omitempty,min=1"`
+}
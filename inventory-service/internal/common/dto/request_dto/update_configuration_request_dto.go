@@ -0,0 +1,19 @@
+package request_dto
+
+import "go.mongodb.org/mongo-driver/bson"
+
+type UpdateConfigurationRequestBody struct {
+	UpdatedBy            string                `json:"updated_by" validate:"required"`
+	JsonSchema           bson.M                `json:"json_schema" validate:"required"`
+	InventoryIdentifiers []InventoryIdentifier `json:"inventory_identifiers" validate:"required,dive"`
+	ValidationLevel      string                `json:"validation_level" validate:"omitempty,oneof=strict moderate off"`
+	SchemaDialect        string                `json:"schema_dialect,omitempty" validate:"omitempty,oneof=mongo draft-07 2020-12"`
+	SourceJsonSchema     bson.M                `json:"source_json_schema,omitempty" swaggerignore:"true"`
+	UnenforcedKeywords   []string              `json:"unenforced_keywords,omitempty" swaggerignore:"true"`
+	IdStrategy           string                `json:"id_strategy,omitempty" validate:"omitempty,oneof=uuid ulid sequence"`
+	IdPrefix             string                `json:"id_prefix,omitempty" validate:"omitempty,max=32"`
+	Pagination           *bool                 `json:"pagination,omitempty"`
+	PaginationSettings   *PaginationSettings   `json:"pagination_settings,omitempty" validate:"omitempty"`
+	RetentionPolicy      *RetentionPolicy      `json:"retention_policy,omitempty" validate:"omitempty"`
+	SearchableFields     []string              `json:"searchable_fields,omitempty" validate:"omitempty,unique,dive,required"`
+}
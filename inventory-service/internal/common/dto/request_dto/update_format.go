@@ -0,0 +1,8 @@
+package request_dto
+
+// formats of an inventory item update, chosen by the content type of the request
+const (
+	UpdateFormatSet        = "set"
+	UpdateFormatJSONPatch  = "json-patch"
+	UpdateFormatMergePatch = "merge-patch"
+)
@@ -0,0 +1,9 @@
+package request_dto
+
+import "go.mongodb.org/mongo-driver/bson"
+
+type ValidateConfigurationRequestBody struct {
+	JsonSchema           bson.M                `json:"json_schema" validate:"required"`
+	InventoryIdentifiers []InventoryIdentifier `json:"inventory_identifiers" validate:"dive"`
+	SchemaDialect        string                `json:"schema_dialect,omitempty" validate:"omitempty,oneof=mongo draft-07 2020-12"`
+}
@@ -12,6 +12,23 @@ type InventoryConfigurationResponseDto struct {
 	InventoryIdentifiers []request_dto.InventoryIdentifier `bson:"inventory_identifiers" json:"inventory_identifiers"`
 	CreatedBy            string                            `bson:"created_by" json:"created_by"`
 	CreatedOn            time.Time                         `bson:"created_on" json:"created_on"`
+	UpdatedBy            string                            `bson:"updated_by" json:"updated_by"`
+	UpdatedOn            time.Time                         `bson:"updated_on" json:"updated_on"`
 	JsonSchema           bson.M                            `bson:"json_schema" json:"json_schema"`
+	SchemaDialect        string                            `bson:"schema_dialect,omitempty" json:"schema_dialect,omitempty"`
+	SourceJsonSchema     bson.M                            `bson:"source_json_schema,omitempty" json:"source_json_schema,omitempty"`
+	UnenforcedKeywords   []string                          `bson:"unenforced_keywords,omitempty" json:"unenforced_keywords,omitempty"`
+	IdStrategy           string                            `bson:"id_strategy,omitempty" json:"id_strategy,omitempty"`
+	IdPrefix             string                            `bson:"id_prefix,omitempty" json:"id_prefix,omitempty"`
+	PaginationSettings   *request_dto.PaginationSettings   `bson:"pagination_settings,omitempty" json:"pagination_settings,omitempty"`
+	RetentionPolicy      *request_dto.RetentionPolicy      `bson:"retention_policy,omitempty" json:"retention_policy,omitempty"`
+	SearchableFields     []string                          `bson:"searchable_fields,omitempty" json:"searchable_fields,omitempty"`
+	ValidationLevel      string                            `bson:"validation_level" json:"validation_level"`
+	Version              int64                             `bson:"version" json:"version"`
 	Pagination           bool                              `bson:"pagination" json:"pagination"`
+	IsDeleted            bool                              `bson:"is_deleted" json:"is_deleted"`
+}
+
+type CreateConfigurationResponseDto struct {
+	UnenforcedKeywords []string `json:"unenforced_keywords"`
 }
@@ -0,0 +1,18 @@
+package dto
+
+// RetentionReport : outcome of enforcing the retention policies of every inventory, on a dry run nothing is removed
+// and the counts are what would have been
+type RetentionReport struct {
+	IsDryRun        bool                 `json:"is_dry_run"`
+	Inventories     []InventoryRetention `json:"inventories"`
+	PurgedItems     int64                `json:"purged_items"`
+	PurgedRevisions int64                `json:"purged_revisions"`
+	Failures        []string             `json:"failures"`
+}
+
+type InventoryRetention struct {
+	InventoryName   string `json:"inventory_name"`
+	IsDryRun        bool   `json:"is_dry_run"`
+	PurgedItems     int64  `json:"purged_items"`
+	PurgedRevisions int64  `json:"purged_revisions"`
+}
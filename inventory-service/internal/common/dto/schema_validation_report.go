@@ -0,0 +1,29 @@
+package dto
+
+type SchemaValidationReport struct {
+	TotalDocuments         int64                    `json:"total_documents"`
+	InvalidDocuments       int64                    `json:"invalid_documents"`
+	SampleInvalidDocuments []InvalidDocumentSample  `json:"sample_invalid_documents"`
+	FieldFailures          []FieldValidationFailure `json:"field_failures"`
+	DuplicateIndexes       []DuplicateIndexReport   `json:"duplicate_indexes"`
+	IsApplicable           bool                     `json:"is_applicable"`
+	UnenforcedKeywords     []string                 `json:"unenforced_keywords,omitempty"`
+}
+
+type InvalidDocumentSample struct {
+	Id            interface{} `json:"id"`
+	FailingFields []string    `json:"failing_fields"`
+}
+
+type FieldValidationFailure struct {
+	Field   string `json:"field"`
+	Keyword string `json:"keyword"`
+	Count   int64  `json:"count"`
+}
+
+type DuplicateIndexReport struct {
+	IndexName       string        `json:"index_name"`
+	Key             string        `json:"key"`
+	DuplicateValues int64         `json:"duplicate_values"`
+	SampleValues    []interface{} `json:"sample_values"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "go.mongodb.org/mongo-driver/bson"
+
+// SearchResult : item matching a text search with its relevance and highlighted snippets of the searchable fields that matched
+type SearchResult struct {
+	Item       bson.M            `json:"item"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights"`
+}
@@ -0,0 +1,257 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"inventory-system/common/pkg/constants"
+	"inventory-system/common/pkg/utils"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// export formats, ndjson writes one json item per line
+const (
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+	FormatJSON   = "json"
+)
+
+// ErrUnknownFormat : returned for a format other than ndjson, csv or json
+var ErrUnknownFormat = errors.New("unknown export format, expected one of ndjson, csv or json")
+
+var contentTypes = map[string]string{
+	FormatNDJSON: "application/x-ndjson",
+	FormatCSV:    "text/csv; charset=utf-8",
+	FormatJSON:   "application/json; charset=utf-8",
+}
+
+// server managed columns, the id leads the schema columns and the metadata follows them
+var (
+	leadingColumns  = []string{constants.ItemIdField}
+	trailingColumns = []string{constants.ItemCreatedAtField, constants.ItemCreatedByField, constants.ItemUpdatedAtField, constants.ItemUpdatedByField, constants.ItemVersionField}
+)
+
+// Encoder : writes items in an export format as they are read. Nothing is written before the first item or End,
+// so an export that fails before streaming starts leaves the writer untouched
+type Encoder interface {
+	Write(item map[string]interface{}) error
+	End() error
+}
+
+// ContentType : media type of the format, empty for an unknown format
+func ContentType(format string) string {
+	return contentTypes[format]
+}
+
+// NewEncoder : encoder of the format writing to w, csv rows have the given columns
+func NewEncoder(format string, w io.Writer, columns []string) (Encoder, error) {
+	buffered := bufio.NewWriter(w)
+	switch format {
+	case FormatNDJSON:
+		return &jsonEncoder{writer: buffered, separator: "\n"}, nil
+	case FormatJSON:
+		return &jsonEncoder{writer: buffered, open: "[", separator: ",", close: "]", isArray: true}, nil
+	case FormatCSV:
+		return &csvEncoder{writer: csv.NewWriter(buffered), buffered: buffered, columns: columns}, nil
+	}
+	return nil, ErrUnknownFormat
+}
+
+// Columns : csv columns of the items of the schema, nested object properties are flattened into dot paths. Properties are
+// in alphabetical order between the id and the item metadata, a projection keeps only the columns it returns
+func Columns(jsonSchema map[string]interface{}, projection bson.M) []string {
+	managed := append(append([]string{}, leadingColumns...), trailingColumns...)
+	var schemaColumns []string
+	for _, column := range propertyColumns(jsonSchema, "") {
+		if !utils.Contains(managed, column) {
+			schemaColumns = append(schemaColumns, column)
+		}
+	}
+
+	var columns []string
+	for _, column := range append(append(append([]string{}, leadingColumns...), schemaColumns...), trailingColumns...) {
+		if isProjected(column, projection) {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+func propertyColumns(propertySchema map[string]interface{}, prefix string) []string {
+	properties, hasProperties := utils.AsMap(propertySchema["properties"])
+	if !hasProperties || len(properties) == 0 {
+		if prefix == "" {
+			return nil
+		}
+		return []string{strings.TrimSuffix(prefix, ".")}
+	}
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var columns []string
+	for _, name := range names {
+		nestedSchema, _ := utils.AsMap(properties[name])
+		columns = append(columns, propertyColumns(nestedSchema, prefix+name+".")...)
+	}
+	return columns
+}
+
+// isProjected : whether the projection returns the column, in full or in part
+func isProjected(column string, projection bson.M) bool {
+	isInclusion := false
+	for path, value := range projection {
+		if path == "_id" {
+			continue
+		}
+		isCovered := column == path || strings.HasPrefix(column, path+".")
+		if value == 0 {
+			if isCovered {
+				return false
+			}
+			continue
+		}
+		isInclusion = true
+		if isCovered || strings.HasPrefix(path, column+".") {
+			return true
+		}
+	}
+	return !isInclusion
+}
+
+type jsonEncoder struct {
+	writer    *bufio.Writer
+	open      string
+	separator string
+	close     string
+	isArray   bool
+	count     int
+}
+
+func (e *jsonEncoder) Write(item map[string]interface{}) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	switch {
+	case e.count == 0:
+		_, err = e.writer.WriteString(e.open)
+	case e.isArray:
+		_, err = e.writer.WriteString(e.separator)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err = e.writer.Write(data); err != nil {
+		return err
+	}
+	if !e.isArray {
+		if _, err = e.writer.WriteString(e.separator); err != nil {
+			return err
+		}
+	}
+	e.count++
+	return nil
+}
+
+func (e *jsonEncoder) End() error {
+	if e.count == 0 {
+		if _, err := e.writer.WriteString(e.open); err != nil {
+			return err
+		}
+	}
+	if _, err := e.writer.WriteString(e.close); err != nil {
+		return err
+	}
+	return e.writer.Flush()
+}
+
+type csvEncoder struct {
+	writer    *csv.Writer
+	buffered  *bufio.Writer
+	columns   []string
+	isStarted bool
+}
+
+func (e *csvEncoder) Write(item map[string]interface{}) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	row := make([]string, len(e.columns))
+	for index, column := range e.columns {
+		cell, err := formatCell(lookup(item, column))
+		if err != nil {
+			return err
+		}
+		row[index] = cell
+	}
+	return e.writer.Write(row)
+}
+
+func (e *csvEncoder) End() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	e.writer.Flush()
+	if err := e.writer.Error(); err != nil {
+		return err
+	}
+	return e.buffered.Flush()
+}
+
+// start : writes the header row once
+func (e *csvEncoder) start() error {
+	if e.isStarted {
+		return nil
+	}
+	e.isStarted = true
+	return e.writer.Write(e.columns)
+}
+
+// lookup : value at the dot path of the item, nil when a segment is missing or not an object
+func lookup(item map[string]interface{}, path string) interface{} {
+	var current interface{} = item
+	for _, segment := range strings.Split(path, ".") {
+		document, isMap := utils.AsMap(current)
+		if !isMap {
+			return nil
+		}
+		current = document[segment]
+	}
+	return current
+}
+
+// formatCell : csv text of a value, dates are written in RFC 3339 and arrays or objects as json
+func formatCell(value interface{}) (string, error) {
+	switch typedValue := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return typedValue, nil
+	case bool:
+		return strconv.FormatBool(typedValue), nil
+	case int32:
+		return strconv.FormatInt(int64(typedValue), 10), nil
+	case int64:
+		return strconv.FormatInt(typedValue, 10), nil
+	case int:
+		return strconv.Itoa(typedValue), nil
+	case float64:
+		return strconv.FormatFloat(typedValue, 'f', -1, 64), nil
+	case primitive.DateTime:
+		return typedValue.Time().UTC().Format(time.RFC3339Nano), nil
+	case time.Time:
+		return typedValue.UTC().Format(time.RFC3339Nano), nil
+	}
+	data, err := json.Marshal(value)
+	return string(data), err
+}
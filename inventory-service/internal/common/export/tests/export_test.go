@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"inventory-system/inventory-service/internal/common/export"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var courseSchema = map[string]interface{}{
+	"bsonType": "object",
+	"properties": map[string]interface{}{
+		"title":      map[string]interface{}{"bsonType": "string"},
+		"price":      map[string]interface{}{"bsonType": "double"},
+		"tags":       map[string]interface{}{"bsonType": "array", "items": map[string]interface{}{"bsonType": "string"}},
+		"attributes": map[string]interface{}{"bsonType": "object", "properties": map[string]interface{}{"level": map[string]interface{}{"bsonType": "int"}, "language": map[string]interface{}{"bsonType": "string"}}},
+		"version":    map[string]interface{}{"bsonType": "long"},
+	},
+}
+
+func TestColumns(t *testing.T) {
+	t.Run("TestColumns_ShouldFlattenNestedPropertiesBetweenIdAndMetadata", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"id", "attributes.language", "attributes.level", "price", "tags", "title",
+			"created_at", "created_by", "updated_at", "updated_by", "version",
+		}, export.Columns(courseSchema, nil))
+	})
+	t.Run("TestColumns_ShouldKeepProjectedColumns", func(t *testing.T) {
+		assert.Equal(t, []string{"id", "attributes.level", "title"}, export.Columns(courseSchema, bson.M{"_id": 0, "id": 1, "title": 1, "attributes.level": 1}))
+		assert.Equal(t, []string{"id", "attributes.language", "attributes.level", "title", "created_at", "created_by", "updated_at", "updated_by", "version"},
+			export.Columns(courseSchema, bson.M{"_id": 0, "price": 0, "tags": 0}))
+		assert.Equal(t, []string{"id", "price", "tags", "title", "created_at", "created_by", "updated_at", "updated_by", "version"},
+			export.Columns(courseSchema, bson.M{"_id": 0, "attributes": 0}))
+	})
+}
+
+func TestEncoder(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	items := []map[string]interface{}{
+		{"id": "C1", "title": "Graphs, trees", "price": 10.5, "tags": bson.A{"dsa"}, "attributes": bson.M{"level": int32(2)}, "created_at": primitive.NewDateTimeFromTime(createdAt)},
+		{"id": "C2", "title": "Go", "version": int64(3)},
+	}
+	encode := func(format string, columns []string, items []map[string]interface{}) string {
+		var output bytes.Buffer
+		encoder, err := export.NewEncoder(format, &output, columns)
+		assert.Nil(t, err)
+		for _, item := range items {
+			assert.Nil(t, encoder.Write(item))
+		}
+		assert.Nil(t, encoder.End())
+		return output.String()
+	}
+
+	t.Run("TestEncoder_ShouldWriteOneItemPerLine_WhenNDJSON", func(t *testing.T) {
+		assert.Equal(t, `{"id":"C2","title":"Go","version":3}`+"\n", encode(export.FormatNDJSON, nil, items[1:]))
+	})
+	t.Run("TestEncoder_ShouldWriteArray_WhenJSON", func(t *testing.T) {
+		assert.Equal(t, `[{"id":"C2","title":"Go","version":3},{"id":"C2","title":"Go","version":3}]`, encode(export.FormatJSON, nil, []map[string]interface{}{items[1], items[1]}))
+		assert.Equal(t, `[]`, encode(export.FormatJSON, nil, nil))
+	})
+	t.Run("TestEncoder_ShouldWriteHeaderAndFlattenedRows_WhenCSV", func(t *testing.T) {
+		columns := []string{"id", "attributes.level", "price", "tags", "title", "created_at", "version"}
+		assert.Equal(t, "id,attributes.level,price,tags,title,created_at,version\n"+
+			`C1,2,10.5,"[""dsa""]","Graphs, trees",2026-01-02T15:04:05Z,`+"\n"+
+			"C2,,,,Go,,3\n", encode(export.FormatCSV, columns, items))
+		assert.Equal(t, "id,title\n", encode(export.FormatCSV, []string{"id", "title"}, nil))
+	})
+	t.Run("TestEncoder_ShouldWriteNothing_BeforeFirstItem", func(t *testing.T) {
+		var output bytes.Buffer
+		_, err := export.NewEncoder(export.FormatJSON, &output, nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, output.Len())
+	})
+	t.Run("TestEncoder_ShouldReturnError_WhenFormatIsUnknown", func(t *testing.T) {
+		_, err := export.NewEncoder("xml", &bytes.Buffer{}, nil)
+
+		assert.ErrorIs(t, err, export.ErrUnknownFormat)
+		assert.Equal(t, "", export.ContentType("xml"))
+	})
+}
@@ -0,0 +1,412 @@
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"inventory-system/common/pkg/constants"
+	"inventory-system/common/pkg/utils"
+	"inventory-system/inventory-service/internal/common/schema"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// operators of the filter language
+const (
+	OperatorEq        = "eq"
+	OperatorNe        = "ne"
+	OperatorGt        = "gt"
+	OperatorGte       = "gte"
+	OperatorLt        = "lt"
+	OperatorLte       = "lte"
+	OperatorIn        = "in"
+	OperatorNin       = "nin"
+	OperatorExists    = "exists"
+	OperatorPrefix    = "prefix"
+	OperatorRegex     = "regex"
+	OperatorElemMatch = "elemMatch"
+	OperatorAnd       = "and"
+	OperatorOr        = "or"
+)
+
+// ErrFieldNotAllowed : returned when the filter names a field that cannot be filtered on
+var ErrFieldNotAllowed = errors.New("filter attribute not allowed")
+
+// types of the server managed item fields, which are not declared by the inventory schemas
+var managedFieldTypes = map[string]string{
+	constants.ItemIdField:             "string",
+	constants.ItemCreatedAtField:      "date",
+	constants.ItemCreatedByField:      "string",
+	constants.ItemUpdatedAtField:      "date",
+	constants.ItemUpdatedByField:      "string",
+	constants.ItemVersionField:        "long",
+	constants.ItemIsDeletedField:      "bool",
+	constants.ItemDeletedAtField:      "date",
+	constants.ItemDeletedByField:      "string",
+	constants.ItemDeletionReasonField: "string",
+}
+
+// Parse : translates a filter document into a mongo query. A field maps to a value it must equal, a list of values it must be
+// one of, or an object of operators; "and" and "or" combine lists of filter documents. Values are coerced to the type the json
+// schema declares for the field, so numbers and dates can be given as strings. isAllowed restricts the fields that can be
+// filtered on, the fields inside an elemMatch are relative to the array elements and are not restricted. Regex patterns are
+// in mongo's PCRE dialect and are not validated here, an invalid one fails the query
+func Parse(document map[string]interface{}, jsonSchema map[string]interface{}, isAllowed func(field string) bool) (bson.M, error) {
+	p := parser{isAllowed: isAllowed}
+	return p.parseDocument(document, jsonSchema, false)
+}
+
+type parser struct {
+	isAllowed func(field string) bool
+}
+
+func (p parser) parseDocument(document map[string]interface{}, scope map[string]interface{}, isNested bool) (bson.M, error) {
+	query := bson.M{}
+	for _, key := range sortedKeys(document) {
+		value := document[key]
+		if key == OperatorAnd || key == OperatorOr {
+			subDocuments, isList := utils.AsSlice(value)
+			if !isList || len(subDocuments) == 0 {
+				return nil, errors.New(key + " must be a non empty list of filters")
+			}
+			clauses := bson.A{}
+			for index, subDocument := range subDocuments {
+				subFilter, isObject := utils.AsMap(subDocument)
+				if !isObject {
+					return nil, fmt.Errorf("%s[%d] must be a filter object", key, index)
+				}
+				clause, err := p.parseDocument(subFilter, scope, isNested)
+				if err != nil {
+					return nil, err
+				}
+				clauses = append(clauses, clause)
+			}
+			query["$"+key] = clauses
+			continue
+		}
+		if !isNested && !p.isAllowed(key) {
+			return nil, fmt.Errorf("%w: %s", ErrFieldNotAllowed, key)
+		}
+		condition, err := parseCondition(key, value, fieldSchema(scope, key, isNested))
+		if err != nil {
+			return nil, err
+		}
+		query[key] = condition
+	}
+	return query, nil
+}
+
+// parseCondition : condition on one field, a plain value is an equality and a list a set membership
+func parseCondition(field string, value interface{}, propertySchema map[string]interface{}) (interface{}, error) {
+	valueTypes := schemaTypes(elementSchema(propertySchema))
+	if values, isList := utils.AsSlice(value); isList {
+		coercedValues, err := coerceList(field, OperatorIn, values, valueTypes)
+		if err != nil {
+			return nil, err
+		}
+		return bson.M{"$in": coercedValues}, nil
+	}
+	operators, isObject := utils.AsMap(value)
+	if !isObject {
+		return coerce(field, value, valueTypes)
+	}
+	return parseOperators(field, operators, propertySchema)
+}
+
+func parseOperators(field string, operators map[string]interface{}, propertySchema map[string]interface{}) (bson.M, error) {
+	if len(operators) == 0 {
+		return nil, errors.New(field + ": no operator given")
+	}
+	if _, hasPrefix := operators[OperatorPrefix]; hasPrefix {
+		if _, hasRegex := operators[OperatorRegex]; hasRegex {
+			return nil, errors.New(field + ": prefix and regex cannot be combined")
+		}
+	}
+	valueTypes := schemaTypes(elementSchema(propertySchema))
+	condition := bson.M{}
+	for _, operator := range sortedKeys(operators) {
+		operand := operators[operator]
+		switch operator {
+		case OperatorEq, OperatorNe, OperatorGt, OperatorGte, OperatorLt, OperatorLte:
+			coercedValue, err := coerce(field, operand, valueTypes)
+			if err != nil {
+				return nil, err
+			}
+			condition["$"+operator] = coercedValue
+		case OperatorIn, OperatorNin:
+			values, isList := utils.AsSlice(operand)
+			if !isList {
+				return nil, errors.New(field + ": " + operator + " must be a list")
+			}
+			coercedValues, err := coerceList(field, operator, values, valueTypes)
+			if err != nil {
+				return nil, err
+			}
+			condition["$"+operator] = coercedValues
+		case OperatorExists:
+			exists, err := coerce(field, operand, []string{"bool"})
+			if _, isBool := exists.(bool); err != nil || !isBool {
+				return nil, errors.New(field + ": exists must be a boolean")
+			}
+			condition["$exists"] = exists
+		case OperatorPrefix:
+			prefix, isString := operand.(string)
+			if !isString {
+				return nil, errors.New(field + ": prefix must be a string")
+			}
+			condition["$regex"] = "^" + regexp.QuoteMeta(prefix)
+		case OperatorRegex:
+			pattern, isString := operand.(string)
+			if !isString {
+				return nil, errors.New(field + ": regex must be a string")
+			}
+			//the pattern is run by mongo as PCRE, its syntax is checked there
+			if pattern == "" || utf8.RuneCountInString(pattern) > constants.MaxFilterRegexLength {
+				return nil, errors.New(field + ": regex must have between 1 and " + strconv.Itoa(constants.MaxFilterRegexLength) + " characters")
+			}
+			condition["$regex"] = pattern
+		case OperatorElemMatch:
+			elementFilter, isObject := utils.AsMap(operand)
+			if !isObject || len(elementFilter) == 0 {
+				return nil, errors.New(field + ": elemMatch must be a non empty filter object")
+			}
+			elementCondition, err := parseElemMatch(field, elementFilter, elementSchema(propertySchema))
+			if err != nil {
+				return nil, err
+			}
+			condition["$elemMatch"] = elementCondition
+		default:
+			return nil, errors.New(field + ": unknown operator " + strconv.Quote(operator))
+		}
+	}
+	return condition, nil
+}
+
+// parseElemMatch : operators applying to the elements themselves for arrays of values, a filter on their fields for arrays of documents
+func parseElemMatch(field string, elementFilter map[string]interface{}, itemSchema map[string]interface{}) (bson.M, error) {
+	if isOperatorObject(elementFilter) {
+		return parseOperators(field, elementFilter, itemSchema)
+	}
+	p := parser{}
+	elementQuery, err := p.parseDocument(elementFilter, itemSchema, true)
+	if err != nil {
+		return nil, errors.New(field + "." + err.Error())
+	}
+	return elementQuery, nil
+}
+
+func isOperatorObject(document map[string]interface{}) bool {
+	for key := range document {
+		switch key {
+		case OperatorEq, OperatorNe, OperatorGt, OperatorGte, OperatorLt, OperatorLte, OperatorIn, OperatorNin,
+			OperatorExists, OperatorPrefix, OperatorRegex, OperatorElemMatch:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// CoerceDocument : copy of the document with its values coerced to the types the json schema declares, like the values of a
+// filter. Nested objects and array elements are coerced by their own schema and strings of array or object fields are read as json
+func CoerceDocument(document map[string]interface{}, jsonSchema map[string]interface{}) (map[string]interface{}, error) {
+	return coerceObject(document, jsonSchema, "", false)
+}
+
+func coerceObject(document map[string]interface{}, scope map[string]interface{}, prefix string, isNested bool) (map[string]interface{}, error) {
+	coercedDocument := make(map[string]interface{}, len(document))
+	for _, key := range sortedKeys(document) {
+		coercedValue, err := coerceValue(prefix+key, document[key], fieldSchema(scope, key, isNested))
+		if err != nil {
+			return nil, err
+		}
+		coercedDocument[key] = coercedValue
+	}
+	return coercedDocument, nil
+}
+
+func coerceValue(field string, value interface{}, propertySchema map[string]interface{}) (interface{}, error) {
+	if propertySchema == nil || value == nil {
+		return value, nil
+	}
+	types := schemaTypes(propertySchema)
+	if text, isString := value.(string); isString && !containsType(types, "string") && (containsType(types, "array") || containsType(types, "object")) {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(text), &decoded); err != nil {
+			return nil, fmt.Errorf("%s: %s is not a valid %s", field, strconv.Quote(text), strings.Join(types, "|"))
+		}
+		value = decoded
+	}
+	if document, isMap := utils.AsMap(value); isMap {
+		return coerceObject(document, propertySchema, field+".", true)
+	}
+	if elements, isList := utils.AsSlice(value); isList {
+		itemSchema, _ := utils.AsMap(propertySchema["items"])
+		coercedElements := make([]interface{}, 0, len(elements))
+		for index, element := range elements {
+			coercedElement, err := coerceValue(field+"."+strconv.Itoa(index), element, itemSchema)
+			if err != nil {
+				return nil, err
+			}
+			coercedElements = append(coercedElements, coercedElement)
+		}
+		return coercedElements, nil
+	}
+	return coerce(field, value, types)
+}
+
+// IsNumeric : whether the schema declares the field with numeric types only
+func IsNumeric(jsonSchema map[string]interface{}, field string) bool {
+	propertySchema := fieldSchema(jsonSchema, field, false)
+	if propertySchema == nil {
+		return false
+	}
+	types := schemaTypes(propertySchema)
+	for _, typeName := range types {
+		switch typeName {
+		case "int", "long", "double", "decimal", "number":
+		default:
+			return false
+		}
+	}
+	return len(types) > 0
+}
+
+// fieldSchema : schema declared for the field, server managed fields of an item are typed even when the schema omits them
+func fieldSchema(scope map[string]interface{}, field string, isNested bool) map[string]interface{} {
+	if propertySchema, found := schema.PropertySchema(scope, field); found {
+		return propertySchema
+	}
+	if managedType, isManaged := managedFieldTypes[field]; isManaged && !isNested {
+		return map[string]interface{}{"bsonType": managedType}
+	}
+	return nil
+}
+
+// elementSchema : schema of the elements of an array property, the property schema itself otherwise.
+// Equality and comparisons on an array field match its elements
+func elementSchema(propertySchema map[string]interface{}) map[string]interface{} {
+	if itemSchema, ok := utils.AsMap(propertySchema["items"]); ok {
+		return itemSchema
+	}
+	return propertySchema
+}
+
+// schemaTypes : bsonType or type names of the schema, json schema names are mapped to their bson equivalent
+func schemaTypes(propertySchema map[string]interface{}) []string {
+	typeValue, isBsonType := propertySchema["bsonType"]
+	if !isBsonType {
+		typeValue = propertySchema["type"]
+	}
+	typeNames, isList := utils.AsSlice(typeValue)
+	if !isList {
+		typeNames = []interface{}{typeValue}
+	}
+	var types []string
+	for _, typeName := range typeNames {
+		switch name, _ := typeName.(string); name {
+		case "":
+		case "integer":
+			types = append(types, "long")
+		case "boolean":
+			types = append(types, "bool")
+		default:
+			types = append(types, name)
+		}
+	}
+	return types
+}
+
+func coerceList(field string, operator string, values []interface{}, types []string) (bson.A, error) {
+	if len(values) == 0 {
+		return nil, errors.New(field + ": " + operator + " must not be empty")
+	}
+	coercedValues := make(bson.A, 0, len(values))
+	for _, value := range values {
+		coercedValue, err := coerce(field, value, types)
+		if err != nil {
+			return nil, err
+		}
+		coercedValues = append(coercedValues, coercedValue)
+	}
+	return coercedValues, nil
+}
+
+// coerce : converts the value to the first of the types it can represent. Strings are parsed unless the field accepts strings,
+// whole numbers become int64 for integer fields. Values of fields without a declared type are left as they are
+func coerce(field string, value interface{}, types []string) (interface{}, error) {
+	if len(types) == 0 {
+		return value, nil
+	}
+	switch typedValue := value.(type) {
+	case string:
+		if containsType(types, "string") {
+			return typedValue, nil
+		}
+		for _, typeName := range types {
+			if coercedValue, ok := parseString(typedValue, typeName); ok {
+				return coercedValue, nil
+			}
+		}
+		return nil, fmt.Errorf("%s: %s is not a valid %s", field, strconv.Quote(typedValue), strings.Join(types, "|"))
+	case float64:
+		if containsType(types, "double") || containsType(types, "number") || containsType(types, "decimal") {
+			return typedValue, nil
+		}
+		if containsType(types, "int") || containsType(types, "long") {
+			if typedValue != float64(int64(typedValue)) {
+				return nil, fmt.Errorf("%s: %s is not a valid %s", field, strconv.FormatFloat(typedValue, 'f', -1, 64), strings.Join(types, "|"))
+			}
+			return int64(typedValue), nil
+		}
+	}
+	return value, nil
+}
+
+func parseString(value string, typeName string) (interface{}, bool) {
+	switch typeName {
+	case "date":
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if parsedTime, err := time.Parse(layout, value); err == nil {
+				return parsedTime, true
+			}
+		}
+	case "int", "long":
+		if number, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return number, true
+		}
+	case "double", "number", "decimal":
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number, true
+		}
+	case "bool":
+		if boolean, err := strconv.ParseBool(value); err == nil {
+			return boolean, true
+		}
+	}
+	return nil, false
+}
+
+func containsType(types []string, typeName string) bool {
+	for _, name := range types {
+		if name == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(document map[string]interface{}) []string {
+	keys := make([]string, 0, len(document))
+	for key := range document {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
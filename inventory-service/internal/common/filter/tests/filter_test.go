@@ -0,0 +1,186 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"inventory-system/common/pkg/constants"
+	"inventory-system/inventory-service/internal/common/filter"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var courseSchema = map[string]interface{}{
+	"bsonType": "object",
+	"properties": map[string]interface{}{
+		"name":       map[string]interface{}{"bsonType": "string"},
+		"price":      map[string]interface{}{"bsonType": "double"},
+		"seats":      map[string]interface{}{"bsonType": "int"},
+		"starts_on":  map[string]interface{}{"bsonType": "date"},
+		"is_active":  map[string]interface{}{"type": "boolean"},
+		"tags":       map[string]interface{}{"bsonType": "array", "items": map[string]interface{}{"bsonType": "string"}},
+		"scores":     map[string]interface{}{"bsonType": "array", "items": map[string]interface{}{"bsonType": "long"}},
+		"resources":  map[string]interface{}{"bsonType": "array", "items": map[string]interface{}{"bsonType": "object", "properties": map[string]interface{}{"size": map[string]interface{}{"bsonType": "int"}}}},
+		"attributes": map[string]interface{}{"bsonType": "object", "properties": map[string]interface{}{"level": map[string]interface{}{"bsonType": "int"}}},
+	},
+}
+
+func allowAll(field string) bool {
+	return true
+}
+
+func TestParse(t *testing.T) {
+	t.Run("TestParse_ShouldKeepLegacyListsAsMembership", func(t *testing.T) {
+		query, err := filter.Parse(map[string]interface{}{"name": []interface{}{"DSA", "Java"}}, courseSchema, allowAll)
+
+		assert.Nil(t, err)
+		assert.Equal(t, bson.M{"name": bson.M{"$in": bson.A{"DSA", "Java"}}}, query)
+	})
+	t.Run("TestParse_ShouldCoerceValuesToSchemaTypes", func(t *testing.T) {
+		query, err := filter.Parse(map[string]interface{}{
+			"price":            map[string]interface{}{"gte": "10.5", "lt": float64(100)},
+			"seats":            map[string]interface{}{"in": []interface{}{"10", float64(20)}},
+			"starts_on":        map[string]interface{}{"gt": "2026-01-02"},
+			"is_active":        "true",
+			"attributes.level": "3",
+			"created_at":       map[string]interface{}{"lte": "2026-01-02T15:04:05Z"},
+			"scores":           "7",
+		}, courseSchema, allowAll)
+
+		assert.Nil(t, err)
+		assert.Equal(t, bson.M{
+			"price":            bson.M{"$gte": 10.5, "$lt": float64(100)},
+			"seats":            bson.M{"$in": bson.A{int64(10), int64(20)}},
+			"starts_on":        bson.M{"$gt": time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)},
+			"is_active":        true,
+			"attributes.level": int64(3),
+			"created_at":       bson.M{"$lte": time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)},
+			"scores":           int64(7),
+		}, query)
+	})
+	t.Run("TestParse_ShouldTranslateStringAndPresenceOperators", func(t *testing.T) {
+		query, err := filter.Parse(map[string]interface{}{
+			"name": map[string]interface{}{"prefix": "C++", "ne": "C++11"},
+			"tags": map[string]interface{}{"regex": "^go", "exists": "true", "nin": []interface{}{"legacy"}},
+		}, courseSchema, allowAll)
+
+		assert.Nil(t, err)
+		assert.Equal(t, bson.M{
+			"name": bson.M{"$regex": `^C\+\+`, "$ne": "C++11"},
+			"tags": bson.M{"$regex": "^go", "$exists": true, "$nin": bson.A{"legacy"}},
+		}, query)
+	})
+	t.Run("TestParse_ShouldComposeAndOrAndElemMatch", func(t *testing.T) {
+		query, err := filter.Parse(map[string]interface{}{
+			"or": []interface{}{
+				map[string]interface{}{"name": "DSA"},
+				map[string]interface{}{"and": []interface{}{
+					map[string]interface{}{"resources": map[string]interface{}{"elemMatch": map[string]interface{}{"size": map[string]interface{}{"gt": "5"}}}},
+					map[string]interface{}{"scores": map[string]interface{}{"elemMatch": map[string]interface{}{"gte": "90"}}},
+				}},
+			},
+		}, courseSchema, allowAll)
+
+		assert.Nil(t, err)
+		assert.Equal(t, bson.M{"$or": bson.A{
+			bson.M{"name": "DSA"},
+			bson.M{"$and": bson.A{
+				bson.M{"resources": bson.M{"$elemMatch": bson.M{"size": bson.M{"$gt": int64(5)}}}},
+				bson.M{"scores": bson.M{"$elemMatch": bson.M{"$gte": int64(90)}}},
+			}},
+		}}, query)
+	})
+	t.Run("TestParse_ShouldReturnFieldNotAllowed_WhenFieldIsRestricted", func(t *testing.T) {
+		_, err := filter.Parse(map[string]interface{}{"or": []interface{}{map[string]interface{}{"price": "10"}}}, courseSchema, func(field string) bool {
+			return field == "name"
+		})
+
+		assert.ErrorIs(t, err, filter.ErrFieldNotAllowed)
+		assert.Equal(t, "filter attribute not allowed: price", err.Error())
+	})
+	t.Run("TestParse_ShouldReturnError_WhenFilterIsInvalid", func(t *testing.T) {
+		for _, document := range []map[string]interface{}{
+			{"seats": "ten"},
+			{"seats": float64(1.5)},
+			{"starts_on": "yesterday"},
+			{"name": map[string]interface{}{"between": "a"}},
+			{"name": map[string]interface{}{}},
+			{"name": map[string]interface{}{"in": "DSA"}},
+			{"name": map[string]interface{}{"prefix": "a", "regex": "b"}},
+			{"name": map[string]interface{}{"regex": ""}},
+			{"name": map[string]interface{}{"regex": strings.Repeat("a", constants.MaxFilterRegexLength+1)}},
+			{"name": map[string]interface{}{"exists": "maybe"}},
+			{"tags": map[string]interface{}{"elemMatch": "go"}},
+			{"or": []interface{}{}},
+			{"and": []interface{}{"name"}},
+		} {
+			_, err := filter.Parse(document, courseSchema, allowAll)
+			assert.NotNil(t, err, document)
+			assert.NotErrorIs(t, err, filter.ErrFieldNotAllowed, document)
+		}
+	})
+	t.Run("TestParse_ShouldPassRegexToMongo_WhenPatternIsPcre", func(t *testing.T) {
+		query, err := filter.Parse(map[string]interface{}{"name": map[string]interface{}{"regex": "^(?=.*go)(?!.*legacy)"}}, courseSchema, allowAll)
+
+		assert.Nil(t, err)
+		assert.Equal(t, bson.M{"name": bson.M{"$regex": "^(?=.*go)(?!.*legacy)"}}, query)
+	})
+	t.Run("TestParse_ShouldKeepValues_WhenFieldHasNoDeclaredType", func(t *testing.T) {
+		query, err := filter.Parse(map[string]interface{}{"code": map[string]interface{}{"eq": "42"}}, nil, allowAll)
+
+		assert.Nil(t, err)
+		assert.Equal(t, bson.M{"code": bson.M{"$eq": "42"}}, query)
+	})
+}
+
+func TestIsNumeric(t *testing.T) {
+	for field, expected := range map[string]bool{
+		"price":            true,
+		"seats":            true,
+		"attributes.level": true,
+		"version":          true,
+		"name":             false,
+		"scores":           false,
+		"starts_on":        false,
+		"code":             false,
+	} {
+		assert.Equal(t, expected, filter.IsNumeric(courseSchema, field), field)
+	}
+}
+
+func TestCoerceDocument(t *testing.T) {
+	t.Run("TestCoerceDocument_ShouldCoerceNestedValuesToSchemaTypes", func(t *testing.T) {
+		document, err := filter.CoerceDocument(map[string]interface{}{
+			"name":       "DSA",
+			"price":      "10.5",
+			"seats":      float64(20),
+			"starts_on":  "2026-01-02",
+			"is_active":  "true",
+			"scores":     `[1, 2]`,
+			"resources":  []interface{}{map[string]interface{}{"size": "3"}},
+			"attributes": map[string]interface{}{"level": "2", "note": "kept"},
+			"code":       "42",
+		}, courseSchema)
+
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"name":       "DSA",
+			"price":      10.5,
+			"seats":      int64(20),
+			"starts_on":  time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+			"is_active":  true,
+			"scores":     []interface{}{int64(1), int64(2)},
+			"resources":  []interface{}{map[string]interface{}{"size": int64(3)}},
+			"attributes": map[string]interface{}{"level": int64(2), "note": "kept"},
+			"code":       "42",
+		}, document)
+	})
+	t.Run("TestCoerceDocument_ShouldReturnError_WhenValueDoesNotMatchType", func(t *testing.T) {
+		_, err := filter.CoerceDocument(map[string]interface{}{"attributes": map[string]interface{}{"level": "two"}}, courseSchema)
+
+		assert.Equal(t, `attributes.level: "two" is not a valid int`, err.Error())
+		_, err = filter.CoerceDocument(map[string]interface{}{"tags": "[go"}, courseSchema)
+		assert.NotNil(t, err)
+	})
+}
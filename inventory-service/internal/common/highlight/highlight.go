@@ -0,0 +1,124 @@
+package highlight
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// snippets are cut to SnippetLength runes, starting up to SnippetContext runes before the first match
+const (
+	SnippetLength  = 160
+	SnippetContext = 40
+	StartTag       = "<em>"
+	EndTag         = "</em>"
+	Ellipsis       = "…"
+)
+
+type span struct {
+	start int
+	end   int
+}
+
+// Terms : lower cased words of a text search query. Negated words are left out and phrases are split into their words
+func Terms(query string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, token := range strings.Fields(query) {
+		if strings.HasPrefix(token, "-") {
+			continue
+		}
+		for _, word := range strings.FieldsFunc(strings.ToLower(token), func(r rune) bool { return !isWordRune(r) }) {
+			if !seen[word] {
+				seen[word] = true
+				terms = append(terms, word)
+			}
+		}
+	}
+	return terms
+}
+
+// Snippet : html escaped excerpt of the text around its first match, words starting with a term are wrapped in StartTag
+// and EndTag. Matching by prefix approximates the stemming of the text index, false when no word matches
+func Snippet(text string, terms []string) (string, bool) {
+	runes := []rune(text)
+	var matches []span
+	for _, word := range words(runes) {
+		lowerWord := strings.ToLower(string(runes[word.start:word.end]))
+		for _, term := range terms {
+			if strings.HasPrefix(lowerWord, term) {
+				matches = append(matches, word)
+				break
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return "", false
+	}
+
+	first := matches[0]
+	start := 0
+	if first.start > SnippetContext {
+		start = first.start - SnippetContext
+		//do not start inside a word
+		for start < first.start && isWordRune(runes[start-1]) {
+			start++
+		}
+		for start < first.start && unicode.IsSpace(runes[start]) {
+			start++
+		}
+	}
+	end := len(runes)
+	if end-start > SnippetLength {
+		end = start + SnippetLength
+		if end < first.end {
+			end = first.end
+		}
+		//do not end inside a word
+		for end > first.end && isWordRune(runes[end]) && isWordRune(runes[end-1]) {
+			end--
+		}
+		for end > first.end && unicode.IsSpace(runes[end-1]) {
+			end--
+		}
+	}
+
+	var snippet strings.Builder
+	if start > 0 {
+		snippet.WriteString(Ellipsis)
+	}
+	position := start
+	for _, match := range matches {
+		if match.end > end {
+			break
+		}
+		snippet.WriteString(html.EscapeString(string(runes[position:match.start])))
+		snippet.WriteString(StartTag + html.EscapeString(string(runes[match.start:match.end])) + EndTag)
+		position = match.end
+	}
+	snippet.WriteString(html.EscapeString(string(runes[position:end])))
+	if end < len(runes) {
+		snippet.WriteString(Ellipsis)
+	}
+	return snippet.String(), true
+}
+
+func words(runes []rune) []span {
+	var spans []span
+	for index := 0; index < len(runes); index++ {
+		if !isWordRune(runes[index]) {
+			continue
+		}
+		word := span{start: index}
+		for index < len(runes) && isWordRune(runes[index]) {
+			index++
+		}
+		word.end = index
+		spans = append(spans, word)
+	}
+	return spans
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,33 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"inventory-system/inventory-service/internal/common/highlight"
+	"strings"
+	"testing"
+)
+
+func TestTerms(t *testing.T) {
+	t.Run("TestTerms_ShouldSplitPhrasesAndSkipNegatedWords", func(t *testing.T) {
+		assert.Equal(t, []string{"graph", "shortest", "path"}, highlight.Terms(`Graph "shortest path" -tree graph`))
+	})
+}
+
+func TestSnippet(t *testing.T) {
+	t.Run("TestSnippet_ShouldHighlightWordsStartingWithTerms", func(t *testing.T) {
+		snippet, found := highlight.Snippet("Graphs & trees: a graph is <b>not</b> a tree", []string{"graph"})
+		assert.True(t, found)
+		assert.Equal(t, "<em>Graphs</em> &amp; trees: a <em>graph</em> is &lt;b&gt;not&lt;/b&gt; a tree", snippet)
+	})
+	t.Run("TestSnippet_ShouldCutLongTextsAroundFirstMatch", func(t *testing.T) {
+		text := strings.Repeat("lorem ipsum ", 20) + "dijkstra finds shortest paths " + strings.Repeat("dolor sit ", 30)
+		snippet, found := highlight.Snippet(text, []string{"dijkstra"})
+
+		assert.True(t, found)
+		assert.Equal(t, "…lorem ipsum lorem ipsum lorem ipsum <em>dijkstra</em> finds shortest paths"+strings.Repeat(" dolor sit", 9)+"…", snippet)
+	})
+	t.Run("TestSnippet_ShouldReturnFalse_WhenNothingMatches", func(t *testing.T) {
+		_, found := highlight.Snippet("binary search", []string{"graph"})
+		assert.False(t, found)
+	})
+}
@@ -0,0 +1,25 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"inventory-system/inventory-service/internal/common/identifier"
+	"testing"
+	"time"
+)
+
+func TestNewULID(t *testing.T) {
+	t.Run("TestNewULID_ShouldEncodeTimestampInFirstTenCharacters", func(t *testing.T) {
+		id, err := identifier.NewULID(time.UnixMilli(1469918176385))
+
+		assert.Nil(t, err)
+		assert.Len(t, id, 26)
+		assert.Equal(t, "01ARYZ6S41", id[:10])
+	})
+	t.Run("TestNewULID_ShouldSortByCreationTime", func(t *testing.T) {
+		now := time.Now()
+		earlier, _ := identifier.NewULID(now)
+		later, _ := identifier.NewULID(now.Add(time.Millisecond))
+
+		assert.Less(t, earlier, later)
+	})
+}
@@ -0,0 +1,35 @@
+package identifier
+
+import (
+	"crypto/rand"
+	"math/big"
+	"time"
+)
+
+const (
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	ulidLength        = 26
+)
+
+// NewULID : 26 character lexicographically sortable id, 48 bit millisecond timestamp followed by 80 random bits
+func NewULID(now time.Time) (string, error) {
+	var data [16]byte
+	milliseconds := uint64(now.UnixMilli())
+	for index := 5; index >= 0; index-- {
+		data[index] = byte(milliseconds)
+		milliseconds >>= 8
+	}
+	if _, err := rand.Read(data[6:]); err != nil {
+		return "", err
+	}
+
+	value := new(big.Int).SetBytes(data[:])
+	base := big.NewInt(int64(len(crockfordAlphabet)))
+	digit := new(big.Int)
+	encoded := make([]byte, ulidLength)
+	for index := ulidLength - 1; index >= 0; index-- {
+		value.DivMod(value, base, digit)
+		encoded[index] = crockfordAlphabet[digit.Int64()]
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,180 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"inventory-system/common/pkg/utils"
+	"inventory-system/inventory-service/internal/common/filter"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// import formats, ndjson holds one json item per line
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// MaxLineSize : longest ndjson line accepted
+const MaxLineSize = 1 << 20
+
+// ErrUnknownFormat : returned for a format other than csv or ndjson
+var ErrUnknownFormat = errors.New("unknown import format, expected csv or ndjson")
+
+// Row : item read from a line of the file, Number is the line it starts on. Err is set when the line cannot be read as an item
+type Row struct {
+	Number int
+	Item   map[string]interface{}
+	Err    error
+}
+
+// Reader : reads the rows of an import file one at a time, io.EOF after the last row. Any other error means the rest of
+// the file cannot be read
+type Reader interface {
+	Next() (*Row, error)
+}
+
+// FormatOf : import format of a file name by its extension, empty when unknown
+func FormatOf(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return FormatCSV
+	case ".ndjson", ".jsonl":
+		return FormatNDJSON
+	}
+	return ""
+}
+
+// NewReader : reader of the file in the format, values are coerced to the types of the json schema. Csv files start
+// with a header of dot paths, nested paths build nested objects and empty cells leave the field out
+func NewReader(format string, r io.Reader, jsonSchema map[string]interface{}) (Reader, error) {
+	switch format {
+	case FormatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), MaxLineSize)
+		return &ndjsonRowReader{scanner: scanner, jsonSchema: jsonSchema}, nil
+	case FormatCSV:
+		csvReader := csv.NewReader(r)
+		csvReader.ReuseRecord = true
+		header, err := csvReader.Read()
+		if err != nil {
+			if err == io.EOF {
+				return nil, errors.New("csv file has no header")
+			}
+			return nil, fmt.Errorf("invalid csv header: %w", err)
+		}
+		columns, err := parseHeader(header)
+		if err != nil {
+			return nil, err
+		}
+		csvReader.FieldsPerRecord = len(columns)
+		return &csvRowReader{reader: csvReader, columns: columns, jsonSchema: jsonSchema}, nil
+	}
+	return nil, ErrUnknownFormat
+}
+
+// parseHeader : column paths of the header, a column cannot be repeated nor hold both a value and nested fields
+func parseHeader(header []string) ([][]string, error) {
+	columns := make([][]string, 0, len(header))
+	seen := map[string]bool{}
+	for index, name := range header {
+		name = strings.TrimSpace(name)
+		if index == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		path := strings.Split(name, ".")
+		for _, segment := range path {
+			if segment == "" || strings.HasPrefix(segment, "$") {
+				return nil, fmt.Errorf("invalid csv column %s", strconv.Quote(name))
+			}
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("csv column %s is repeated", strconv.Quote(name))
+		}
+		for other := range seen {
+			if strings.HasPrefix(name, other+".") || strings.HasPrefix(other, name+".") {
+				return nil, fmt.Errorf("csv columns %s and %s overlap", strconv.Quote(other), strconv.Quote(name))
+			}
+		}
+		seen[name] = true
+		columns = append(columns, path)
+	}
+	return columns, nil
+}
+
+type csvRowReader struct {
+	reader     *csv.Reader
+	columns    [][]string
+	jsonSchema map[string]interface{}
+}
+
+func (r *csvRowReader) Next() (*Row, error) {
+	record, err := r.reader.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return &Row{Number: parseErr.StartLine, Err: parseErr.Err}, nil
+		}
+		return nil, err
+	}
+	line, _ := r.reader.FieldPos(0)
+
+	item := map[string]interface{}{}
+	for index, path := range r.columns {
+		if record[index] == "" {
+			continue
+		}
+		current := item
+		for _, segment := range path[:len(path)-1] {
+			next, isMap := utils.AsMap(current[segment])
+			if !isMap {
+				next = map[string]interface{}{}
+				current[segment] = next
+			}
+			current = next
+		}
+		current[path[len(path)-1]] = record[index]
+	}
+	return newRow(line, item, r.jsonSchema), nil
+}
+
+type ndjsonRowReader struct {
+	scanner    *bufio.Scanner
+	jsonSchema map[string]interface{}
+	line       int
+}
+
+func (r *ndjsonRowReader) Next() (*Row, error) {
+	for r.scanner.Scan() {
+		r.line++
+		text := strings.TrimSpace(r.scanner.Text())
+		if text == "" {
+			continue
+		}
+		var item map[string]interface{}
+		if err := json.Unmarshal([]byte(text), &item); err != nil || item == nil {
+			return &Row{Number: r.line, Err: errors.New("line is not a json object")}, nil
+		}
+		return newRow(r.line, item, r.jsonSchema), nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("line %d: %w", r.line+1, err)
+	}
+	return nil, io.EOF
+}
+
+func newRow(number int, item map[string]interface{}, jsonSchema map[string]interface{}) *Row {
+	coercedItem, err := filter.CoerceDocument(item, jsonSchema)
+	if err != nil {
+		return &Row{Number: number, Err: err}
+	}
+	return &Row{Number: number, Item: coercedItem}
+}
@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"inventory-system/inventory-service/internal/common/importer"
+	"io"
+	"strings"
+	"testing"
+)
+
+var courseSchema = map[string]interface{}{
+	"bsonType": "object",
+	"properties": map[string]interface{}{
+		"title":      map[string]interface{}{"bsonType": "string"},
+		"price":      map[string]interface{}{"bsonType": "double"},
+		"tags":       map[string]interface{}{"bsonType": "array", "items": map[string]interface{}{"bsonType": "string"}},
+		"attributes": map[string]interface{}{"bsonType": "object", "properties": map[string]interface{}{"level": map[string]interface{}{"bsonType": "int"}, "language": map[string]interface{}{"bsonType": "string"}}},
+	},
+}
+
+func readRows(t *testing.T, format string, content string) []*importer.Row {
+	reader, err := importer.NewReader(format, strings.NewReader(content), courseSchema)
+	assert.Nil(t, err)
+	var rows []*importer.Row
+	for {
+		row, err := reader.Next()
+		if err == io.EOF {
+			return rows
+		}
+		assert.Nil(t, err)
+		rows = append(rows, row)
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	assert.Equal(t, importer.FormatCSV, importer.FormatOf("courses.CSV"))
+	assert.Equal(t, importer.FormatNDJSON, importer.FormatOf("courses.jsonl"))
+	assert.Equal(t, "", importer.FormatOf("courses.xlsx"))
+}
+
+func TestCSVReader(t *testing.T) {
+	t.Run("TestCSVReader_ShouldBuildNestedItemsOfSchemaTypes", func(t *testing.T) {
+		rows := readRows(t, importer.FormatCSV, "\ufefftitle,price,tags,attributes.level,attributes.language\n"+
+			"\"Graphs, trees\",10.5,\"[\"\"dsa\"\"]\",2,\n"+
+			"Go,,,,en\n")
+
+		assert.Len(t, rows, 2)
+		assert.Equal(t, 2, rows[0].Number)
+		assert.Equal(t, map[string]interface{}{
+			"title":      "Graphs, trees",
+			"price":      10.5,
+			"tags":       []interface{}{"dsa"},
+			"attributes": map[string]interface{}{"level": int64(2)},
+		}, rows[0].Item)
+		assert.Equal(t, map[string]interface{}{"title": "Go", "attributes": map[string]interface{}{"language": "en"}}, rows[1].Item)
+	})
+	t.Run("TestCSVReader_ShouldRejectRow_WhenValueOrFieldCountIsInvalid", func(t *testing.T) {
+		rows := readRows(t, importer.FormatCSV, "title,attributes.level\nGo,two\nGraphs\nDSA,3\n")
+
+		assert.Len(t, rows, 3)
+		assert.Equal(t, `attributes.level: "two" is not a valid int`, rows[0].Err.Error())
+		assert.Equal(t, 3, rows[1].Number)
+		assert.NotNil(t, rows[1].Err)
+		assert.Nil(t, rows[2].Err)
+		assert.Equal(t, 4, rows[2].Number)
+	})
+	t.Run("TestCSVReader_ShouldReturnError_WhenHeaderIsInvalid", func(t *testing.T) {
+		for header, message := range map[string]string{
+			"":                              "csv file has no header",
+			"title,title\n":                 `csv column "title" is repeated`,
+			"attributes,attributes.level\n": `csv columns "attributes" and "attributes.level" overlap`,
+			"title,$where\n":                `invalid csv column "$where"`,
+			"title,attributes.\n":           `invalid csv column "attributes."`,
+		} {
+			_, err := importer.NewReader(importer.FormatCSV, strings.NewReader(header), courseSchema)
+			assert.Equal(t, message, err.Error())
+		}
+	})
+}
+
+func TestNDJSONReader(t *testing.T) {
+	t.Run("TestNDJSONReader_ShouldSkipBlankLinesAndRejectNonObjects", func(t *testing.T) {
+		rows := readRows(t, importer.FormatNDJSON, `{"title":"Go","attributes":{"level":2}}`+"\n\n[1]\n"+`{"price":"cheap"}`+"\n")
+
+		assert.Len(t, rows, 3)
+		assert.Equal(t, map[string]interface{}{"title": "Go", "attributes": map[string]interface{}{"level": int64(2)}}, rows[0].Item)
+		assert.Equal(t, 3, rows[1].Number)
+		assert.Equal(t, "line is not a json object", rows[1].Err.Error())
+		assert.Equal(t, 4, rows[2].Number)
+		assert.NotNil(t, rows[2].Err)
+	})
+	t.Run("TestNDJSONReader_ShouldReturnError_WhenLineIsTooLong", func(t *testing.T) {
+		reader, _ := importer.NewReader(importer.FormatNDJSON, strings.NewReader(`{"title":"`+strings.Repeat("a", importer.MaxLineSize)+`"}`), courseSchema)
+		_, err := reader.Next()
+
+		assert.NotNil(t, err)
+		assert.NotEqual(t, io.EOF, err)
+	})
+	t.Run("TestNewReader_ShouldReturnError_WhenFormatIsUnknown", func(t *testing.T) {
+		_, err := importer.NewReader("xlsx", strings.NewReader(""), courseSchema)
+
+		assert.Equal(t, importer.ErrUnknownFormat, err)
+	})
+}
@@ -0,0 +1,61 @@
+package patch
+
+import (
+	"inventory-system/common/pkg/utils"
+	"sort"
+	"strconv"
+)
+
+// Diff : JSON Patch turning the from document into the to document. Objects are compared member by member, arrays element by
+// element while their length is unchanged, any other difference replaces the whole value
+func Diff(from map[string]interface{}, to map[string]interface{}) []Operation {
+	operations := []Operation{}
+	return diffObject(operations, []string{}, from, to)
+}
+
+func diffObject(operations []Operation, path []string, from map[string]interface{}, to map[string]interface{}) []Operation {
+	for _, key := range sortedKeys(from) {
+		if _, exists := to[key]; !exists {
+			operations = append(operations, Operation{Op: OperationRemove, Path: displayPointer(append(append([]string{}, path...), key))})
+		}
+	}
+	for _, key := range sortedKeys(to) {
+		fieldPath := append(append([]string{}, path...), key)
+		fromValue, exists := from[key]
+		if !exists {
+			operations = append(operations, Operation{Op: OperationAdd, Path: displayPointer(fieldPath), Value: Clone(to[key])})
+			continue
+		}
+		operations = diffValue(operations, fieldPath, fromValue, to[key])
+	}
+	return operations
+}
+
+func diffValue(operations []Operation, path []string, from interface{}, to interface{}) []Operation {
+	if jsonEqual(from, to) {
+		return operations
+	}
+	fromObject, isFromObject := utils.AsMap(from)
+	toObject, isToObject := utils.AsMap(to)
+	if isFromObject && isToObject {
+		return diffObject(operations, path, fromObject, toObject)
+	}
+	fromArray, isFromArray := utils.AsSlice(from)
+	toArray, isToArray := utils.AsSlice(to)
+	if isFromArray && isToArray && len(fromArray) == len(toArray) {
+		for index := range toArray {
+			operations = diffValue(operations, append(append([]string{}, path...), strconv.Itoa(index)), fromArray[index], toArray[index])
+		}
+		return operations
+	}
+	return append(operations, Operation{Op: OperationReplace, Path: displayPointer(path), Value: Clone(to)})
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
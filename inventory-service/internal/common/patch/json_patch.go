@@ -0,0 +1,308 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"inventory-system/common/pkg/utils"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// JSON Patch operations of RFC 6902
+const (
+	OperationAdd     = "add"
+	OperationRemove  = "remove"
+	OperationReplace = "replace"
+	OperationMove    = "move"
+	OperationCopy    = "copy"
+	OperationTest    = "test"
+)
+
+// ErrTestFailed : returned when a test operation does not match the document
+var ErrTestFailed = errors.New("test operation failed")
+
+type Operation struct {
+	Op    string      `json:"op" bson:"op"`
+	Path  string      `json:"path" bson:"path"`
+	From  string      `json:"from,omitempty" bson:"from,omitempty"`
+	Value interface{} `json:"value,omitempty" bson:"value,omitempty"`
+}
+
+// ApplyJSONPatch : applies the operations in order to a copy of the document, the document itself is left untouched.
+// Either every operation applies or an error naming the failing operation is returned
+func ApplyJSONPatch(document map[string]interface{}, operations []Operation) (map[string]interface{}, error) {
+	var root interface{} = Clone(document)
+	for index, operation := range operations {
+		var err error
+		root, err = applyOperation(root, operation)
+		if err != nil {
+			if errors.Is(err, ErrTestFailed) {
+				return nil, fmt.Errorf("operation %d: %w at %s", index, ErrTestFailed, displayPath(operation.Path))
+			}
+			return nil, fmt.Errorf("operation %d: %s", index, err.Error())
+		}
+	}
+	patchedDocument, isMap := root.(map[string]interface{})
+	if !isMap {
+		return nil, errors.New("patched document is not an object")
+	}
+	return patchedDocument, nil
+}
+
+func applyOperation(root interface{}, operation Operation) (interface{}, error) {
+	path, err := parsePointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch operation.Op {
+	case OperationAdd:
+		return addValue(root, path, Clone(operation.Value))
+	case OperationRemove:
+		root, _, err = removeValue(root, path)
+		return root, err
+	case OperationReplace:
+		root, _, err = removeValue(root, path)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(root, path, Clone(operation.Value))
+	case OperationMove:
+		from, err := parsePointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		if isPrefix(from, path) && len(from) < len(path) {
+			return nil, errors.New("cannot move " + displayPath(operation.From) + " into itself")
+		}
+		root, value, err := removeValue(root, from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(root, path, value)
+	case OperationCopy:
+		from, err := parsePointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := getValue(root, from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(root, path, Clone(value))
+	case OperationTest:
+		value, err := getValue(root, path)
+		if err != nil {
+			return nil, err
+		}
+		if !jsonEqual(value, operation.Value) {
+			return nil, ErrTestFailed
+		}
+		return root, nil
+	}
+	return nil, errors.New("unknown op " + strconv.Quote(operation.Op))
+}
+
+func addValue(root interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := getValue(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	key := path[len(path)-1]
+	if object, isMap := utils.AsMap(parent); isMap {
+		object[key] = value
+		return root, nil
+	}
+	array, isSlice := utils.AsSlice(parent)
+	if !isSlice {
+		return nil, errors.New(displayPointer(path[:len(path)-1]) + " is not a container")
+	}
+	index := len(array)
+	if key != "-" {
+		index, err = arrayIndex(key, len(array)+1)
+		if err != nil {
+			return nil, errors.New(displayPointer(path) + ": " + err.Error())
+		}
+	}
+	updatedArray := make([]interface{}, 0, len(array)+1)
+	updatedArray = append(updatedArray, array[:index]...)
+	updatedArray = append(updatedArray, value)
+	updatedArray = append(updatedArray, array[index:]...)
+	return setValue(root, path[:len(path)-1], updatedArray)
+}
+
+func removeValue(root interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("cannot remove the whole document")
+	}
+	parent, err := getValue(root, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	key := path[len(path)-1]
+	if object, isMap := utils.AsMap(parent); isMap {
+		value, exists := object[key]
+		if !exists {
+			return nil, nil, errors.New(displayPointer(path) + " does not exist")
+		}
+		delete(object, key)
+		return root, value, nil
+	}
+	array, isSlice := utils.AsSlice(parent)
+	if !isSlice {
+		return nil, nil, errors.New(displayPointer(path[:len(path)-1]) + " is not a container")
+	}
+	index, err := arrayIndex(key, len(array))
+	if err != nil {
+		return nil, nil, errors.New(displayPointer(path) + ": " + err.Error())
+	}
+	value := array[index]
+	updatedArray := make([]interface{}, 0, len(array)-1)
+	updatedArray = append(updatedArray, array[:index]...)
+	updatedArray = append(updatedArray, array[index+1:]...)
+	root, err = setValue(root, path[:len(path)-1], updatedArray)
+	return root, value, err
+}
+
+// setValue : replaces the value at an existing path, arrays change length so they are written back into their parent
+func setValue(root interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := getValue(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	key := path[len(path)-1]
+	if object, isMap := utils.AsMap(parent); isMap {
+		object[key] = value
+		return root, nil
+	}
+	array, _ := utils.AsSlice(parent)
+	index, err := arrayIndex(key, len(array))
+	if err != nil {
+		return nil, err
+	}
+	array[index] = value
+	return root, nil
+}
+
+func getValue(root interface{}, path []string) (interface{}, error) {
+	current := root
+	for depth, key := range path {
+		if object, isMap := utils.AsMap(current); isMap {
+			value, exists := object[key]
+			if !exists {
+				return nil, errors.New(displayPointer(path[:depth+1]) + " does not exist")
+			}
+			current = value
+			continue
+		}
+		array, isSlice := utils.AsSlice(current)
+		if !isSlice {
+			return nil, errors.New(displayPointer(path[:depth]) + " is not a container")
+		}
+		index, err := arrayIndex(key, len(array))
+		if err != nil {
+			return nil, errors.New(displayPointer(path[:depth+1]) + ": " + err.Error())
+		}
+		current = array[index]
+	}
+	return current, nil
+}
+
+func arrayIndex(key string, length int) (int, error) {
+	if key == "" || (len(key) > 1 && key[0] == '0') {
+		return 0, errors.New("invalid array index " + strconv.Quote(key))
+	}
+	index, err := strconv.Atoi(key)
+	if err != nil || index < 0 {
+		return 0, errors.New("invalid array index " + strconv.Quote(key))
+	}
+	if index >= length {
+		return 0, errors.New("array index " + key + " out of range")
+	}
+	return index, nil
+}
+
+// parsePointer : splits a JSON pointer of RFC 6901 into its unescaped reference tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, errors.New("path " + strconv.Quote(pointer) + " must start with /")
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for index, token := range tokens {
+		tokens[index] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func displayPointer(path []string) string {
+	escaped := make([]string, 0, len(path))
+	for _, token := range path {
+		escaped = append(escaped, strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return displayPath("/" + strings.Join(escaped, "/"))
+}
+
+func displayPath(pointer string) string {
+	if pointer == "" {
+		return "/"
+	}
+	return pointer
+}
+
+func isPrefix(prefix []string, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for index := range prefix {
+		if prefix[index] != path[index] {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonEqual : compares values the way JSON sees them, so an int64 read from mongo equals the float64 sent by a client
+func jsonEqual(left interface{}, right interface{}) bool {
+	return reflect.DeepEqual(normalize(left), normalize(right))
+}
+
+func normalize(value interface{}) interface{} {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return value
+	}
+	return decoded
+}
+
+// Clone : deep copy of a document, nested bson documents and arrays become plain maps and slices
+func Clone(value interface{}) interface{} {
+	if object, isMap := utils.AsMap(value); isMap {
+		cloned := make(map[string]interface{}, len(object))
+		for key, fieldValue := range object {
+			cloned[key] = Clone(fieldValue)
+		}
+		return cloned
+	}
+	if array, isSlice := utils.AsSlice(value); isSlice {
+		cloned := make([]interface{}, len(array))
+		for index, element := range array {
+			cloned[index] = Clone(element)
+		}
+		return cloned
+	}
+	return value
+}
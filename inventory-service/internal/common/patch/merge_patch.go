@@ -0,0 +1,28 @@
+package patch
+
+import "inventory-system/common/pkg/utils"
+
+// ApplyMergePatch : applies a JSON Merge Patch of RFC 7396 to a copy of the document, null members remove fields and objects merge recursively
+func ApplyMergePatch(document map[string]interface{}, mergePatch map[string]interface{}) map[string]interface{} {
+	return mergeObject(Clone(document).(map[string]interface{}), mergePatch)
+}
+
+func mergeObject(target map[string]interface{}, mergePatch map[string]interface{}) map[string]interface{} {
+	for key, patchValue := range mergePatch {
+		if patchValue == nil {
+			delete(target, key)
+			continue
+		}
+		patchObject, isPatchObject := utils.AsMap(patchValue)
+		if !isPatchObject {
+			target[key] = Clone(patchValue)
+			continue
+		}
+		targetObject, isTargetObject := target[key].(map[string]interface{})
+		if !isTargetObject {
+			targetObject = map[string]interface{}{}
+		}
+		target[key] = mergeObject(targetObject, patchObject)
+	}
+	return target
+}
@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"inventory-system/inventory-service/internal/common/patch"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func topic() bson.M {
+	return bson.M{
+		"id":         "T1",
+		"topic_name": "Arrays",
+		"version":    int64(2),
+		"resources":  bson.A{bson.M{"type": "video"}, bson.M{"type": "pdf"}},
+		"meta":       bson.M{"level": "easy", "tags": bson.A{"dsa"}},
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	t.Run("TestApplyJSONPatch_ShouldApplyOperationsInOrder", func(t *testing.T) {
+		document := topic()
+		patched, err := patch.ApplyJSONPatch(document, []patch.Operation{
+			{Op: patch.OperationTest, Path: "/version", Value: float64(2)},
+			{Op: patch.OperationAdd, Path: "/resources/-", Value: map[string]interface{}{"type": "quiz"}},
+			{Op: patch.OperationReplace, Path: "/resources/0/type", Value: "audio"},
+			{Op: patch.OperationRemove, Path: "/meta/level"},
+			{Op: patch.OperationMove, From: "/topic_name", Path: "/title"},
+			{Op: patch.OperationCopy, From: "/meta/tags/0", Path: "/meta/tags/0"},
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"type": "audio"},
+			map[string]interface{}{"type": "pdf"},
+			map[string]interface{}{"type": "quiz"},
+		}, patched["resources"])
+		assert.Equal(t, map[string]interface{}{"tags": []interface{}{"dsa", "dsa"}}, patched["meta"])
+		assert.Equal(t, "Arrays", patched["title"])
+		assert.NotContains(t, patched, "topic_name")
+		assert.Equal(t, topic(), document)
+	})
+	t.Run("TestApplyJSONPatch_ShouldReturnError_WhenTestFails", func(t *testing.T) {
+		_, err := patch.ApplyJSONPatch(topic(), []patch.Operation{{Op: patch.OperationTest, Path: "/topic_name", Value: "Graphs"}})
+
+		assert.ErrorIs(t, err, patch.ErrTestFailed)
+		assert.Equal(t, "operation 0: test operation failed at /topic_name", err.Error())
+	})
+	t.Run("TestApplyJSONPatch_ShouldReturnError_WhenPathInvalid", func(t *testing.T) {
+		for _, operation := range []patch.Operation{
+			{Op: patch.OperationRemove, Path: "/missing"},
+			{Op: patch.OperationReplace, Path: "/resources/5", Value: "x"},
+			{Op: patch.OperationAdd, Path: "/resources/01", Value: "x"},
+			{Op: patch.OperationAdd, Path: "topic_name", Value: "x"},
+			{Op: patch.OperationMove, From: "/meta", Path: "/meta/inner"},
+			{Op: patch.OperationRemove, Path: ""},
+			{Op: "increment", Path: "/version"},
+		} {
+			_, err := patch.ApplyJSONPatch(topic(), []patch.Operation{operation})
+			assert.NotNil(t, err, operation)
+		}
+	})
+	t.Run("TestApplyJSONPatch_ShouldUnescapePointerTokens", func(t *testing.T) {
+		patched, err := patch.ApplyJSONPatch(bson.M{"a/b": "x", "c~d": "y"}, []patch.Operation{
+			{Op: patch.OperationRemove, Path: "/a~1b"},
+			{Op: patch.OperationReplace, Path: "/c~0d", Value: "z"},
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{"c~d": "z"}, patched)
+	})
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	t.Run("TestApplyMergePatch_ShouldMergeObjectsAndRemoveNullMembers", func(t *testing.T) {
+		document := topic()
+		patched := patch.ApplyMergePatch(document, map[string]interface{}{
+			"topic_name": nil,
+			"meta":       map[string]interface{}{"level": "hard", "tags": nil, "new": map[string]interface{}{"a": nil, "b": float64(1)}},
+			"resources":  []interface{}{"replaced"},
+		})
+
+		assert.NotContains(t, patched, "topic_name")
+		assert.Equal(t, map[string]interface{}{"level": "hard", "new": map[string]interface{}{"b": float64(1)}}, patched["meta"])
+		assert.Equal(t, []interface{}{"replaced"}, patched["resources"])
+		assert.Equal(t, topic(), document)
+	})
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("TestDiff_ShouldProducePatchTurningFromIntoTo", func(t *testing.T) {
+		from := topic()
+		to := bson.M{
+			"id":        "T1",
+			"title":     "Arrays",
+			"version":   int64(3),
+			"resources": bson.A{bson.M{"type": "audio"}, bson.M{"type": "pdf"}},
+			"meta":      bson.M{"tags": bson.A{"dsa", "arrays"}},
+		}
+
+		operations := patch.Diff(from, to)
+		patched, err := patch.ApplyJSONPatch(from, operations)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []patch.Operation{
+			{Op: patch.OperationRemove, Path: "/topic_name"},
+			{Op: patch.OperationRemove, Path: "/meta/level"},
+			{Op: patch.OperationReplace, Path: "/meta/tags", Value: []interface{}{"dsa", "arrays"}},
+			{Op: patch.OperationReplace, Path: "/resources/0/type", Value: "audio"},
+			{Op: patch.OperationAdd, Path: "/title", Value: "Arrays"},
+			{Op: patch.OperationReplace, Path: "/version", Value: int64(3)},
+		}, operations)
+		assert.Equal(t, patch.Clone(to), patched)
+	})
+	t.Run("TestDiff_ShouldBeEmpty_WhenDocumentsAreEqual", func(t *testing.T) {
+		assert.Empty(t, patch.Diff(topic(), topic()))
+	})
+}
@@ -0,0 +1,89 @@
+package projection
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Parse : mongo projection returning only the comma separated fields, or everything but the excluded ones. The required
+// fields are always returned, they are added to the fields and cannot be excluded. Nil when neither is given, a path
+// nested in another one is covered by it
+func Parse(fields string, exclude string, required ...string) (bson.M, error) {
+	if fields != "" && exclude != "" {
+		return nil, errors.New("fields and exclude cannot be combined")
+	}
+	if fields == "" && exclude == "" {
+		return nil, nil
+	}
+	isInclusion := fields != ""
+	list := fields
+	if !isInclusion {
+		list = exclude
+	}
+
+	var paths []string
+	for _, field := range strings.Split(list, ",") {
+		field = strings.TrimSpace(field)
+		if !isValidPath(field) {
+			return nil, errors.New("invalid field " + strconv.Quote(field))
+		}
+		paths = addPath(paths, field)
+	}
+
+	value := 1
+	if isInclusion {
+		for _, field := range required {
+			paths = addPath(paths, field)
+		}
+	} else {
+		value = 0
+		for _, field := range required {
+			for _, path := range paths {
+				if covers(path, field) {
+					return nil, errors.New(field + " cannot be excluded")
+				}
+			}
+		}
+	}
+
+	query := bson.M{"_id": 0}
+	for _, path := range paths {
+		query[path] = value
+	}
+	return query, nil
+}
+
+func isValidPath(field string) bool {
+	if field == "" || field == "_id" {
+		return false
+	}
+	for _, segment := range strings.Split(field, ".") {
+		if segment == "" || strings.HasPrefix(segment, "$") {
+			return false
+		}
+	}
+	return true
+}
+
+// addPath : adds the path unless a path covering it is present, paths it covers are replaced. Mongo rejects projections
+// naming both a document and one of its fields
+func addPath(paths []string, field string) []string {
+	kept := make([]string, 0, len(paths)+1)
+	for _, path := range paths {
+		if covers(path, field) {
+			return paths
+		}
+		if !covers(field, path) {
+			kept = append(kept, path)
+		}
+	}
+	return append(kept, field)
+}
+
+// covers : whether the path is the field or one of the documents containing it
+func covers(path string, field string) bool {
+	return path == field || strings.HasPrefix(field, path+".")
+}
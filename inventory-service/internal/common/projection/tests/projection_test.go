@@ -0,0 +1,39 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"inventory-system/inventory-service/internal/common/projection"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("TestParse_ShouldReturnNil_WhenNoProjectionIsRequested", func(t *testing.T) {
+		query, err := projection.Parse("", "", "id")
+		assert.Nil(t, err)
+		assert.Nil(t, query)
+	})
+	t.Run("TestParse_ShouldIncludeRequiredFields", func(t *testing.T) {
+		query, err := projection.Parse("name, attributes.level", "", "id", "name")
+		assert.Nil(t, err)
+		assert.Equal(t, bson.M{"_id": 0, "name": 1, "attributes.level": 1, "id": 1}, query)
+	})
+	t.Run("TestParse_ShouldMergeNestedPaths", func(t *testing.T) {
+		query, err := projection.Parse("resources.url,resources,resources.size", "")
+		assert.Nil(t, err)
+		assert.Equal(t, bson.M{"_id": 0, "resources": 1}, query)
+	})
+	t.Run("TestParse_ShouldExcludeFields", func(t *testing.T) {
+		query, err := projection.Parse("", "resources,assessments", "id")
+		assert.Nil(t, err)
+		assert.Equal(t, bson.M{"_id": 0, "resources": 0, "assessments": 0}, query)
+	})
+	t.Run("TestParse_ShouldReturnError_WhenProjectionIsInvalid", func(t *testing.T) {
+		for _, request := range [][2]string{{"name", "resources"}, {"name,,price", ""}, {"", "$where"}, {"_id", ""}, {"", "attributes."}, {"", "attributes"}} {
+			query, err := projection.Parse(request[0], request[1], "id", "attributes.level")
+			assert.Nil(t, query)
+			assert.NotNil(t, err, request)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package schema
+
+import (
+	"inventory-system/common/pkg/utils"
+	"math"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CoerceIntegers : converts in place the numbers of int and long properties to the integer type mongo stores for them.
+// Documents bound from json carry every number as float64 and mongo rejects a double for an int or long property, while
+// values coerced for filters are int64 which mongo rejects for an int property. Keys may be dotted paths as in a $set
+func CoerceIntegers(jsonSchema map[string]interface{}, document map[string]interface{}) {
+	for key, value := range document {
+		if propertySchema, found := PropertySchema(jsonSchema, key); found {
+			document[key] = coerceInteger(propertySchema, value)
+		}
+	}
+}
+
+func coerceInteger(propertySchema map[string]interface{}, value interface{}) interface{} {
+	switch document := value.(type) {
+	case map[string]interface{}:
+		CoerceIntegers(propertySchema, document)
+		return document
+	case primitive.M:
+		CoerceIntegers(propertySchema, document)
+		return document
+	}
+	if elements, isList := utils.AsSlice(value); isList {
+		if itemSchema, ok := utils.AsMap(propertySchema["items"]); ok {
+			for index, element := range elements {
+				elements[index] = coerceInteger(itemSchema, element)
+			}
+		}
+		return value
+	}
+
+	var number float64
+	switch typedValue := value.(type) {
+	case float64:
+		number = typedValue
+	case int64:
+		number = float64(typedValue)
+	default:
+		return value
+	}
+	if number != math.Trunc(number) {
+		return value
+	}
+	bsonTypes, ok := utils.AsSlice(propertySchema["bsonType"])
+	if !ok {
+		bsonTypes = []interface{}{propertySchema["bsonType"]}
+	}
+	isInt, isLong := false, false
+	for _, bsonType := range bsonTypes {
+		switch bsonType {
+		case "double", "number", "decimal":
+			//the value is valid as it is
+			return value
+		case "int":
+			isInt = true
+		case "long":
+			isLong = true
+		}
+	}
+	if isInt && number >= math.MinInt32 && number <= math.MaxInt32 {
+		return int32(number)
+	}
+	if _, isFloat := value.(float64); isFloat && isLong && number >= math.MinInt64 && number < math.MaxInt64 {
+		return int64(number)
+	}
+	return value
+}
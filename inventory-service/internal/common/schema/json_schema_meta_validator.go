@@ -0,0 +1,268 @@
+package schema
+
+import (
+	"fmt"
+	"inventory-system/common/pkg/utils"
+	"sort"
+	"strings"
+)
+
+var bsonTypeNames = map[string]bool{
+	"double": true, "string": true, "object": true, "array": true, "binData": true, "undefined": true,
+	"objectId": true, "bool": true, "date": true, "null": true, "regex": true, "dbPointer": true,
+	"javascript": true, "symbol": true, "javascriptWithScope": true, "int": true, "timestamp": true,
+	"long": true, "decimal": true, "minKey": true, "maxKey": true, "number": true,
+}
+
+var jsonTypeNames = map[string]bool{
+	"object": true, "array": true, "number": true, "boolean": true, "string": true, "null": true,
+}
+
+// ValidateMongoSchema : checks the schema against the $jsonSchema keywords mongo accepts, returns one problem per invalid keyword
+func ValidateMongoSchema(jsonSchema map[string]interface{}) []string {
+	var problems []string
+	validateSchemaNode(jsonSchema, "", &problems)
+	return problems
+}
+
+func validateSchemaNode(node interface{}, pointer string, problems *[]string) {
+	addProblem := func(keyword string, reason string) {
+		*problems = append(*problems, displayPointer(pointer+"/"+escapePointer(keyword))+": "+reason)
+	}
+
+	jsonSchema, ok := utils.AsMap(node)
+	if !ok {
+		*problems = append(*problems, displayPointer(pointer)+": schema must be an object")
+		return
+	}
+
+	keywords := make([]string, 0, len(jsonSchema))
+	for keyword := range jsonSchema {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+
+	if _, hasType := jsonSchema["type"]; hasType {
+		if _, hasBsonType := jsonSchema["bsonType"]; hasBsonType {
+			addProblem("type", "cannot be combined with bsonType")
+		}
+	}
+
+	for _, keyword := range keywords {
+		value := jsonSchema[keyword]
+		keywordPointer := pointer + "/" + escapePointer(keyword)
+		switch keyword {
+		case "bsonType":
+			if !isTypeList(value, bsonTypeNames) {
+				addProblem(keyword, "must be a bson type or an array of bson types")
+			}
+		case "type":
+			if !isTypeList(value, jsonTypeNames) {
+				addProblem(keyword, "must be a json type or an array of json types")
+			}
+		case "enum":
+			if values, ok := utils.AsSlice(value); !ok || len(values) == 0 {
+				addProblem(keyword, "must be a non empty array")
+			}
+		case "required":
+			if !isUniqueStringList(value) {
+				addProblem(keyword, "must be a non empty array of unique strings")
+			}
+		case "title", "description", "pattern":
+			if _, ok := value.(string); !ok {
+				addProblem(keyword, "must be a string")
+			}
+		case "minimum", "maximum":
+			if _, ok := toFloat(value); !ok {
+				addProblem(keyword, "must be a number")
+			}
+		case "multipleOf":
+			if number, ok := toFloat(value); !ok || number <= 0 {
+				addProblem(keyword, "must be a number greater than 0")
+			}
+		case "exclusiveMinimum", "exclusiveMaximum":
+			boundKeyword := "minimum"
+			if keyword == "exclusiveMaximum" {
+				boundKeyword = "maximum"
+			}
+			if _, ok := value.(bool); !ok {
+				addProblem(keyword, "must be a boolean")
+			} else if _, hasBound := jsonSchema[boundKeyword]; !hasBound {
+				addProblem(keyword, "requires "+boundKeyword)
+			}
+		case "minLength", "maxLength", "minItems", "maxItems", "minProperties", "maxProperties":
+			if number, ok := toFloat(value); !ok || number < 0 || number != float64(int64(number)) {
+				addProblem(keyword, "must be a non negative integer")
+			}
+		case "uniqueItems":
+			if _, ok := value.(bool); !ok {
+				addProblem(keyword, "must be a boolean")
+			}
+		case "properties", "patternProperties":
+			subSchemas, ok := utils.AsMap(value)
+			if !ok {
+				addProblem(keyword, "must be an object")
+				continue
+			}
+			for _, name := range sortedKeys(subSchemas) {
+				validateSchemaNode(subSchemas[name], keywordPointer+"/"+escapePointer(name), problems)
+			}
+		case "additionalProperties", "additionalItems":
+			if _, ok := value.(bool); !ok {
+				validateSchemaNode(value, keywordPointer, problems)
+			}
+		case "items":
+			if itemSchemas, ok := utils.AsSlice(value); ok {
+				for index, itemSchema := range itemSchemas {
+					validateSchemaNode(itemSchema, fmt.Sprintf("%s/%d", keywordPointer, index), problems)
+				}
+				continue
+			}
+			validateSchemaNode(value, keywordPointer, problems)
+		case "allOf", "anyOf", "oneOf":
+			subSchemas, ok := utils.AsSlice(value)
+			if !ok || len(subSchemas) == 0 {
+				addProblem(keyword, "must be a non empty array of schemas")
+				continue
+			}
+			for index, subSchema := range subSchemas {
+				validateSchemaNode(subSchema, fmt.Sprintf("%s/%d", keywordPointer, index), problems)
+			}
+		case "not":
+			validateSchemaNode(value, keywordPointer, problems)
+		case "dependencies":
+			dependencies, ok := utils.AsMap(value)
+			if !ok {
+				addProblem(keyword, "must be an object")
+				continue
+			}
+			for _, field := range sortedKeys(dependencies) {
+				if _, isList := utils.AsSlice(dependencies[field]); isList {
+					if !isUniqueStringList(dependencies[field]) {
+						*problems = append(*problems, keywordPointer+"/"+escapePointer(field)+": must be a non empty array of unique strings")
+					}
+					continue
+				}
+				validateSchemaNode(dependencies[field], keywordPointer+"/"+escapePointer(field), problems)
+			}
+		default:
+			addProblem(keyword, "unknown keyword")
+		}
+	}
+}
+
+// HasProperty : true when the dotted path is declared through nested properties, directly or inside allOf/anyOf/oneOf
+func HasProperty(jsonSchema map[string]interface{}, path string) bool {
+	return hasPropertyPath(jsonSchema, strings.Split(path, "."))
+}
+
+func hasPropertyPath(jsonSchema map[string]interface{}, segments []string) bool {
+	if len(segments) == 0 {
+		return true
+	}
+	if properties, ok := utils.AsMap(jsonSchema["properties"]); ok {
+		if propertySchema, exists := properties[segments[0]]; exists {
+			if len(segments) == 1 {
+				return true
+			}
+			if nestedSchema, ok := utils.AsMap(propertySchema); ok {
+				// arrays of documents are indexed through their item fields
+				if itemSchema, ok := utils.AsMap(nestedSchema["items"]); ok && hasPropertyPath(itemSchema, segments[1:]) {
+					return true
+				}
+				if hasPropertyPath(nestedSchema, segments[1:]) {
+					return true
+				}
+			}
+		}
+	}
+	for _, combinator := range []string{"allOf", "anyOf", "oneOf"} {
+		subSchemas, _ := utils.AsSlice(jsonSchema[combinator])
+		for _, subSchema := range subSchemas {
+			if subSchemaMap, ok := utils.AsMap(subSchema); ok && hasPropertyPath(subSchemaMap, segments) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PropertySchema : schema of the property at the dotted path, found the way HasProperty finds it. Array properties are
+// walked through their items schema
+func PropertySchema(jsonSchema map[string]interface{}, path string) (map[string]interface{}, bool) {
+	return propertySchemaPath(jsonSchema, strings.Split(path, "."))
+}
+
+func propertySchemaPath(jsonSchema map[string]interface{}, segments []string) (map[string]interface{}, bool) {
+	if len(segments) == 0 {
+		return jsonSchema, true
+	}
+	if properties, ok := utils.AsMap(jsonSchema["properties"]); ok {
+		if propertySchema, ok := utils.AsMap(properties[segments[0]]); ok {
+			if len(segments) == 1 {
+				return propertySchema, true
+			}
+			if itemSchema, ok := utils.AsMap(propertySchema["items"]); ok {
+				if nestedSchema, found := propertySchemaPath(itemSchema, segments[1:]); found {
+					return nestedSchema, true
+				}
+			}
+			if nestedSchema, found := propertySchemaPath(propertySchema, segments[1:]); found {
+				return nestedSchema, true
+			}
+		}
+	}
+	for _, combinator := range []string{"allOf", "anyOf", "oneOf"} {
+		subSchemas, _ := utils.AsSlice(jsonSchema[combinator])
+		for _, subSchema := range subSchemas {
+			if subSchemaMap, ok := utils.AsMap(subSchema); ok {
+				if nestedSchema, found := propertySchemaPath(subSchemaMap, segments); found {
+					return nestedSchema, true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+func isTypeList(value interface{}, allowed map[string]bool) bool {
+	typeNames, isList := utils.AsSlice(value)
+	if !isList {
+		typeNames = []interface{}{value}
+	}
+	if len(typeNames) == 0 {
+		return false
+	}
+	for _, typeName := range typeNames {
+		name, ok := typeName.(string)
+		if !ok || !allowed[name] {
+			return false
+		}
+	}
+	return true
+}
+
+func isUniqueStringList(value interface{}) bool {
+	values, ok := utils.AsSlice(value)
+	if !ok || len(values) == 0 {
+		return false
+	}
+	seen := make(map[string]bool, len(values))
+	for _, item := range values {
+		text, ok := item.(string)
+		if !ok || seen[text] {
+			return false
+		}
+		seen[text] = true
+	}
+	return true
+}
+
+func sortedKeys(document map[string]interface{}) []string {
+	keys := make([]string, 0, len(document))
+	for key := range document {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
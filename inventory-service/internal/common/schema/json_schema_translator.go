@@ -0,0 +1,452 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"inventory-system/common/pkg/utils"
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	DialectMongo   = "mongo"
+	DialectDraft07 = "draft-07"
+	Dialect202012  = "2020-12"
+
+	// a $ref chain deeper than this is treated as recursive and left unenforced
+	maxRefDepth = 16
+)
+
+// keywords mongo accepts in $jsonSchema as they are
+var passThroughKeywords = map[string]bool{
+	"bsonType": true, "title": true, "description": true, "minimum": true, "maximum": true,
+	"multipleOf": true, "minLength": true, "maxLength": true, "pattern": true, "minItems": true,
+	"maxItems": true, "uniqueItems": true, "minProperties": true, "maxProperties": true,
+}
+
+// annotation keywords which carry no validation and are dropped silently
+var annotationKeywords = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "$anchor": true, "$dynamicAnchor": true,
+	"$vocabulary": true, "definitions": true, "$defs": true, "default": true, "examples": true,
+	"readOnly": true, "writeOnly": true, "deprecated": true,
+}
+
+var jsonTypeToBsonType = map[string]string{
+	"object": "object", "array": "array", "string": "string", "boolean": "bool",
+	"null": "null", "number": "number", "integer": "number",
+}
+
+// DetectDialect : requested dialect when given, otherwise derived from the $schema keyword, mongo when absent
+func DetectDialect(source map[string]interface{}, requested string) (string, error) {
+	if requested != "" {
+		return requested, nil
+	}
+	schemaUri, exists := source["$schema"]
+	if !exists {
+		return DialectMongo, nil
+	}
+	uri, _ := schemaUri.(string)
+	switch {
+	case strings.Contains(uri, "draft-07"), strings.Contains(uri, "draft-06"), strings.Contains(uri, "draft-04"):
+		return DialectDraft07, nil
+	case strings.Contains(uri, "2020-12"), strings.Contains(uri, "2019-09"):
+		return Dialect202012, nil
+	}
+	return "", errors.New("unsupported $schema " + uri)
+}
+
+type translator struct {
+	root       map[string]interface{}
+	dialect    string
+	unenforced []string
+	refDepth   int
+}
+
+// Translate : converts a draft-07 or 2020-12 JSON Schema into mongo's $jsonSchema dialect.
+// Keywords mongo cannot enforce are dropped and reported as "<schema pointer>: <keyword>".
+func Translate(source map[string]interface{}, dialect string) (map[string]interface{}, []string, error) {
+	if dialect == DialectMongo {
+		return source, nil, nil
+	}
+	t := &translator{root: source, dialect: dialect}
+	translated, err := t.translateSchema(source, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	return translated, t.unenforced, nil
+}
+
+func (t *translator) report(pointer string, keyword string) {
+	if pointer == "" {
+		pointer = "/"
+	}
+	t.unenforced = append(t.unenforced, pointer+": "+keyword)
+}
+
+func (t *translator) translateSchema(node interface{}, pointer string) (map[string]interface{}, error) {
+	if boolean, ok := node.(bool); ok {
+		if boolean {
+			return map[string]interface{}{}, nil
+		}
+		return map[string]interface{}{"not": map[string]interface{}{}}, nil
+	}
+	source, ok := utils.AsMap(node)
+	if !ok {
+		return nil, fmt.Errorf("%s: schema must be an object or boolean", displayPointer(pointer))
+	}
+
+	if ref, exists := source["$ref"]; exists {
+		return t.translateRef(source, ref, pointer)
+	}
+
+	result := map[string]interface{}{}
+	keywords := make([]string, 0, len(source))
+	for keyword := range source {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+
+	for _, keyword := range keywords {
+		value := source[keyword]
+		keywordPointer := pointer + "/" + escapePointer(keyword)
+		switch {
+		case passThroughKeywords[keyword]:
+			result[keyword] = value
+		case annotationKeywords[keyword]:
+			continue
+		case keyword == "type":
+			if err := t.translateType(result, value, source, pointer); err != nil {
+				return nil, err
+			}
+		case keyword == "const":
+			appendConstraint(result, "enum", []interface{}{value})
+		case keyword == "enum":
+			appendConstraint(result, keyword, value)
+		case keyword == "exclusiveMinimum", keyword == "exclusiveMaximum":
+			t.translateExclusiveBound(result, keyword, value, source)
+		case keyword == "required":
+			if required, ok := utils.AsSlice(value); ok && len(required) > 0 {
+				result[keyword] = required
+			}
+		case keyword == "properties", keyword == "patternProperties":
+			properties, err := t.translateSchemaMap(value, keywordPointer)
+			if err != nil {
+				return nil, err
+			}
+			result[keyword] = properties
+		case keyword == "additionalProperties", keyword == "additionalItems":
+			if boolean, ok := value.(bool); ok {
+				result[keyword] = boolean
+				continue
+			}
+			subSchema, err := t.translateSchema(value, keywordPointer)
+			if err != nil {
+				return nil, err
+			}
+			result[keyword] = subSchema
+		case keyword == "items":
+			if err := t.translateItems(result, source, pointer); err != nil {
+				return nil, err
+			}
+		case keyword == "prefixItems":
+			if _, hasItems := source["items"]; !hasItems {
+				if err := t.translateItems(result, source, pointer); err != nil {
+					return nil, err
+				}
+			}
+		case keyword == "allOf", keyword == "anyOf", keyword == "oneOf":
+			subSchemas, err := t.translateSchemaList(value, keywordPointer)
+			if err != nil {
+				return nil, err
+			}
+			for _, subSchema := range subSchemas {
+				appendCombinator(result, keyword, subSchema)
+			}
+		case keyword == "not":
+			subSchema, err := t.translateSchema(value, keywordPointer)
+			if err != nil {
+				return nil, err
+			}
+			result[keyword] = subSchema
+		case keyword == "dependencies", keyword == "dependentRequired", keyword == "dependentSchemas":
+			if err := t.translateDependencies(result, value, keywordPointer); err != nil {
+				return nil, err
+			}
+		case keyword == "if":
+			if err := t.translateConditional(result, source, pointer); err != nil {
+				return nil, err
+			}
+		case keyword == "then", keyword == "else":
+			if _, hasIf := source["if"]; !hasIf {
+				continue
+			}
+		default:
+			// format, contains, propertyNames, unevaluated*, content* and anything unknown to mongo
+			t.report(pointer, keyword)
+		}
+	}
+	return result, nil
+}
+
+func (t *translator) translateRef(source map[string]interface{}, ref interface{}, pointer string) (map[string]interface{}, error) {
+	refString, _ := ref.(string)
+	target, resolved := resolveLocalRef(t.root, refString)
+	if !resolved || t.refDepth >= maxRefDepth {
+		t.report(pointer, "$ref")
+		return map[string]interface{}{}, nil
+	}
+
+	t.refDepth++
+	translatedRef, err := t.translateSchema(target, pointer)
+	t.refDepth--
+	if err != nil {
+		return nil, err
+	}
+
+	// draft-07 ignores keywords next to $ref, later drafts apply both
+	if t.dialect == DialectDraft07 || len(source) == 1 {
+		return translatedRef, nil
+	}
+	siblings := make(map[string]interface{}, len(source)-1)
+	for keyword, value := range source {
+		if keyword != "$ref" {
+			siblings[keyword] = value
+		}
+	}
+	result, err := t.translateSchema(siblings, pointer)
+	if err != nil {
+		return nil, err
+	}
+	appendCombinator(result, "allOf", translatedRef)
+	return result, nil
+}
+
+func (t *translator) translateType(result map[string]interface{}, value interface{}, source map[string]interface{}, pointer string) error {
+	typeNames, isList := utils.AsSlice(value)
+	if !isList {
+		typeNames = []interface{}{value}
+	}
+
+	var bsonTypes []interface{}
+	seen := map[string]bool{}
+	hasInteger, hasNumber := false, false
+	for _, typeName := range typeNames {
+		name, _ := typeName.(string)
+		bsonType, known := jsonTypeToBsonType[name]
+		if !known {
+			return fmt.Errorf("%s: unknown type %v", displayPointer(pointer+"/type"), typeName)
+		}
+		hasInteger = hasInteger || name == "integer"
+		hasNumber = hasNumber || name == "number"
+		if !seen[bsonType] {
+			seen[bsonType] = true
+			bsonTypes = append(bsonTypes, bsonType)
+		}
+	}
+	if len(bsonTypes) == 1 {
+		result["bsonType"] = bsonTypes[0]
+	} else {
+		result["bsonType"] = bsonTypes
+	}
+
+	// documents bound from json store every number as a double, so integer is enforced as a whole number
+	if hasInteger && !hasNumber {
+		multipleOf, hasMultipleOf := toFloat(source["multipleOf"])
+		if !hasMultipleOf || multipleOf != math.Trunc(multipleOf) {
+			appendCombinator(result, "allOf", map[string]interface{}{"multipleOf": 1})
+		}
+	}
+	return nil
+}
+
+func (t *translator) translateExclusiveBound(result map[string]interface{}, keyword string, value interface{}, source map[string]interface{}) {
+	// draft-04 style boolean flags are already what mongo expects
+	if boolean, ok := value.(bool); ok {
+		result[keyword] = boolean
+		return
+	}
+	boundKeyword := "minimum"
+	if keyword == "exclusiveMaximum" {
+		boundKeyword = "maximum"
+	}
+	if _, hasBound := source[boundKeyword]; hasBound {
+		appendCombinator(result, "allOf", map[string]interface{}{boundKeyword: value, keyword: true})
+		return
+	}
+	result[boundKeyword] = value
+	result[keyword] = true
+}
+
+func (t *translator) translateItems(result map[string]interface{}, source map[string]interface{}, pointer string) error {
+	items, hasItems := source["items"]
+	prefixItems, hasPrefixItems := source["prefixItems"]
+
+	// 2020-12 moved positional schemas to prefixItems and the schema for the rest to items
+	if hasPrefixItems {
+		positional, err := t.translateSchemaList(prefixItems, pointer+"/prefixItems")
+		if err != nil {
+			return err
+		}
+		result["items"] = positional
+		if !hasItems {
+			return nil
+		}
+		if boolean, ok := items.(bool); ok {
+			result["additionalItems"] = boolean
+			return nil
+		}
+		additionalItems, err := t.translateSchema(items, pointer+"/items")
+		if err != nil {
+			return err
+		}
+		result["additionalItems"] = additionalItems
+		return nil
+	}
+
+	if _, isList := utils.AsSlice(items); isList {
+		positional, err := t.translateSchemaList(items, pointer+"/items")
+		if err != nil {
+			return err
+		}
+		result["items"] = positional
+		return nil
+	}
+	if boolean, ok := items.(bool); ok {
+		if !boolean {
+			result["maxItems"] = 0
+		}
+		return nil
+	}
+	itemSchema, err := t.translateSchema(items, pointer+"/items")
+	if err != nil {
+		return err
+	}
+	result["items"] = itemSchema
+	return nil
+}
+
+func (t *translator) translateDependencies(result map[string]interface{}, value interface{}, pointer string) error {
+	dependencies, ok := utils.AsMap(value)
+	if !ok {
+		return fmt.Errorf("%s: must be an object", displayPointer(pointer))
+	}
+	translated, _ := utils.AsMap(result["dependencies"])
+	if translated == nil {
+		translated = map[string]interface{}{}
+	}
+	for field, dependency := range dependencies {
+		if dependentFields, isList := utils.AsSlice(dependency); isList {
+			translated[field] = dependentFields
+			continue
+		}
+		dependentSchema, err := t.translateSchema(dependency, pointer+"/"+escapePointer(field))
+		if err != nil {
+			return err
+		}
+		translated[field] = dependentSchema
+	}
+	result["dependencies"] = translated
+	return nil
+}
+
+// translateConditional : if/then/else has no mongo keyword, it is rewritten as anyOf [ if and then, not if and else ]
+func (t *translator) translateConditional(result map[string]interface{}, source map[string]interface{}, pointer string) error {
+	ifSchema, err := t.translateSchema(source["if"], pointer+"/if")
+	if err != nil {
+		return err
+	}
+	thenSchema, elseSchema := map[string]interface{}{}, map[string]interface{}{}
+	if thenNode, exists := source["then"]; exists {
+		if thenSchema, err = t.translateSchema(thenNode, pointer+"/then"); err != nil {
+			return err
+		}
+	}
+	if elseNode, exists := source["else"]; exists {
+		if elseSchema, err = t.translateSchema(elseNode, pointer+"/else"); err != nil {
+			return err
+		}
+	}
+	conditional := map[string]interface{}{
+		"anyOf": []interface{}{
+			map[string]interface{}{"allOf": []interface{}{ifSchema, thenSchema}},
+			map[string]interface{}{"allOf": []interface{}{map[string]interface{}{"not": ifSchema}, elseSchema}},
+		},
+	}
+	appendCombinator(result, "allOf", conditional)
+	return nil
+}
+
+func (t *translator) translateSchemaMap(value interface{}, pointer string) (map[string]interface{}, error) {
+	schemas, ok := utils.AsMap(value)
+	if !ok {
+		return nil, fmt.Errorf("%s: must be an object", displayPointer(pointer))
+	}
+	translated := make(map[string]interface{}, len(schemas))
+	for name, subSchema := range schemas {
+		translatedSchema, err := t.translateSchema(subSchema, pointer+"/"+escapePointer(name))
+		if err != nil {
+			return nil, err
+		}
+		translated[name] = translatedSchema
+	}
+	return translated, nil
+}
+
+func (t *translator) translateSchemaList(value interface{}, pointer string) ([]interface{}, error) {
+	schemas, ok := utils.AsSlice(value)
+	if !ok {
+		return nil, fmt.Errorf("%s: must be an array", displayPointer(pointer))
+	}
+	translated := make([]interface{}, 0, len(schemas))
+	for index, subSchema := range schemas {
+		translatedSchema, err := t.translateSchema(subSchema, fmt.Sprintf("%s/%d", pointer, index))
+		if err != nil {
+			return nil, err
+		}
+		translated = append(translated, translatedSchema)
+	}
+	return translated, nil
+}
+
+func resolveLocalRef(root map[string]interface{}, ref string) (interface{}, bool) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, false
+	}
+	var current interface{} = root
+	for _, segment := range strings.Split(strings.TrimPrefix(ref, "#"), "/") {
+		if segment == "" {
+			continue
+		}
+		segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+		document, ok := utils.AsMap(current)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = document[segment]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// appendConstraint : sets the keyword, moving it into allOf when the schema already constrains it
+func appendConstraint(result map[string]interface{}, keyword string, value interface{}) {
+	if _, exists := result[keyword]; exists {
+		appendCombinator(result, "allOf", map[string]interface{}{keyword: value})
+		return
+	}
+	result[keyword] = value
+}
+
+func appendCombinator(result map[string]interface{}, keyword string, subSchema interface{}) {
+	existing, _ := utils.AsSlice(result[keyword])
+	result[keyword] = append(existing, subSchema)
+}
+
+func displayPointer(pointer string) string {
+	if pointer == "" {
+		return "/"
+	}
+	return pointer
+}
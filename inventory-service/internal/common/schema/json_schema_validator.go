@@ -0,0 +1,467 @@
+package schema
+
+import (
+	"fmt"
+	"inventory-system/common/pkg/utils"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SchemaViolation : single failing JSON pointer in a document with the violated keyword
+type SchemaViolation struct {
+	Pointer string `json:"pointer"`
+	Keyword string `json:"keyword"`
+	Param   string `json:"param,omitempty"`
+}
+
+func (v SchemaViolation) String() string {
+	pointer := v.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+	if v.Param == "" {
+		return pointer + ": " + v.Keyword
+	}
+	return pointer + ": " + v.Keyword + " " + v.Param
+}
+
+// ViolationsMessage : joins the violations the same way request validation errors are joined
+func ViolationsMessage(violations []SchemaViolation) string {
+	messages := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		messages = append(messages, violation.String())
+	}
+	return strings.Join(messages, ", ")
+}
+
+// Validate : validates the document against a mongo $jsonSchema and returns every violation found
+func Validate(jsonSchema map[string]interface{}, document interface{}) []SchemaViolation {
+	var violations []SchemaViolation
+	validateValue(jsonSchema, document, "", &violations)
+	return violations
+}
+
+func validateValue(jsonSchema map[string]interface{}, value interface{}, pointer string, violations *[]SchemaViolation) {
+	addViolation := func(keyword string, param string) {
+		*violations = append(*violations, SchemaViolation{Pointer: pointer, Keyword: keyword, Param: param})
+	}
+
+	if bsonType, ok := jsonSchema["bsonType"]; ok {
+		if !matchesAnyType(bsonType, value, true) {
+			addViolation("bsonType", typeParam(bsonType))
+			return
+		}
+	}
+	if jsonType, ok := jsonSchema["type"]; ok {
+		if !matchesAnyType(jsonType, value, false) {
+			addViolation("type", typeParam(jsonType))
+			return
+		}
+	}
+
+	if enum, ok := utils.AsSlice(jsonSchema["enum"]); ok {
+		found := false
+		for _, allowed := range enum {
+			if valuesEqual(allowed, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			addViolation("enum", "")
+		}
+	}
+
+	if number, ok := toFloat(value); ok {
+		validateNumber(jsonSchema, number, addViolation)
+	}
+	if text, ok := value.(string); ok {
+		validateString(jsonSchema, text, addViolation)
+	}
+	if array, ok := utils.AsSlice(value); ok {
+		validateArray(jsonSchema, array, pointer, violations)
+	}
+	if document, ok := utils.AsMap(value); ok {
+		validateObject(jsonSchema, document, pointer, violations)
+	}
+
+	validateCombinators(jsonSchema, value, pointer, violations)
+}
+
+func validateNumber(jsonSchema map[string]interface{}, number float64, addViolation func(string, string)) {
+	if minimum, ok := toFloat(jsonSchema["minimum"]); ok {
+		exclusive, _ := jsonSchema["exclusiveMinimum"].(bool)
+		if number < minimum || (exclusive && number == minimum) {
+			if exclusive {
+				addViolation("exclusiveMinimum", formatNumber(minimum))
+			} else {
+				addViolation("minimum", formatNumber(minimum))
+			}
+		}
+	}
+	if maximum, ok := toFloat(jsonSchema["maximum"]); ok {
+		exclusive, _ := jsonSchema["exclusiveMaximum"].(bool)
+		if number > maximum || (exclusive && number == maximum) {
+			if exclusive {
+				addViolation("exclusiveMaximum", formatNumber(maximum))
+			} else {
+				addViolation("maximum", formatNumber(maximum))
+			}
+		}
+	}
+	if multipleOf, ok := toFloat(jsonSchema["multipleOf"]); ok && multipleOf > 0 {
+		quotient := number / multipleOf
+		if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			addViolation("multipleOf", formatNumber(multipleOf))
+		}
+	}
+}
+
+func validateString(jsonSchema map[string]interface{}, text string, addViolation func(string, string)) {
+	length := float64(utf8.RuneCountInString(text))
+	if minLength, ok := toFloat(jsonSchema["minLength"]); ok && length < minLength {
+		addViolation("minLength", formatNumber(minLength))
+	}
+	if maxLength, ok := toFloat(jsonSchema["maxLength"]); ok && length > maxLength {
+		addViolation("maxLength", formatNumber(maxLength))
+	}
+	if pattern, ok := jsonSchema["pattern"].(string); ok {
+		expression, err := regexp.Compile(pattern)
+		if err == nil && !expression.MatchString(text) {
+			addViolation("pattern", pattern)
+		}
+	}
+}
+
+func validateArray(jsonSchema map[string]interface{}, array []interface{}, pointer string, violations *[]SchemaViolation) {
+	addViolation := func(keyword string, param string) {
+		*violations = append(*violations, SchemaViolation{Pointer: pointer, Keyword: keyword, Param: param})
+	}
+
+	length := float64(len(array))
+	if minItems, ok := toFloat(jsonSchema["minItems"]); ok && length < minItems {
+		addViolation("minItems", formatNumber(minItems))
+	}
+	if maxItems, ok := toFloat(jsonSchema["maxItems"]); ok && length > maxItems {
+		addViolation("maxItems", formatNumber(maxItems))
+	}
+	if uniqueItems, _ := jsonSchema["uniqueItems"].(bool); uniqueItems {
+		for i := range array {
+			for j := i + 1; j < len(array); j++ {
+				if valuesEqual(array[i], array[j]) {
+					addViolation("uniqueItems", "")
+					i = len(array)
+					break
+				}
+			}
+		}
+	}
+
+	if itemSchema, ok := utils.AsMap(jsonSchema["items"]); ok {
+		for index, item := range array {
+			validateValue(itemSchema, item, pointer+"/"+strconv.Itoa(index), violations)
+		}
+		return
+	}
+	if itemSchemas, ok := utils.AsSlice(jsonSchema["items"]); ok {
+		for index, item := range array {
+			itemPointer := pointer + "/" + strconv.Itoa(index)
+			if index < len(itemSchemas) {
+				if itemSchema, ok := utils.AsMap(itemSchemas[index]); ok {
+					validateValue(itemSchema, item, itemPointer, violations)
+				}
+				continue
+			}
+			if additionalItems, ok := jsonSchema["additionalItems"].(bool); ok && !additionalItems {
+				*violations = append(*violations, SchemaViolation{Pointer: itemPointer, Keyword: "additionalItems", Param: "false"})
+			} else if additionalSchema, ok := utils.AsMap(jsonSchema["additionalItems"]); ok {
+				validateValue(additionalSchema, item, itemPointer, violations)
+			}
+		}
+	}
+}
+
+func validateObject(jsonSchema map[string]interface{}, document map[string]interface{}, pointer string, violations *[]SchemaViolation) {
+	addViolation := func(keyword string, param string) {
+		*violations = append(*violations, SchemaViolation{Pointer: pointer, Keyword: keyword, Param: param})
+	}
+
+	if required, ok := utils.AsSlice(jsonSchema["required"]); ok {
+		for _, field := range required {
+			name, _ := field.(string)
+			if _, exists := document[name]; !exists {
+				*violations = append(*violations, SchemaViolation{Pointer: pointer + "/" + escapePointer(name), Keyword: "required"})
+			}
+		}
+	}
+
+	count := float64(len(document))
+	if minProperties, ok := toFloat(jsonSchema["minProperties"]); ok && count < minProperties {
+		addViolation("minProperties", formatNumber(minProperties))
+	}
+	if maxProperties, ok := toFloat(jsonSchema["maxProperties"]); ok && count > maxProperties {
+		addViolation("maxProperties", formatNumber(maxProperties))
+	}
+
+	properties, _ := utils.AsMap(jsonSchema["properties"])
+	patternProperties, _ := utils.AsMap(jsonSchema["patternProperties"])
+
+	// sorted so the same document always reports violations in the same order
+	fields := make([]string, 0, len(document))
+	for field := range document {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		fieldPointer := pointer + "/" + escapePointer(field)
+		matched := false
+		if propertySchema, ok := utils.AsMap(properties[field]); ok {
+			matched = true
+			validateValue(propertySchema, document[field], fieldPointer, violations)
+		} else if _, ok := properties[field]; ok {
+			matched = true
+		}
+		for pattern, patternSchema := range patternProperties {
+			expression, err := regexp.Compile(pattern)
+			if err != nil || !expression.MatchString(field) {
+				continue
+			}
+			matched = true
+			if propertySchema, ok := utils.AsMap(patternSchema); ok {
+				validateValue(propertySchema, document[field], fieldPointer, violations)
+			}
+		}
+		if matched {
+			continue
+		}
+		if additionalProperties, ok := jsonSchema["additionalProperties"].(bool); ok && !additionalProperties {
+			*violations = append(*violations, SchemaViolation{Pointer: fieldPointer, Keyword: "additionalProperties", Param: "false"})
+		} else if additionalSchema, ok := utils.AsMap(jsonSchema["additionalProperties"]); ok {
+			validateValue(additionalSchema, document[field], fieldPointer, violations)
+		}
+	}
+
+	if dependencies, ok := utils.AsMap(jsonSchema["dependencies"]); ok {
+		for field, dependency := range dependencies {
+			if _, exists := document[field]; !exists {
+				continue
+			}
+			if dependentFields, ok := utils.AsSlice(dependency); ok {
+				for _, dependentField := range dependentFields {
+					name, _ := dependentField.(string)
+					if _, exists := document[name]; !exists {
+						*violations = append(*violations, SchemaViolation{Pointer: pointer + "/" + escapePointer(name), Keyword: "dependencies", Param: field})
+					}
+				}
+			} else if dependentSchema, ok := utils.AsMap(dependency); ok {
+				validateValue(dependentSchema, document, pointer, violations)
+			}
+		}
+	}
+}
+
+func validateCombinators(jsonSchema map[string]interface{}, value interface{}, pointer string, violations *[]SchemaViolation) {
+	if allOf, ok := utils.AsSlice(jsonSchema["allOf"]); ok {
+		for _, subSchema := range allOf {
+			if subSchemaMap, ok := utils.AsMap(subSchema); ok {
+				validateValue(subSchemaMap, value, pointer, violations)
+			}
+		}
+	}
+	if anyOf, ok := utils.AsSlice(jsonSchema["anyOf"]); ok && countMatching(anyOf, value) == 0 {
+		*violations = append(*violations, SchemaViolation{Pointer: pointer, Keyword: "anyOf"})
+	}
+	if oneOf, ok := utils.AsSlice(jsonSchema["oneOf"]); ok && countMatching(oneOf, value) != 1 {
+		*violations = append(*violations, SchemaViolation{Pointer: pointer, Keyword: "oneOf"})
+	}
+	if not, ok := utils.AsMap(jsonSchema["not"]); ok && len(Validate(not, value)) == 0 {
+		*violations = append(*violations, SchemaViolation{Pointer: pointer, Keyword: "not"})
+	}
+}
+
+func countMatching(subSchemas []interface{}, value interface{}) int {
+	matching := 0
+	for _, subSchema := range subSchemas {
+		if subSchemaMap, ok := utils.AsMap(subSchema); ok && len(Validate(subSchemaMap, value)) == 0 {
+			matching++
+		}
+	}
+	return matching
+}
+
+func matchesAnyType(typeValue interface{}, value interface{}, isBsonType bool) bool {
+	typeNames, ok := utils.AsSlice(typeValue)
+	if !ok {
+		typeNames = []interface{}{typeValue}
+	}
+	for _, typeName := range typeNames {
+		name, _ := typeName.(string)
+		if isBsonType && matchesBsonType(name, value) {
+			return true
+		}
+		if !isBsonType && matchesJsonType(name, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesBsonType(typeName string, value interface{}) bool {
+	switch typeName {
+	case "double":
+		switch value.(type) {
+		case float64, float32:
+			return true
+		}
+	case "int":
+		// go ints are written as int32 when they fit, json numbers must go through CoerceIntegers first
+		switch number := value.(type) {
+		case int32:
+			return true
+		case int:
+			return number >= math.MinInt32 && number <= math.MaxInt32
+		}
+	case "long":
+		switch number := value.(type) {
+		case int64:
+			return true
+		case int:
+			return number < math.MinInt32 || number > math.MaxInt32
+		}
+	case "decimal":
+		_, ok := value.(primitive.Decimal128)
+		return ok
+	case "number":
+		_, isDecimal := value.(primitive.Decimal128)
+		_, isNumber := toFloat(value)
+		return isNumber || isDecimal
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "bool":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	case "date":
+		switch value.(type) {
+		case time.Time, primitive.DateTime:
+			return true
+		}
+	case "objectId":
+		_, ok := value.(primitive.ObjectID)
+		return ok
+	case "binData":
+		switch value.(type) {
+		case primitive.Binary, []byte:
+			return true
+		}
+	case "regex":
+		_, ok := value.(primitive.Regex)
+		return ok
+	case "timestamp":
+		_, ok := value.(primitive.Timestamp)
+		return ok
+	case "array":
+		_, ok := utils.AsSlice(value)
+		return ok
+	case "object":
+		_, ok := utils.AsMap(value)
+		return ok
+	}
+	return false
+}
+
+func matchesJsonType(typeName string, value interface{}) bool {
+	switch typeName {
+	case "integer":
+		return matchesBsonType("long", value)
+	case "boolean":
+		return matchesBsonType("bool", value)
+	case "string", "null", "array", "object", "number":
+		return matchesBsonType(typeName, value)
+	}
+	return false
+}
+
+func typeParam(typeValue interface{}) string {
+	if typeNames, ok := utils.AsSlice(typeValue); ok {
+		names := make([]string, 0, len(typeNames))
+		for _, typeName := range typeNames {
+			names = append(names, fmt.Sprint(typeName))
+		}
+		return strings.Join(names, "|")
+	}
+	return fmt.Sprint(typeValue)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case float64:
+		return number, true
+	case float32:
+		return float64(number), true
+	case int:
+		return float64(number), true
+	case int32:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	}
+	return 0, false
+}
+
+func valuesEqual(first interface{}, second interface{}) bool {
+	firstNumber, isFirstNumber := toFloat(first)
+	secondNumber, isSecondNumber := toFloat(second)
+	if isFirstNumber && isSecondNumber {
+		return firstNumber == secondNumber
+	}
+	if firstMap, ok := utils.AsMap(first); ok {
+		secondMap, ok := utils.AsMap(second)
+		if !ok || len(firstMap) != len(secondMap) {
+			return false
+		}
+		for key, value := range firstMap {
+			if secondValue, exists := secondMap[key]; !exists || !valuesEqual(value, secondValue) {
+				return false
+			}
+		}
+		return true
+	}
+	if firstSlice, ok := utils.AsSlice(first); ok {
+		secondSlice, ok := utils.AsSlice(second)
+		if !ok || len(firstSlice) != len(secondSlice) {
+			return false
+		}
+		for index := range firstSlice {
+			if !valuesEqual(firstSlice[index], secondSlice[index]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(first, second)
+}
+
+func formatNumber(number float64) string {
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+func escapePointer(field string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(field, "~", "~0"), "/", "~1")
+}
+
+// ExtractJsonSchema : the configuration json_schema is the collection validator, the schema itself sits under $jsonSchema
+func ExtractJsonSchema(validator map[string]interface{}) (map[string]interface{}, bool) {
+	return utils.AsMap(validator["$jsonSchema"])
+}
@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"inventory-system/inventory-service/internal/common/schema"
+	"testing"
+)
+
+func TestCoerceIntegers(t *testing.T) {
+	lessonSchema := map[string]interface{}{
+		"bsonType": "object",
+		"properties": map[string]interface{}{
+			"duration": map[string]interface{}{"bsonType": "long"},
+			"rating":   map[string]interface{}{"bsonType": []interface{}{"int", "double"}},
+			"parts":    map[string]interface{}{"bsonType": "array", "items": map[string]interface{}{"bsonType": "int"}},
+			"syllabus": courseSchema["properties"].(map[string]interface{})["syllabus"],
+		},
+	}
+
+	t.Run("TestCoerceIntegers_ShouldConvertWholeNumbersOfIntAndLongProperties", func(t *testing.T) {
+		document := map[string]interface{}{
+			"duration": float64(90),
+			"rating":   float64(4),
+			"parts":    []interface{}{float64(1), int64(2)},
+			"syllabus": map[string]interface{}{"weeks": float64(12)},
+			"title":    float64(3),
+		}
+
+		schema.CoerceIntegers(lessonSchema, document)
+
+		assert.Equal(t, map[string]interface{}{
+			"duration": int64(90),
+			"rating":   float64(4),
+			"parts":    []interface{}{int32(1), int32(2)},
+			"syllabus": map[string]interface{}{"weeks": int32(12)},
+			"title":    float64(3),
+		}, document)
+		assert.Empty(t, schema.Validate(lessonSchema, document))
+	})
+	t.Run("TestCoerceIntegers_ShouldCoerceDottedPaths_WhenDocumentIsSetFields", func(t *testing.T) {
+		fields := map[string]interface{}{"syllabus.weeks": float64(8), "parts": []interface{}{float64(1.5)}}
+
+		schema.CoerceIntegers(lessonSchema, fields)
+
+		assert.Equal(t, map[string]interface{}{"syllabus.weeks": int32(8), "parts": []interface{}{float64(1.5)}}, fields)
+	})
+	t.Run("TestCoerceIntegers_ShouldKeepNumber_WhenItDoesNotFitInt", func(t *testing.T) {
+		document := map[string]interface{}{"parts": []interface{}{float64(1 << 40)}}
+
+		schema.CoerceIntegers(lessonSchema, document)
+
+		assert.Equal(t, []interface{}{float64(1 << 40)}, document["parts"])
+		assert.Equal(t, "/parts/0: bsonType int", schema.ViolationsMessage(schema.Validate(lessonSchema, document)))
+	})
+}
@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"inventory-system/inventory-service/internal/common/schema"
+	"testing"
+)
+
+func TestDetectDialect(t *testing.T) {
+	t.Run("TestDetectDialect_ShouldReturnMongo_WhenSchemaKeywordAbsent", func(t *testing.T) {
+		dialect, err := schema.DetectDialect(map[string]interface{}{"bsonType": "object"}, "")
+		assert.Nil(t, err)
+		assert.Equal(t, schema.DialectMongo, dialect)
+	})
+	t.Run("TestDetectDialect_ShouldReturn202012_WhenSchemaKeywordIs202012", func(t *testing.T) {
+		dialect, err := schema.DetectDialect(map[string]interface{}{"$schema": "https://json-schema.org/draft/2020-12/schema"}, "")
+		assert.Nil(t, err)
+		assert.Equal(t, schema.Dialect202012, dialect)
+	})
+	t.Run("TestDetectDialect_ShouldReturnError_WhenSchemaKeywordUnsupported", func(t *testing.T) {
+		_, err := schema.DetectDialect(map[string]interface{}{"$schema": "http://json-schema.org/draft-03/schema#"}, "")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestTranslate(t *testing.T) {
+	t.Run("TestTranslate_ShouldTranslateDraft07Keywords_WhenSchemaUsesRefsAndConditionals", func(t *testing.T) {
+		source := map[string]interface{}{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"type":    "object",
+			"definitions": map[string]interface{}{
+				"price": map[string]interface{}{"type": "integer", "minimum": float64(0)},
+			},
+			"properties": map[string]interface{}{
+				"price":  map[string]interface{}{"$ref": "#/definitions/price"},
+				"status": map[string]interface{}{"const": "active"},
+				"tags":   map[string]interface{}{"type": "array", "contains": map[string]interface{}{"type": "string"}},
+			},
+			"if":   map[string]interface{}{"required": []interface{}{"price"}},
+			"then": map[string]interface{}{"required": []interface{}{"status"}},
+		}
+
+		translated, unenforced, err := schema.Translate(source, schema.DialectDraft07)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"/properties/tags: contains"}, unenforced)
+		assert.Empty(t, schema.ValidateMongoSchema(translated))
+		assert.Equal(t, map[string]interface{}{
+			"bsonType": "object",
+			"properties": map[string]interface{}{
+				"price":  map[string]interface{}{"bsonType": "number", "minimum": float64(0), "allOf": []interface{}{map[string]interface{}{"multipleOf": 1}}},
+				"status": map[string]interface{}{"enum": []interface{}{"active"}},
+				"tags":   map[string]interface{}{"bsonType": "array"},
+			},
+			"allOf": []interface{}{map[string]interface{}{
+				"anyOf": []interface{}{
+					map[string]interface{}{"allOf": []interface{}{map[string]interface{}{"required": []interface{}{"price"}}, map[string]interface{}{"required": []interface{}{"status"}}}},
+					map[string]interface{}{"allOf": []interface{}{map[string]interface{}{"not": map[string]interface{}{"required": []interface{}{"price"}}}, map[string]interface{}{}}},
+				},
+			}},
+		}, translated)
+	})
+	t.Run("TestTranslate_ShouldMapPrefixItems_When202012TupleSchemaGiven", func(t *testing.T) {
+		source := map[string]interface{}{
+			"type":        "array",
+			"prefixItems": []interface{}{map[string]interface{}{"type": "string"}},
+			"items":       false,
+		}
+
+		translated, unenforced, err := schema.Translate(source, schema.Dialect202012)
+
+		assert.Nil(t, err)
+		assert.Empty(t, unenforced)
+		assert.Equal(t, map[string]interface{}{
+			"bsonType":        "array",
+			"items":           []interface{}{map[string]interface{}{"bsonType": "string"}},
+			"additionalItems": false,
+		}, translated)
+	})
+	t.Run("TestTranslate_ShouldReturnError_WhenTypeUnknown", func(t *testing.T) {
+		_, _, err := schema.Translate(map[string]interface{}{"type": "date"}, schema.DialectDraft07)
+		assert.NotNil(t, err)
+	})
+}
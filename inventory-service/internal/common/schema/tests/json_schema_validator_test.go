@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"github.com/stretchr/testify/assert"
+	"inventory-system/inventory-service/internal/common/schema"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var courseSchema = map[string]interface{}{
+	"bsonType": "object",
+	"required": []interface{}{"course_name", "price"},
+	"properties": map[string]interface{}{
+		"course_name": map[string]interface{}{"bsonType": "string", "minLength": float64(3)},
+		"price":       map[string]interface{}{"bsonType": "number", "minimum": float64(0)},
+		"level":       map[string]interface{}{"enum": []interface{}{"beginner", "advanced"}},
+		"tags": map[string]interface{}{
+			"bsonType": "array",
+			"items":    map[string]interface{}{"bsonType": "string"},
+		},
+		"syllabus": map[string]interface{}{
+			"bsonType":             "object",
+			"additionalProperties": false,
+			"properties": map[string]interface{}{
+				"weeks": map[string]interface{}{"bsonType": "int", "maximum": float64(52)},
+			},
+		},
+	},
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("TestValidate_ShouldReturnNoViolations_WhenDocumentMatchesSchema", func(t *testing.T) {
+		document := map[string]interface{}{
+			"course_name": "DSA",
+			"price":       float64(100),
+			"level":       "beginner",
+			"tags":        []interface{}{"algorithms"},
+			"syllabus":    map[string]interface{}{"weeks": int32(12)},
+		}
+
+		violations := schema.Validate(courseSchema, document)
+
+		assert.Empty(t, violations)
+	})
+	t.Run("TestValidate_ShouldReturnEveryFailingPointer_WhenDocumentBreaksSchema", func(t *testing.T) {
+		document := map[string]interface{}{
+			"course_name": "DS",
+			"price":       float64(-1),
+			"level":       "expert",
+			"tags":        []interface{}{"algorithms", float64(1)},
+			"syllabus":    map[string]interface{}{"weeks": float64(1.5), "hours": float64(3)},
+		}
+
+		violations := schema.Validate(courseSchema, document)
+
+		assert.Equal(t, "/course_name: minLength 3, /level: enum, /price: minimum 0, /syllabus/hours: additionalProperties false, /syllabus/weeks: bsonType int, /tags/1: bsonType string", schema.ViolationsMessage(violations))
+	})
+	t.Run("TestValidate_ShouldReportRequired_WhenFieldMissing", func(t *testing.T) {
+		violations := schema.Validate(courseSchema, bson.M{"course_name": "DSA"})
+
+		assert.Equal(t, []schema.SchemaViolation{{Pointer: "/price", Keyword: "required"}}, violations)
+	})
+	t.Run("TestValidate_ShouldAcceptBsonDecodedDocument_WhenDocumentMatchesSchema", func(t *testing.T) {
+		document := bson.M{"course_name": "DSA", "price": int32(10), "syllabus": bson.D{{Key: "weeks", Value: int32(8)}}}
+
+		violations := schema.Validate(courseSchema, document)
+
+		assert.Empty(t, violations)
+	})
+}
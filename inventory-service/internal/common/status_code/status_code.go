@@ -24,6 +24,49 @@ const (
 	IMS118 dto.StatusCode = "IMS118:Invalid Lesson Name or Subject Id"
 	IMS119 dto.StatusCode = "IMS119:Invalid Resource Id Provided"
 	IMS114 dto.StatusCode = "IMS114:Inventory Configuration not found"
+	IMS120 dto.StatusCode = "IMS120:Error while updating collection schema"
+	IMS121 dto.StatusCode = "IMS121:Error while dropping index"
+	IMS122 dto.StatusCode = "IMS122:Error occurred while updating configuration"
+	IMS123 dto.StatusCode = "IMS123:Inventory Configuration modified concurrently, retry with latest version"
+	IMS124 dto.StatusCode = "IMS124:Error occurred while saving configuration revision"
+	IMS125 dto.StatusCode = "IMS125:Invalid json schema"
+	IMS126 dto.StatusCode = "IMS126:Error while validating existing documents against schema"
+	IMS127 dto.StatusCode = "IMS127:Inventory Configuration is not deleted"
+	IMS128 dto.StatusCode = "IMS128:Invalid purge confirmation token"
+	IMS129 dto.StatusCode = "IMS129:Error while dropping collection"
+	IMS130 dto.StatusCode = "IMS130:Error while restoring inventory configuration"
+	IMS131 dto.StatusCode = "IMS131:Error while purging inventory configuration"
+	IMS132 dto.StatusCode = "IMS132:Inventory identifier not present in json schema properties"
+	IMS133 dto.StatusCode = "IMS133:Error while rolling back inventory configuration"
+	IMS134 dto.StatusCode = "IMS134:Error while listing inventory collections"
+	IMS135 dto.StatusCode = "IMS135:Error while inspecting inventory collection"
+	IMS136 dto.StatusCode = "IMS136:Error while generating inventory item id"
+	IMS137 dto.StatusCode = "IMS137:Invalid pagination settings"
+	IMS138 dto.StatusCode = "IMS138:Invalid pagination request"
+	IMS139 dto.StatusCode = "IMS139:Invalid bulk insert request"
+	IMS140 dto.StatusCode = "IMS140:Upsert key is not a unique inventory identifier"
+	IMS141 dto.StatusCode = "IMS141:Inventory item version conflict"
+	IMS142 dto.StatusCode = "IMS142:Update modifies a protected field"
+	IMS143 dto.StatusCode = "IMS143:Invalid patch document"
+	IMS144 dto.StatusCode = "IMS144:Error occurred while accessing item revisions"
+	IMS145 dto.StatusCode = "IMS145:Item revision not found"
+	IMS146 dto.StatusCode = "IMS146:Invalid revision request"
+	IMS147 dto.StatusCode = "IMS147:Inventory item is not deleted"
+	IMS148 dto.StatusCode = "IMS148:Restored item conflicts with a live item on a unique identifier"
+	IMS149 dto.StatusCode = "IMS149:Inventory has no retention policy"
+	IMS150 dto.StatusCode = "IMS150:Error occurred while purging inventory"
+	IMS151 dto.StatusCode = "IMS151:Invalid filter"
+	IMS152 dto.StatusCode = "IMS152:Invalid sort"
+	IMS153 dto.StatusCode = "IMS153:Invalid projection"
+	IMS154 dto.StatusCode = "IMS154:Invalid searchable fields"
+	IMS155 dto.StatusCode = "IMS155:Inventory has no searchable fields"
+	IMS156 dto.StatusCode = "IMS156:Invalid search query"
+	IMS157 dto.StatusCode = "IMS157:Invalid aggregation request"
+	IMS158 dto.StatusCode = "IMS158:Error occurred while aggregating inventory"
+	IMS159 dto.StatusCode = "IMS159:Invalid facet request"
+	IMS160 dto.StatusCode = "IMS160:Invalid export request"
+	IMS161 dto.StatusCode = "IMS161:Error occurred while exporting inventory"
+	IMS162 dto.StatusCode = "IMS162:Invalid import request"
 
 	IMS200 dto.StatusCode = "IMS200:success"
 	IMS204 dto.StatusCode = "IMS204:Inventory Configuration deleted"
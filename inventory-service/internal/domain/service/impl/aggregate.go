@@ -0,0 +1,231 @@
+package impl
+
+import (
+	"context"
+	"inventory-system/common/pkg/constants"
+	"inventory-system/common/pkg/dto"
+	"inventory-system/common/pkg/logger"
+	"inventory-system/common/pkg/utils"
+	commonDto "inventory-system/inventory-service/internal/common/dto"
+	"inventory-system/inventory-service/internal/common/dto/request_dto"
+	"inventory-system/inventory-service/internal/common/dto/response_dto"
+	"inventory-system/inventory-service/internal/common/filter"
+	"inventory-system/inventory-service/internal/common/schema"
+	"inventory-system/inventory-service/internal/common/status_code"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// name of the item count of a group, metric names must not clash with it
+const aggregateCountField = "count"
+
+// metric names are returned as keys of the group metrics
+var metricNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// aggregateOperators : mongo accumulator of each metric operator
+var aggregateOperators = map[string]string{
+	request_dto.MetricSum: "$sum",
+	request_dto.MetricAvg: "$avg",
+	request_dto.MetricMin: "$min",
+	request_dto.MetricMax: "$max",
+}
+
+// AggregateInventory : groups of the live items matching the request filter with their count and metrics, see CompileAggregation
+func (c InventoryService) AggregateInventory(ctx context.Context, inventoryName string, aggregateRequest request_dto.AggregateRequest) (*commonDto.AggregateResult, *dto.ErrorResponseDto) {
+	methodName := "AggregateInventory"
+	log := logger.GetLogger()
+	log.Info("Inside "+methodName+" aggregating items of :", inventoryName)
+
+	inventoryConfiguration, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, inventoryName)
+	if errDto != nil {
+		log.Info("Inside "+methodName+" unable to fetch inventory configuration for inventoryName :", inventoryName)
+		return nil, errDto
+	}
+	pipeline, compileErr := CompileAggregation(*inventoryConfiguration, aggregateRequest)
+	if compileErr != nil {
+		log.Error("Inside "+methodName+" invalid aggregation request for "+inventoryName+" : ", compileErr.Message)
+		return nil, compileErr
+	}
+
+	groups, adapterError := c.InventoryRepository.AggregateInventory(ctx, inventoryName, pipeline)
+	if adapterError != nil {
+		log.Error("Inside "+methodName+" error while aggregating items of :", inventoryName)
+		return nil, adapterError
+	}
+
+	limit := aggregateLimit(aggregateRequest)
+	result := &commonDto.AggregateResult{Groups: []commonDto.AggregateGroup{}}
+	if int64(len(groups)) > limit {
+		groups, result.IsTruncated = groups[:limit], true
+	}
+	metricNames := aggregateMetricNames(aggregateRequest.Metrics)
+	for _, group := range groups {
+		aggregateGroup := commonDto.AggregateGroup{Key: map[string]interface{}{}}
+		groupKey, _ := utils.AsMap(group["_id"])
+		for index, field := range aggregateRequest.GroupBy {
+			aggregateGroup.Key[field] = groupKey[groupAlias(index)]
+		}
+		aggregateGroup.Count = countValue(group[aggregateCountField])
+		if len(metricNames) > 0 {
+			aggregateGroup.Metrics = map[string]interface{}{}
+			for index, name := range metricNames {
+				aggregateGroup.Metrics[name] = group[metricAlias(index)]
+			}
+		}
+		result.Groups = append(result.Groups, aggregateGroup)
+	}
+	return result, nil
+}
+
+// CompileAggregation : mongo pipeline of the aggregation request. Items are grouped on inventory identifiers and metrics are
+// computed over numeric schema fields, group fields and metrics are aliased in the pipeline so their names cannot inject
+// operators. One group more than the limit is read to tell whether the result is truncated
+func CompileAggregation(inventoryConfiguration response_dto.InventoryConfigurationResponseDto, aggregateRequest request_dto.AggregateRequest) (bson.A, *dto.ErrorResponseDto) {
+	var domainErr dto.ErrorResponseDto
+	invalid := func(detail string) (bson.A, *dto.ErrorResponseDto) {
+		domainErr.SetError(status_code.IMS157)
+		domainErr.Message = domainErr.Message + " : " + detail
+		return nil, &domainErr
+	}
+
+	if len(aggregateRequest.GroupBy) == 0 {
+		return invalid("group_by must name at least one identifier")
+	}
+	if len(aggregateRequest.GroupBy) > constants.MaxAggregateGroupFields {
+		return invalid("group_by must not have more than " + strconv.Itoa(constants.MaxAggregateGroupFields) + " fields")
+	}
+	groupKey := bson.D{}
+	for index, field := range aggregateRequest.GroupBy {
+		switch {
+		case !KeyExists(inventoryConfiguration.InventoryIdentifiers, field):
+			return invalid(field + " is not an indexed identifier")
+		case utils.Contains(aggregateRequest.GroupBy[:index], field):
+			return invalid(field + " is grouped on twice")
+		}
+		groupKey = append(groupKey, bson.E{Key: groupAlias(index), Value: "$" + field})
+	}
+
+	if len(aggregateRequest.Metrics) > constants.MaxAggregateMetrics {
+		return invalid("metrics must not have more than " + strconv.Itoa(constants.MaxAggregateMetrics) + " entries")
+	}
+	jsonSchema, _ := schema.ExtractJsonSchema(inventoryConfiguration.JsonSchema)
+	group := bson.D{{Key: "_id", Value: groupKey}, {Key: aggregateCountField, Value: bson.M{"$sum": 1}}}
+	metricNames := aggregateMetricNames(aggregateRequest.Metrics)
+	for index, metric := range aggregateRequest.Metrics {
+		accumulator, isOperator := aggregateOperators[metric.Operator]
+		switch {
+		case !isOperator:
+			return invalid("unknown metric operator " + metric.Operator + ", expected one of sum, avg, min or max")
+		case metric.Field == "":
+			return invalid(metric.Operator + " metric must name a field")
+		case !filter.IsNumeric(jsonSchema, metric.Field):
+			return invalid(metric.Field + " is not a numeric field")
+		case !metricNamePattern.MatchString(metricNames[index]):
+			return invalid("metric name " + metricNames[index] + " must start with a letter and contain only letters, digits and _")
+		case metricNames[index] == aggregateCountField || utils.Contains(metricNames[:index], metricNames[index]):
+			return invalid("metric name " + metricNames[index] + " is used twice")
+		}
+		group = append(group, bson.E{Key: metricAlias(index), Value: bson.M{accumulator: "$" + metric.Field}})
+	}
+
+	order, orderErr := aggregateSort(aggregateRequest, metricNames)
+	if orderErr != "" {
+		return invalid(orderErr)
+	}
+	if aggregateRequest.Limit < 0 || aggregateRequest.Limit > constants.MaxAggregateLimit {
+		return invalid("limit must be between 1 and " + strconv.Itoa(constants.MaxAggregateLimit))
+	}
+
+	match := bson.M{constants.ItemIsDeletedField: false}
+	if len(aggregateRequest.Filter) > 0 {
+		query, filterErr := ParseFilter(inventoryConfiguration, aggregateRequest.Filter)
+		if filterErr != nil {
+			return nil, filterErr
+		}
+		match = bson.M{"$and": bson.A{match, query}}
+	}
+
+	return bson.A{
+		bson.D{{Key: "$match", Value: match}},
+		bson.D{{Key: "$group", Value: group}},
+		bson.D{{Key: "$sort", Value: order}},
+		bson.D{{Key: "$limit", Value: aggregateLimit(aggregateRequest) + 1}},
+	}, nil
+}
+
+// aggregateSort : order of the groups, by count descending unless the request sorts on the count, a metric or a group field.
+// Ties are broken on the group key so the truncation is stable
+func aggregateSort(aggregateRequest request_dto.AggregateRequest, metricNames []string) (bson.D, string) {
+	sort := aggregateRequest.Sort
+	if sort == "" {
+		sort = "-" + aggregateCountField
+	}
+	field, direction := sort, 1
+	if strings.HasPrefix(field, "-") {
+		field, direction = field[1:], -1
+	}
+	var sortKey string
+	switch {
+	case field == aggregateCountField:
+		sortKey = aggregateCountField
+	case utils.Contains(metricNames, field):
+		for index, name := range metricNames {
+			if name == field {
+				sortKey = metricAlias(index)
+			}
+		}
+	case utils.Contains(aggregateRequest.GroupBy, field):
+		for index, groupField := range aggregateRequest.GroupBy {
+			if groupField == field {
+				sortKey = "_id." + groupAlias(index)
+			}
+		}
+	default:
+		return nil, "cannot sort on " + field + ", expected count, a metric name or a group_by field"
+	}
+	return bson.D{{Key: sortKey, Value: direction}, {Key: "_id", Value: 1}}, ""
+}
+
+// aggregateMetricNames : names of the metrics, <op>_<field> with dots replaced by _ when not given
+func aggregateMetricNames(metrics []request_dto.AggregateMetric) []string {
+	names := make([]string, 0, len(metrics))
+	for _, metric := range metrics {
+		name := metric.Name
+		if name == "" {
+			name = metric.Operator + "_" + strings.ReplaceAll(metric.Field, ".", "_")
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func aggregateLimit(aggregateRequest request_dto.AggregateRequest) int64 {
+	if aggregateRequest.Limit == 0 {
+		return constants.DefaultAggregateLimit
+	}
+	return aggregateRequest.Limit
+}
+
+func groupAlias(index int) string {
+	return "g" + strconv.Itoa(index)
+}
+
+func metricAlias(index int) string {
+	return "m" + strconv.Itoa(index)
+}
+
+// countValue : group count as int64, mongo returns int32 for counts that fit
+func countValue(value interface{}) int64 {
+	switch count := value.(type) {
+	case int32:
+		return int64(count)
+	case int64:
+		return count
+	case float64:
+		return int64(count)
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package impl
+
+import (
+	"context"
+	"inventory-system/common/pkg/constants"
+	"inventory-system/common/pkg/dto"
+	"inventory-system/common/pkg/logger"
+	"inventory-system/inventory-service/internal/common/dto/request_dto"
+	"inventory-system/inventory-service/internal/common/export"
+	"inventory-system/inventory-service/internal/common/schema"
+	"inventory-system/inventory-service/internal/common/status_code"
+	"io"
+)
+
+// ExportInventory : streams the live items selected by the export request to the writer in the requested format, ndjson by
+// default. The request is validated before anything is written, csv columns are derived from the inventory schema
+func (c InventoryService) ExportInventory(ctx context.Context, inventoryName string, exportRequest request_dto.ExportRequest, writer io.Writer) *dto.ErrorResponseDto {
+	methodName := "ExportInventory"
+	log := logger.GetLogger()
+	var domainErr dto.ErrorResponseDto
+	log.Info("Inside "+methodName+" exporting items of :", inventoryName, " as : ", exportRequest.Format)
+
+	if exportRequest.Format == "" {
+		exportRequest.Format = export.FormatNDJSON
+	}
+	if export.ContentType(exportRequest.Format) == "" {
+		domainErr.SetError(status_code.IMS160)
+		domainErr.Message = domainErr.Message + " : " + export.ErrUnknownFormat.Error()
+		return &domainErr
+	}
+
+	inventoryConfiguration, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, inventoryName)
+	if errDto != nil {
+		log.Info("Inside "+methodName+" unable to fetch inventory configuration for inventoryName :", inventoryName)
+		return errDto
+	}
+	//Exports are not paged, the pagination only gives the default order of the inventory
+	pagination, paginationErr := ResolvePagination(*inventoryConfiguration, "", "")
+	if paginationErr == nil && exportRequest.Sort != "" {
+		pagination.Sort, paginationErr = ResolveSort(*inventoryConfiguration, exportRequest.Sort)
+	}
+	if paginationErr != nil {
+		log.Error("Inside "+methodName+" invalid sort for "+inventoryName+" : ", paginationErr.Message)
+		return paginationErr
+	}
+	itemProjection, projectionErr := ResolveProjection(exportRequest.Fields, exportRequest.Exclude, constants.ItemIdField)
+	if projectionErr != nil {
+		log.Error("Inside "+methodName+" invalid projection for "+inventoryName+" : ", projectionErr.Message)
+		return projectionErr
+	}
+	query, filterErr := ParseFilter(*inventoryConfiguration, exportRequest.Filter)
+	if filterErr != nil {
+		log.Error("Inside "+methodName+" invalid filter for :", inventoryName, " : ", filterErr.Message)
+		return filterErr
+	}
+
+	jsonSchema, _ := schema.ExtractJsonSchema(inventoryConfiguration.JsonSchema)
+	encoder, _ := export.NewEncoder(exportRequest.Format, writer, export.Columns(jsonSchema, itemProjection))
+	adapterError := c.InventoryRepository.ExportInventory(ctx, exportRequest.From, exportRequest.To, inventoryName, query, pagination.Order(), itemProjection, encoder.Write)
+	if adapterError != nil {
+		log.Error("Inside "+methodName+" error while exporting items of :", inventoryName)
+		return adapterError
+	}
+	if err := encoder.End(); err != nil {
+		log.Error("Inside "+methodName+" error while ending export of :", inventoryName, " : ", err.Error())
+		domainErr.SetError(status_code.IMS161)
+		domainErr.Message = domainErr.Message + " : " + err.Error()
+		return &domainErr
+	}
+	return nil
+}
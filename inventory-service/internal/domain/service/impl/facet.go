@@ -0,0 +1,208 @@
+package impl
+
+import (
+	"context"
+	"inventory-system/common/pkg/constants"
+	"inventory-system/common/pkg/dto"
+	"inventory-system/common/pkg/logger"
+	"inventory-system/common/pkg/utils"
+	commonDto "inventory-system/inventory-service/internal/common/dto"
+	"inventory-system/inventory-service/internal/common/dto/request_dto"
+	"inventory-system/inventory-service/internal/common/dto/response_dto"
+	"inventory-system/inventory-service/internal/common/filter"
+	"inventory-system/inventory-service/internal/common/status_code"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// GetInventoryFacets : values of each facet field with their item counts, computed in a single aggregation, see CompileFacets
+func (c InventoryService) GetInventoryFacets(ctx context.Context, inventoryName string, facetRequest request_dto.FacetRequest) ([]commonDto.Facet, *dto.ErrorResponseDto) {
+	methodName := "GetInventoryFacets"
+	log := logger.GetLogger()
+	log.Info("Inside "+methodName+" computing facets of :", inventoryName)
+
+	inventoryConfiguration, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, inventoryName)
+	if errDto != nil {
+		log.Info("Inside "+methodName+" unable to fetch inventory configuration for inventoryName :", inventoryName)
+		return nil, errDto
+	}
+	pipeline, compileErr := CompileFacets(*inventoryConfiguration, facetRequest)
+	if compileErr != nil {
+		log.Error("Inside "+methodName+" invalid facet request for "+inventoryName+" : ", compileErr.Message)
+		return nil, compileErr
+	}
+
+	results, adapterError := c.InventoryRepository.AggregateInventory(ctx, inventoryName, pipeline)
+	if adapterError != nil {
+		log.Error("Inside "+methodName+" error while computing facets of :", inventoryName)
+		return nil, adapterError
+	}
+
+	limit := facetLimit(facetRequest)
+	facetValues := bson.M{}
+	if len(results) > 0 {
+		facetValues = results[0]
+	}
+	facets := make([]commonDto.Facet, 0, len(facetRequest.Facets))
+	for index, field := range facetRequest.Facets {
+		facet := commonDto.Facet{Field: field, Values: []commonDto.FacetValue{}}
+		buckets, _ := utils.AsSlice(facetValues[facetAlias(index)])
+		if int64(len(buckets)) > limit {
+			buckets, facet.IsTruncated = buckets[:limit], true
+		}
+		for _, bucket := range buckets {
+			value, _ := utils.AsMap(bucket)
+			facet.Values = append(facet.Values, commonDto.FacetValue{Value: value["_id"], Count: countValue(value[aggregateCountField])})
+		}
+		facets = append(facets, facet)
+	}
+	return facets, nil
+}
+
+// CompileFacets : mongo pipeline computing every facet in one $facet stage. Conditions of the filter on fields that are not
+// facets are matched once for all facets, the condition on a facet field applies to the other facets only. Array fields
+// are unwound so each element is a value of the facet. A facet field can only be selected at the top level of the filter,
+// a condition on it inside and/or could not be left out of its own facet
+func CompileFacets(inventoryConfiguration response_dto.InventoryConfigurationResponseDto, facetRequest request_dto.FacetRequest) (bson.A, *dto.ErrorResponseDto) {
+	var domainErr dto.ErrorResponseDto
+	invalid := func(detail string) (bson.A, *dto.ErrorResponseDto) {
+		domainErr.SetError(status_code.IMS159)
+		domainErr.Message = domainErr.Message + " : " + detail
+		return nil, &domainErr
+	}
+
+	if len(facetRequest.Facets) == 0 {
+		return invalid("facets must name at least one identifier")
+	}
+	if len(facetRequest.Facets) > constants.MaxFacetFields {
+		return invalid("facets must not have more than " + strconv.Itoa(constants.MaxFacetFields) + " fields")
+	}
+	for index, field := range facetRequest.Facets {
+		switch {
+		case !KeyExists(inventoryConfiguration.InventoryIdentifiers, field):
+			return invalid(field + " is not an indexed identifier")
+		case utils.Contains(facetRequest.Facets[:index], field):
+			return invalid(field + " is requested twice")
+		}
+	}
+	if facetRequest.Limit < 0 || facetRequest.Limit > constants.MaxFacetLimit {
+		return invalid("limit must be between 1 and " + strconv.Itoa(constants.MaxFacetLimit))
+	}
+
+	//Each top level condition is parsed on its own so it can be left out of its own facet
+	fields := make([]string, 0, len(facetRequest.Filter))
+	for field := range facetRequest.Filter {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	sharedConditions := bson.A{bson.M{constants.ItemIsDeletedField: false}}
+	selections := map[string]bson.M{}
+	for _, field := range fields {
+		condition, filterErr := ParseFilter(inventoryConfiguration, map[string]interface{}{field: facetRequest.Filter[field]})
+		if filterErr != nil {
+			return nil, filterErr
+		}
+		if field == filter.OperatorAnd || field == filter.OperatorOr {
+			if facetField, found := combinedFacetField(facetRequest.Filter[field], facetRequest.Facets); found {
+				return invalid(facetField + " is a facet and can only be filtered at the top level of the filter")
+			}
+		}
+		if utils.Contains(facetRequest.Facets, field) {
+			selections[field] = condition
+			continue
+		}
+		sharedConditions = append(sharedConditions, condition)
+	}
+
+	facetStage := bson.D{}
+	for index, field := range facetRequest.Facets {
+		var facetPipeline bson.A
+		otherSelections := bson.A{}
+		for _, selectedField := range fields {
+			if condition, isSelection := selections[selectedField]; isSelection && selectedField != field {
+				otherSelections = append(otherSelections, condition)
+			}
+		}
+		if len(otherSelections) > 0 {
+			facetPipeline = append(facetPipeline, bson.D{{Key: "$match", Value: matchAll(otherSelections)}})
+		}
+		facetPipeline = append(facetPipeline,
+			bson.D{{Key: "$unwind", Value: "$" + field}},
+			bson.D{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$" + field}, {Key: aggregateCountField, Value: bson.M{"$sum": 1}}}}},
+			bson.D{{Key: "$sort", Value: bson.D{{Key: aggregateCountField, Value: -1}, {Key: "_id", Value: 1}}}},
+			bson.D{{Key: "$limit", Value: facetLimit(facetRequest) + 1}},
+		)
+		facetStage = append(facetStage, bson.E{Key: facetAlias(index), Value: facetPipeline})
+	}
+
+	return bson.A{
+		bson.D{{Key: "$match", Value: matchAll(sharedConditions)}},
+		bson.D{{Key: "$facet", Value: facetStage}},
+	}, nil
+}
+
+// combinedFacetField : first facet field named by the filter documents combined under an and/or
+func combinedFacetField(combined interface{}, facets []string) (string, bool) {
+	subDocuments, _ := utils.AsSlice(combined)
+	for _, subDocument := range subDocuments {
+		subFilter, _ := utils.AsMap(subDocument)
+		keys := make([]string, 0, len(subFilter))
+		for key := range subFilter {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if value := subFilter[key]; key == filter.OperatorAnd || key == filter.OperatorOr {
+				if facetField, found := combinedFacetField(value, facets); found {
+					return facetField, true
+				}
+			} else if utils.Contains(facets, key) {
+				return key, true
+			}
+		}
+	}
+	return "", false
+}
+
+// FilterValuesPipeline : mongo pipeline of a page of the distinct values of the field among the live items matching the
+// filters, in ascending order. Array fields are unwound and values can be restricted to strings starting with prefix,
+// ignoring case. The pipeline returns a single document with the page of values and their total count
+func FilterValuesPipeline(field string, filters bson.M, prefix string, pagination commonDto.Pagination) bson.A {
+	pipeline := bson.A{
+		bson.D{{Key: "$match", Value: matchAll(bson.A{bson.M{constants.ItemIsDeletedField: false}, filters})}},
+		bson.D{{Key: "$unwind", Value: "$" + field}},
+	}
+	if prefix != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{field: bson.M{"$regex": "^" + regexp.QuoteMeta(prefix), "$options": "i"}}}})
+	}
+	return append(pipeline,
+		bson.D{{Key: "$group", Value: bson.M{"_id": "$" + field}}},
+		bson.D{{Key: "$sort", Value: bson.M{"_id": 1}}},
+		bson.D{{Key: "$facet", Value: bson.D{
+			{Key: "values", Value: bson.A{bson.D{{Key: "$skip", Value: pagination.PageSize * pagination.PageNumber}}, bson.D{{Key: "$limit", Value: pagination.PageSize}}}},
+			{Key: "total", Value: bson.A{bson.D{{Key: "$count", Value: aggregateCountField}}}},
+		}}},
+	)
+}
+
+// matchAll : condition of all the conditions, the condition itself when there is only one
+func matchAll(conditions bson.A) interface{} {
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+	return bson.M{"$and": conditions}
+}
+
+func facetLimit(facetRequest request_dto.FacetRequest) int64 {
+	if facetRequest.Limit == 0 {
+		return constants.DefaultFacetLimit
+	}
+	return facetRequest.Limit
+}
+
+func facetAlias(index int) string {
+	return "f" + strconv.Itoa(index)
+}
@@ -0,0 +1,451 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"inventory-system/common/pkg/constants"
+	"inventory-system/common/pkg/dto"
+	"inventory-system/common/pkg/logger"
+	commonDto "inventory-system/inventory-service/internal/common/dto"
+	"inventory-system/inventory-service/internal/common/dto/request_dto"
+	"inventory-system/inventory-service/internal/common/dto/response_dto"
+	"inventory-system/inventory-service/internal/common/importer"
+	"inventory-system/inventory-service/internal/common/schema"
+	"inventory-system/inventory-service/internal/common/status_code"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// deletion reason of the items removed by a replace_all import
+const importDeletionReason = "replaced by import"
+
+// ImportInventory : reads the rows of the file, coerces them to the inventory schema and writes the valid ones ImportBatchSize
+// at a time. Insert creates an item per row, upsert replaces the live item having the key value of the row and creates the
+// others. replace_all replaces the live items matching a row on a unique identifier, creates the others and then removes the
+// live items the import did not write, it only writes when every row is valid and only removes when every row was written.
+// Rejected rows are reported with their reason, a dry run validates every row without writing
+func (c InventoryService) ImportInventory(ctx context.Context, inventoryName string, importRequest request_dto.ImportRequest, file io.ReadSeeker, caller string) (*commonDto.ImportReport, *dto.ErrorResponseDto) {
+	methodName := "ImportInventory"
+	log := logger.GetLogger()
+	var domainErr dto.ErrorResponseDto
+	log.Info("Inside "+methodName+" importing items into :", inventoryName, " as : ", importRequest.Format, " mode : ", importRequest.Mode)
+
+	invalid := func(detail string) (*commonDto.ImportReport, *dto.ErrorResponseDto) {
+		domainErr.SetError(status_code.IMS162)
+		domainErr.Message = domainErr.Message + " : " + detail
+		return nil, &domainErr
+	}
+	if importRequest.Mode == "" {
+		importRequest.Mode = request_dto.ImportModeInsert
+	}
+	switch importRequest.Mode {
+	case request_dto.ImportModeInsert, request_dto.ImportModeUpsert, request_dto.ImportModeReplaceAll:
+	default:
+		return invalid("unknown import mode " + importRequest.Mode + ", expected one of insert, upsert or replace_all")
+	}
+	if importRequest.Format != importer.FormatCSV && importRequest.Format != importer.FormatNDJSON {
+		return invalid(importer.ErrUnknownFormat.Error())
+	}
+	if (importRequest.Mode == request_dto.ImportModeUpsert) != (importRequest.Key != "") {
+		return invalid("key is required in upsert mode and only allowed in it")
+	}
+
+	inventoryConfiguration, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, inventoryName)
+	if errDto != nil {
+		log.Info("Inside "+methodName+" unable to fetch inventory configuration for inventoryName :", inventoryName)
+		return nil, errDto
+	}
+	if importRequest.Key != "" && (isManagedItemField(importRequest.Key) || !UniqueKeyExists(inventoryConfiguration.InventoryIdentifiers, importRequest.Key)) {
+		log.Error("Inside "+methodName+" upsert key "+importRequest.Key+" is not a unique identifier of: ", inventoryName)
+		domainErr.SetError(status_code.IMS140)
+		domainErr.Message = domainErr.Message + " : " + importRequest.Key
+		return nil, &domainErr
+	}
+
+	if importRequest.Mode != request_dto.ImportModeReplaceAll || importRequest.DryRun {
+		report, errDto := c.importRows(ctx, inventoryName, *inventoryConfiguration, importRequest, file, caller, importRequest.DryRun)
+		if errDto != nil {
+			return nil, errDto
+		}
+		report.IsApplied = !importRequest.DryRun
+		log.Info("Inside "+methodName+" imported ", report.InsertedCount, " and replaced ", report.ReplacedCount, " of ", report.TotalRows, " rows into "+inventoryName)
+		return report, nil
+	}
+
+	//replace_all writes only once a first pass over the file found no rejected row
+	report, errDto := c.importRows(ctx, inventoryName, *inventoryConfiguration, importRequest, file, caller, true)
+	if errDto != nil {
+		return nil, errDto
+	}
+	report.IsDryRun = false
+	if report.RejectedCount > 0 {
+		log.Info("Inside "+methodName+" replace_all import of "+inventoryName+" stopped by rejected rows : ", report.RejectedCount)
+		return report, nil
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		log.Error("Inside "+methodName+" error: ", err.Error(), " while rewinding import file for: ", inventoryName)
+		domainErr.SetError(status_code.IMS500)
+		return nil, &domainErr
+	}
+	report, errDto = c.importRows(ctx, inventoryName, *inventoryConfiguration, importRequest, file, caller, false)
+	if errDto != nil {
+		return nil, errDto
+	}
+	report.IsApplied = true
+	if report.RejectedCount > 0 {
+		//the items that were not replaced are kept so no item of the inventory goes missing
+		log.Error("Inside "+methodName+" replace_all import of "+inventoryName+" kept the previous items after rejected writes : ", report.RejectedCount)
+		return report, nil
+	}
+
+	//every item written by the import is stamped with the time it started, the items it did not write are older
+	removeFields := ItemUpdateMetadata(caller, time.Now())
+	removeFields[constants.ItemDeletedAtField] = removeFields[constants.ItemUpdatedAtField]
+	removeFields[constants.ItemDeletedByField] = caller
+	removeFields[constants.ItemDeletionReasonField] = importDeletionReason
+	removedFields := ApplySetFields(bson.M{constants.ItemIsDeletedField: true}, removeFields)
+	report.RemovedCount, errDto = c.InventoryRepository.RemoveItemsUpdatedBefore(ctx, inventoryName, report.StartedAt, removeFields, func(items []bson.M) {
+		revisions := make([]commonDto.ItemRevision, 0, len(items))
+		for _, item := range items {
+			revisions = append(revisions, NewItemRevision(commonDto.ItemRevisionDelete, item, nextItemState(item, removedFields)))
+		}
+		c.recordItemRevisions(ctx, inventoryName, revisions...)
+	})
+	if errDto != nil {
+		log.Error("Inside "+methodName+" error while removing items of "+inventoryName+" after removing ", report.RemovedCount)
+		return nil, errDto
+	}
+	log.Info("Inside "+methodName+" replaced the items of "+inventoryName+" by ", report.ValidCount, " rows, removed ", report.RemovedCount)
+	return report, nil
+}
+
+// itemImport : state of one pass over an import file, rows are written a batch at a time. Rows are matched to live items on
+// the upsert key, or on every unique identifier in replace_all mode
+type itemImport struct {
+	service                InventoryService
+	inventoryName          string
+	inventoryConfiguration response_dto.InventoryConfigurationResponseDto
+	request                request_dto.ImportRequest
+	caller                 string
+	now                    time.Time
+	isDryRun               bool
+	matchFields            [][]string
+	report                 *commonDto.ImportReport
+	batch                  []*importer.Row
+	keyRows                map[string]int
+}
+
+// importRows : one pass over the file, nothing is written in a dry run
+func (c InventoryService) importRows(ctx context.Context, inventoryName string, inventoryConfiguration response_dto.InventoryConfigurationResponseDto, importRequest request_dto.ImportRequest, file io.Reader, caller string, isDryRun bool) (*commonDto.ImportReport, *dto.ErrorResponseDto) {
+	methodName := "importRows"
+	log := logger.GetLogger()
+	var domainErr dto.ErrorResponseDto
+
+	jsonSchema, _ := schema.ExtractJsonSchema(inventoryConfiguration.JsonSchema)
+	reader, err := importer.NewReader(importRequest.Format, file, jsonSchema)
+	if err != nil {
+		log.Error("Inside "+methodName+" unable to read import file for "+inventoryName+" : ", err.Error())
+		domainErr.SetError(status_code.IMS162)
+		domainErr.Message = domainErr.Message + " : " + err.Error()
+		return nil, &domainErr
+	}
+
+	//mongo keeps dates to the millisecond, written items must not look older than the import
+	now := time.Now().Truncate(time.Millisecond)
+	itemImport := &itemImport{
+		service:                c,
+		inventoryName:          inventoryName,
+		inventoryConfiguration: inventoryConfiguration,
+		request:                importRequest,
+		caller:                 caller,
+		now:                    now,
+		isDryRun:               isDryRun,
+		matchFields:            importMatchFields(inventoryConfiguration, importRequest),
+		report:                 &commonDto.ImportReport{Mode: importRequest.Mode, IsDryRun: isDryRun, StartedAt: now, Rejected: []commonDto.ImportRejection{}},
+		keyRows:                map[string]int{},
+	}
+	for {
+		row, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			//the rest of the file cannot be read, the rows read so far are still written
+			log.Error("Inside "+methodName+" error while reading import file for "+inventoryName+" : ", err.Error())
+			var readErr dto.ErrorResponseDto
+			readErr.SetError(status_code.IMS162)
+			itemImport.reject(0, &readErr, err.Error())
+			break
+		}
+		itemImport.report.TotalRows++
+		if errDto := itemImport.add(ctx, row); errDto != nil {
+			return nil, errDto
+		}
+	}
+	if errDto := itemImport.flush(ctx); errDto != nil {
+		return nil, errDto
+	}
+
+	report := itemImport.report
+	sort.SliceStable(report.Rejected, func(i, j int) bool {
+		return report.Rejected[i].Row < report.Rejected[j].Row
+	})
+	if len(report.Rejected) > constants.MaxImportRejections {
+		report.Rejected = report.Rejected[:constants.MaxImportRejections]
+	}
+	return report, nil
+}
+
+// importMatchFields : fields of each identifier matching rows to live items, the upsert key or every unique identifier in
+// replace_all mode. Rows of insert imports are never matched
+func importMatchFields(inventoryConfiguration response_dto.InventoryConfigurationResponseDto, importRequest request_dto.ImportRequest) [][]string {
+	switch importRequest.Mode {
+	case request_dto.ImportModeUpsert:
+		return [][]string{{importRequest.Key}}
+	case request_dto.ImportModeReplaceAll:
+		var matchFields [][]string
+		for _, identifier := range inventoryConfiguration.InventoryIdentifiers {
+			if identifier.IsUnique && !identifier.IsText() {
+				matchFields = append(matchFields, identifier.Fields())
+			}
+		}
+		return matchFields
+	}
+	return nil
+}
+
+// add : checks the row on its own and queues it for the next batch
+func (i *itemImport) add(ctx context.Context, row *importer.Row) *dto.ErrorResponseDto {
+	if row.Err != nil {
+		var rowErr dto.ErrorResponseDto
+		rowErr.SetError(status_code.IMS109)
+		i.reject(row.Number, &rowErr, row.Err.Error())
+		return nil
+	}
+	if i.request.Key != "" {
+		if keyValue, hasKey := ItemFieldValue(row.Item, i.request.Key); !hasKey || keyValue == nil {
+			var rowErr dto.ErrorResponseDto
+			rowErr.SetError(status_code.IMS400)
+			i.reject(row.Number, &rowErr, "row has no value for "+i.request.Key)
+			return nil
+		}
+	}
+	//a unique value is written once, a later row would replace the earlier one or fail on the unique index
+	var rowKeys []string
+	for _, fields := range i.matchFields {
+		keyText, hasKey := identifierText(row.Item, fields)
+		if !hasKey {
+			continue
+		}
+		if firstRow, isRepeated := i.keyRows[keyText]; isRepeated {
+			var rowErr dto.ErrorResponseDto
+			rowErr.SetError(status_code.IMS108)
+			i.reject(row.Number, &rowErr, keyText+" is already imported by row "+strconv.Itoa(firstRow))
+			return nil
+		}
+		rowKeys = append(rowKeys, keyText)
+	}
+	for _, keyText := range rowKeys {
+		i.keyRows[keyText] = row.Number
+	}
+	i.batch = append(i.batch, row)
+	if len(i.batch) < constants.ImportBatchSize {
+		return nil
+	}
+	return i.flush(ctx)
+}
+
+// flush : validates the queued rows as the items they create or replace and writes the valid ones
+func (i *itemImport) flush(ctx context.Context) *dto.ErrorResponseDto {
+	methodName := "flush"
+	log := logger.GetLogger()
+	if len(i.batch) == 0 {
+		return nil
+	}
+	batch := i.batch
+	i.batch = nil
+
+	existingItems, errDto := i.existingItems(ctx, batch)
+	if errDto != nil {
+		return errDto
+	}
+
+	var writes []commonDto.ItemWrite
+	var writeRows []int
+	var revisions []commonDto.ItemRevision
+	for _, row := range batch {
+		existingItem, isAmbiguous := i.matchingItem(row, existingItems)
+		if isAmbiguous {
+			var rowErr dto.ErrorResponseDto
+			rowErr.SetError(status_code.IMS108)
+			i.reject(row.Number, &rowErr, "row matches more than one item on its unique identifiers")
+			continue
+		}
+
+		if existingItem != nil {
+			replacement := ReplacementItem(row.Item, i.caller, i.now)
+			CoerceDocumentIntegers(i.inventoryConfiguration.JsonSchema, replacement)
+			replacedItem := ApplySetFields(PreservedItemFields(existingItem), replacement)
+			if validationErr := ValidateDocument(i.inventoryConfiguration.JsonSchema, replacedItem); validationErr != nil {
+				i.reject(row.Number, validationErr, "")
+				continue
+			}
+			writes = append(writes, commonDto.ItemWrite{Filter: bson.M{constants.ItemIdField: existingItem[constants.ItemIdField]}, Item: replacement})
+			revisions = append(revisions, NewItemRevision(commonDto.ItemRevisionReplace, existingItem, replacedItem))
+		} else {
+			id, errDto := i.newItemId(ctx)
+			if errDto != nil {
+				return errDto
+			}
+			stampedItem := StampNewItem(row.Item, id, i.caller, i.now)
+			CoerceDocumentIntegers(i.inventoryConfiguration.JsonSchema, stampedItem)
+			if validationErr := ValidateDocument(i.inventoryConfiguration.JsonSchema, stampedItem); validationErr != nil {
+				i.reject(row.Number, validationErr, "")
+				continue
+			}
+			writes = append(writes, commonDto.ItemWrite{Item: stampedItem})
+			revisions = append(revisions, NewItemRevision(commonDto.ItemRevisionCreate, nil, stampedItem))
+		}
+		writeRows = append(writeRows, row.Number)
+	}
+
+	if i.isDryRun {
+		i.report.ValidCount += len(writes)
+		return nil
+	}
+	if len(writes) == 0 {
+		return nil
+	}
+	itemErrors, errDto := i.service.InventoryRepository.BulkWriteInventory(ctx, i.inventoryName, writes)
+	if errDto != nil {
+		log.Error("Inside "+methodName+" error occurred when trying to write import batch into: ", i.inventoryName)
+		return errDto
+	}
+	var writtenRevisions []commonDto.ItemRevision
+	for position, write := range writes {
+		if itemErr, isFailed := itemErrors[position]; isFailed {
+			i.reject(writeRows[position], itemErr, "")
+			continue
+		}
+		i.report.ValidCount++
+		if write.Filter == nil {
+			i.report.InsertedCount++
+		} else {
+			i.report.ReplacedCount++
+		}
+		writtenRevisions = append(writtenRevisions, revisions[position])
+	}
+	i.service.recordItemRevisions(ctx, i.inventoryName, writtenRevisions...)
+	return nil
+}
+
+// existingItems : live items having the values of the rows on the match fields, by the identifier text of each of their values
+func (i *itemImport) existingItems(ctx context.Context, batch []*importer.Row) (map[string]bson.M, *dto.ErrorResponseDto) {
+	methodName := "existingItems"
+	log := logger.GetLogger()
+	existingItems := map[string]bson.M{}
+	conditions := bson.A{}
+	for _, fields := range i.matchFields {
+		if len(fields) == 1 {
+			values := bson.A{}
+			for _, row := range batch {
+				if value, hasValue := ItemFieldValue(row.Item, fields[0]); hasValue && value != nil {
+					values = append(values, value)
+				}
+			}
+			if len(values) > 0 {
+				conditions = append(conditions, bson.M{fields[0]: bson.M{"$in": values}})
+			}
+			continue
+		}
+		for _, row := range batch {
+			if filter, isComplete := identifierFilter(row.Item, fields); isComplete {
+				conditions = append(conditions, filter)
+			}
+		}
+	}
+	if len(conditions) == 0 {
+		return existingItems, nil
+	}
+	query := bson.M{"$or": conditions}
+	if len(conditions) == 1 {
+		query, _ = conditions[0].(bson.M)
+	}
+	items, _, errDto := i.service.InventoryRepository.FetchInventoryList(ctx, "", "", i.inventoryName, query, commonDto.Pagination{}, nil)
+	if errDto != nil && errDto.StatusCode != dto.GetStatusDetails(status_code.IMS404).StatusCode {
+		log.Error("Inside "+methodName+" error while fetching items to replace in: ", i.inventoryName)
+		return nil, errDto
+	}
+	for _, item := range items {
+		for _, fields := range i.matchFields {
+			if keyText, hasKey := identifierText(item, fields); hasKey {
+				existingItems[keyText] = item
+			}
+		}
+	}
+	return existingItems, nil
+}
+
+// matchingItem : live item the row replaces, true when the row matches different items on different identifiers
+func (i *itemImport) matchingItem(row *importer.Row, existingItems map[string]bson.M) (bson.M, bool) {
+	var matchingItem bson.M
+	for _, fields := range i.matchFields {
+		keyText, hasKey := identifierText(row.Item, fields)
+		if !hasKey {
+			continue
+		}
+		item, isMatch := existingItems[keyText]
+		if !isMatch {
+			continue
+		}
+		if matchingItem != nil && matchingItem[constants.ItemIdField] != item[constants.ItemIdField] {
+			return nil, true
+		}
+		matchingItem = item
+	}
+	return matchingItem, false
+}
+
+// identifierText : text of the values of the identifier fields in the item, like code C1, false when the item lacks one of them
+func identifierText(item map[string]interface{}, fields []string) (string, bool) {
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		value, exists := ItemFieldValue(item, field)
+		if !exists || value == nil {
+			return "", false
+		}
+		parts = append(parts, field+" "+fmt.Sprint(value))
+	}
+	return strings.Join(parts, ", "), true
+}
+
+// newItemId : id of a created item. Sequences are not consumed in a dry run, the item is validated with the first id of the sequence
+func (i *itemImport) newItemId(ctx context.Context) (string, *dto.ErrorResponseDto) {
+	if i.isDryRun && i.inventoryConfiguration.IdStrategy == request_dto.IdStrategySequence {
+		return i.inventoryConfiguration.IdPrefix + "1", nil
+	}
+	return i.service.newItemId(ctx, i.inventoryName, i.inventoryConfiguration.IdStrategy, i.inventoryConfiguration.IdPrefix)
+}
+
+// reject : records the rejected row, the reason defaults to the error message. Only the first MaxImportRejections rows are
+// kept, rows are rejected roughly in file order so the list is trimmed once it is twice as long
+func (i *itemImport) reject(rowNumber int, rowErr *dto.ErrorResponseDto, reason string) {
+	if reason == "" {
+		reason = rowErr.Message
+	} else {
+		reason = rowErr.Message + " : " + reason
+	}
+	i.report.RejectedCount++
+	i.report.Rejected = append(i.report.Rejected, commonDto.ImportRejection{Row: rowNumber, StatusCode: rowErr.StatusCode, Reason: reason})
+	if len(i.report.Rejected) > 2*constants.MaxImportRejections {
+		sort.SliceStable(i.report.Rejected, func(a, b int) bool {
+			return i.report.Rejected[a].Row < i.report.Rejected[b].Row
+		})
+		i.report.Rejected = i.report.Rejected[:constants.MaxImportRejections]
+	}
+}
@@ -11,7 +11,12 @@ import (
 	inventoryServiceDto "inventory-system/inventory-service/internal/common/dto"
 	"inventory-system/inventory-service/internal/common/dto/request_dto"
 	"inventory-system/inventory-service/internal/common/dto/response_dto"
+	"inventory-system/inventory-service/internal/common/schema"
 	"inventory-system/inventory-service/internal/common/status_code"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 type InventoryConfigurationService struct {
@@ -27,26 +32,79 @@ func NewInventoryConfigurationService(inventoryConfigurationRepository repositor
 	return s
 }
 
-func (c InventoryConfigurationService) CreateNewConfiguration(ctx context.Context, inventoryConfiguration request_dto.CreateNewConfigurationRequestBody) *dto.ErrorResponseDto {
+// CreateNewConfiguration : saves the configuration and creates its collection, returns the schema keywords mongo cannot enforce
+func (c InventoryConfigurationService) CreateNewConfiguration(ctx context.Context, inventoryConfiguration request_dto.CreateNewConfigurationRequestBody) ([]string, *dto.ErrorResponseDto) {
 	methodName := "AddNewInventory"
 	log := logger.GetLogger()
 
+	//Translate standard json schema drafts into the $jsonSchema dialect used by the collection validator
+	translatedSchema, translateErr := TranslateJsonSchema(inventoryConfiguration.JsonSchema, inventoryConfiguration.SchemaDialect)
+	if translateErr != nil {
+		log.Error("Inside "+methodName+" invalid json schema for: "+inventoryConfiguration.InventoryName+" : ", translateErr.Message)
+		return nil, translateErr
+	}
+	inventoryConfiguration.SchemaDialect = translatedSchema.SchemaDialect
+	inventoryConfiguration.SourceJsonSchema = translatedSchema.SourceJsonSchema
+	inventoryConfiguration.JsonSchema = translatedSchema.JsonSchema
+	inventoryConfiguration.UnenforcedKeywords = translatedSchema.UnenforcedKeywords
+
+	//Nothing is persisted unless the schema and identifiers are valid
+	schemaErr := ValidateConfigurationSchema(inventoryConfiguration.JsonSchema, inventoryConfiguration.InventoryIdentifiers)
+	if schemaErr != nil {
+		log.Error("Inside "+methodName+" invalid configuration schema for: "+inventoryConfiguration.InventoryName+" : ", schemaErr.Message)
+		return nil, schemaErr
+	}
+
+	paginationErr := ValidatePaginationSettings(inventoryConfiguration.JsonSchema, inventoryConfiguration.PaginationSettings)
+	if paginationErr != nil {
+		log.Error("Inside "+methodName+" invalid pagination settings for: "+inventoryConfiguration.InventoryName+" : ", paginationErr.Message)
+		return nil, paginationErr
+	}
+	searchErr := ValidateSearchableFields(inventoryConfiguration.JsonSchema, inventoryConfiguration.InventoryIdentifiers, inventoryConfiguration.SearchableFields)
+	if searchErr != nil {
+		log.Error("Inside "+methodName+" invalid searchable fields for: "+inventoryConfiguration.InventoryName+" : ", searchErr.Message)
+		return nil, searchErr
+	}
+
 	errorDto := c.InventoryConfigurationRepository.CreateNewConfiguration(ctx, inventoryConfiguration)
 	if errorDto != nil {
 		log.Error("Inside " + methodName + " error occurred when trying to create new base configuration: " + inventoryConfiguration.InventoryName)
-		return errorDto
+		return nil, errorDto
 	}
 	log.Info("Inside " + methodName + "successfully created base configuration: " + inventoryConfiguration.InventoryName)
 
-	createCollectionError := c.MongoStorageManagerClient.CreateCollection(context.Background(), inventoryConfiguration.InventoryName, inventoryConfiguration.JsonSchema, inventoryConfiguration.InventoryIdentifiers)
+	createCollectionError := c.MongoStorageManagerClient.CreateCollection(context.Background(), inventoryConfiguration.InventoryName, inventoryConfiguration.JsonSchema, IndexedIdentifiers(inventoryConfiguration.InventoryIdentifiers, inventoryConfiguration.SearchableFields))
 	if createCollectionError != nil {
 		log.Error("Inside " + methodName + " error occurred when trying to create base configuration collection: " + constants.InventoryCollectionNamePrefix + inventoryConfiguration.InventoryName)
-		return createCollectionError
+		//IMS102 means the collection was not created, it may hold the data of another configuration and must not be dropped
+		isCollectionCreated := createCollectionError.StatusCode != dto.GetStatusDetails(status_code.IMS102).StatusCode
+		c.rollbackNewConfiguration(inventoryConfiguration.InventoryName, isCollectionCreated)
+		return nil, createCollectionError
 	}
 	log.Info("Inside " + methodName + " successfully created new base configuration collection: " + constants.InventoryCollectionNamePrefix + inventoryConfiguration.InventoryName)
 
-	return nil
+	return inventoryConfiguration.UnenforcedKeywords, nil
+}
 
+// rollbackNewConfiguration : undoes a partially created configuration, the collection is only dropped when this configuration
+// created it. The original error is returned to the caller either way
+func (c InventoryConfigurationService) rollbackNewConfiguration(inventoryName string, isCollectionCreated bool) {
+	methodName := "rollbackNewConfiguration"
+	log := logger.GetLogger()
+	ctx := context.Background()
+
+	if isCollectionCreated {
+		dropErr := c.MongoStorageManagerClient.DropCollection(ctx, inventoryName)
+		if dropErr != nil {
+			log.Error("Inside "+methodName+" unable to drop collection while rolling back: ", constants.InventoryCollectionNamePrefix+inventoryName)
+		}
+	}
+	removeErr := c.InventoryConfigurationRepository.RemoveInventoryConfigurationByName(ctx, inventoryName)
+	if removeErr != nil {
+		log.Error("Inside "+methodName+" unable to remove configuration while rolling back: ", inventoryName)
+		return
+	}
+	log.Info("Inside "+methodName+" rolled back inventory configuration: ", inventoryName)
 }
 
 func (c InventoryConfigurationService) GetInventoryConfiguration(ctx context.Context, inventoryName string) (*response_dto.InventoryConfigurationResponseDto, *dto.ErrorResponseDto) {
@@ -72,7 +130,7 @@ func (c InventoryConfigurationService) GetInventoryConfiguration(ctx context.Con
 
 }
 
-func (c InventoryConfigurationService) GetAllInventoryConfiguration(ctx context.Context) ([]response_dto.InventoryConfigurationResponseDto, *dto.ErrorResponseDto) {
+func (c InventoryConfigurationService) GetAllInventoryConfiguration(ctx context.Context, includeDeleted bool) ([]response_dto.InventoryConfigurationResponseDto, *dto.ErrorResponseDto) {
 	methodName := "GetAllInventoryConfiguration"
 	log := logger.GetLogger()
 
@@ -82,11 +140,11 @@ func (c InventoryConfigurationService) GetAllInventoryConfiguration(ctx context.
 		log.Error("Inside " + methodName + " error while fetching all base configuration")
 	}
 
-	//Remove deleted base configurations
+	//Remove deleted base configurations unless asked for
 	var activeInventoryConfigurations []response_dto.InventoryConfigurationResponseDto
 	for _, inventoryConfiguration := range inventoryConfigurations {
 		isDeletedErr := IsInventoryConfigurationDeleted(inventoryConfiguration)
-		if isDeletedErr != nil {
+		if isDeletedErr != nil && !includeDeleted {
 			continue
 		} else {
 			configurationResponse, typeErr := utils.TypeConverter[response_dto.InventoryConfigurationResponseDto](inventoryConfiguration)
@@ -114,6 +172,451 @@ func (c InventoryConfigurationService) DeleteInventoryConfiguration(ctx context.
 	return err
 }
 
+func (c InventoryConfigurationService) RestoreInventoryConfiguration(ctx context.Context, inventoryName string) *dto.ErrorResponseDto {
+	methodName := "RestoreInventoryConfiguration"
+	log := logger.GetLogger()
+
+	log.Info("Inside "+methodName+" restoring inventory configuration for :", inventoryName)
+	err := c.InventoryConfigurationRepository.RestoreInventoryConfigurationByName(ctx, inventoryName)
+	if err != nil {
+		log.Error("Inside "+methodName+" error while restoring inventory configuration for :", inventoryName)
+	}
+	return err
+}
+
+// PurgeInventoryConfiguration : drops the collection of a soft deleted configuration and removes the configuration, the confirmation token must repeat the inventory name
+func (c InventoryConfigurationService) PurgeInventoryConfiguration(ctx context.Context, inventoryName string, confirmationToken string) *dto.ErrorResponseDto {
+	methodName := "PurgeInventoryConfiguration"
+	log := logger.GetLogger()
+	var domainErr dto.ErrorResponseDto
+
+	log.Info("Inside "+methodName+" purging inventory configuration for :", inventoryName)
+
+	if confirmationToken != inventoryName {
+		log.Error("Inside "+methodName+" confirmation token mismatch for :", inventoryName)
+		domainErr.SetError(status_code.IMS128)
+		return &domainErr
+	}
+
+	inventoryConfiguration, err := c.InventoryConfigurationRepository.FetchInventoryConfigurationByName(ctx, inventoryName)
+	if err != nil {
+		log.Error("Inside "+methodName+" error while fetching inventory configuration for :", inventoryName)
+		return err
+	}
+	if IsInventoryConfigurationDeleted(*inventoryConfiguration) == nil {
+		log.Error("Inside "+methodName+" inventory configuration must be deleted before purge :", inventoryName)
+		domainErr.SetError(status_code.IMS127)
+		return &domainErr
+	}
+
+	err = c.MongoStorageManagerClient.DropCollection(ctx, inventoryName)
+	if err != nil {
+		log.Error("Inside " + methodName + " error occurred when trying to drop collection: " + constants.InventoryCollectionNamePrefix + inventoryName)
+		return err
+	}
+
+	err = c.InventoryConfigurationRepository.PurgeInventoryConfigurationByName(ctx, inventoryName)
+	if err != nil {
+		log.Error("Inside "+methodName+" error while purging inventory configuration for :", inventoryName)
+		return err
+	}
+	log.Info("Inside "+methodName+" successfully purged inventory configuration: ", inventoryName)
+	return nil
+}
+
+// UpdateInventoryConfiguration : migrates the inventory collection to the new schema and identifiers and records a new revision
+func (c InventoryConfigurationService) UpdateInventoryConfiguration(ctx context.Context, inventoryName string, updateConfiguration request_dto.UpdateConfigurationRequestBody) (*response_dto.InventoryConfigurationResponseDto, *dto.ErrorResponseDto) {
+	methodName := "UpdateInventoryConfiguration"
+	log := logger.GetLogger()
+
+	log.Info("Inside "+methodName+" updating inventory configuration for :", inventoryName)
+
+	inventoryConfiguration, err := c.InventoryConfigurationRepository.FetchInventoryConfigurationByName(ctx, inventoryName)
+	if err != nil {
+		log.Error("Inside "+methodName+" error while fetching inventory configuration for :", inventoryName)
+		return nil, err
+	}
+	isDeletedErr := IsInventoryConfigurationDeleted(*inventoryConfiguration)
+	if isDeletedErr != nil {
+		return nil, isDeletedErr
+	}
+
+	if updateConfiguration.ValidationLevel == "" {
+		updateConfiguration.ValidationLevel = constants.DefaultValidationLevel
+	}
+	//Items keep the id strategy they were created with unless a new one is given
+	if updateConfiguration.IdStrategy == "" {
+		updateConfiguration.IdStrategy = inventoryConfiguration.IdStrategy
+		updateConfiguration.IdPrefix = inventoryConfiguration.IdPrefix
+	}
+
+	translatedSchema, translateErr := TranslateJsonSchema(updateConfiguration.JsonSchema, updateConfiguration.SchemaDialect)
+	if translateErr != nil {
+		log.Error("Inside "+methodName+" invalid json schema for: "+inventoryName+" : ", translateErr.Message)
+		return nil, translateErr
+	}
+	updateConfiguration.SchemaDialect = translatedSchema.SchemaDialect
+	updateConfiguration.SourceJsonSchema = translatedSchema.SourceJsonSchema
+	updateConfiguration.JsonSchema = translatedSchema.JsonSchema
+	updateConfiguration.UnenforcedKeywords = translatedSchema.UnenforcedKeywords
+
+	schemaErr := ValidateConfigurationSchema(updateConfiguration.JsonSchema, updateConfiguration.InventoryIdentifiers)
+	if schemaErr != nil {
+		log.Error("Inside "+methodName+" invalid configuration schema for: "+inventoryName+" : ", schemaErr.Message)
+		return nil, schemaErr
+	}
+
+	//Pagination is only changed when given
+	if updateConfiguration.Pagination == nil {
+		updateConfiguration.Pagination = &inventoryConfiguration.Pagination
+	}
+	if updateConfiguration.PaginationSettings == nil {
+		updateConfiguration.PaginationSettings = inventoryConfiguration.PaginationSettings
+	}
+	//Retention policy is only changed when given
+	if updateConfiguration.RetentionPolicy == nil {
+		updateConfiguration.RetentionPolicy = inventoryConfiguration.RetentionPolicy
+	}
+	//Searchable fields are only changed when given, an empty list turns search off
+	if updateConfiguration.SearchableFields == nil {
+		updateConfiguration.SearchableFields = inventoryConfiguration.SearchableFields
+	}
+	paginationErr := ValidatePaginationSettings(updateConfiguration.JsonSchema, updateConfiguration.PaginationSettings)
+	if paginationErr != nil {
+		log.Error("Inside "+methodName+" invalid pagination settings for: "+inventoryName+" : ", paginationErr.Message)
+		return nil, paginationErr
+	}
+	searchErr := ValidateSearchableFields(updateConfiguration.JsonSchema, updateConfiguration.InventoryIdentifiers, updateConfiguration.SearchableFields)
+	if searchErr != nil {
+		log.Error("Inside "+methodName+" invalid searchable fields for: "+inventoryName+" : ", searchErr.Message)
+		return nil, searchErr
+	}
+
+	updateCollectionError := c.MongoStorageManagerClient.UpdateCollection(ctx, inventoryName, updateConfiguration.JsonSchema, updateConfiguration.ValidationLevel, IndexedIdentifiers(updateConfiguration.InventoryIdentifiers, updateConfiguration.SearchableFields))
+	if updateCollectionError != nil {
+		log.Error("Inside " + methodName + " error occurred when trying to update collection: " + constants.InventoryCollectionNamePrefix + inventoryName)
+		//The migration may have stopped after dropping indexes, the collection is put back in line with the saved configuration
+		c.restoreCollection(inventoryName)
+		return nil, updateCollectionError
+	}
+
+	updatedConfiguration, err := c.InventoryConfigurationRepository.UpdateInventoryConfigurationByName(ctx, inventoryName, inventoryConfiguration.Version, updateConfiguration)
+	if err != nil {
+		log.Error("Inside "+methodName+" error while saving inventory configuration for :", inventoryName)
+		//The configuration was not saved (or was changed by a concurrent update), the collection must match the saved one
+		c.restoreCollection(inventoryName)
+		return nil, err
+	}
+
+	err = c.InventoryConfigurationRepository.CreateInventoryConfigurationRevision(ctx, *updatedConfiguration)
+	if err != nil {
+		log.Error("Inside "+methodName+" error while saving revision for :", inventoryName, " version: ", updatedConfiguration.Version)
+		return nil, err
+	}
+	log.Info("Inside "+methodName+" successfully updated inventory configuration: ", inventoryName, " to version: ", updatedConfiguration.Version)
+
+	configurationResponse, typeErr := utils.TypeConverter[response_dto.InventoryConfigurationResponseDto](updatedConfiguration)
+	if typeErr != nil {
+		var domainErr dto.ErrorResponseDto
+		domainErr.SetError(status_code.IMS500)
+		log.Error("Inside " + methodName + " error in type converter, json marshal/unmarshal failed: " + inventoryName)
+		return nil, &domainErr
+	}
+	return configurationResponse, nil
+}
+
+// restoreCollection : re-applies the validator and indexes of the saved configuration after a failed update. The saved
+// configuration is fetched again so that the migration of a concurrent update which won the version check is kept
+func (c InventoryConfigurationService) restoreCollection(inventoryName string) {
+	methodName := "restoreCollection"
+	log := logger.GetLogger()
+	ctx := context.Background()
+
+	inventoryConfiguration, err := c.InventoryConfigurationRepository.FetchInventoryConfigurationByName(ctx, inventoryName)
+	if err != nil {
+		log.Error("Inside "+methodName+" unable to fetch saved configuration, collection is not restored: ", constants.InventoryCollectionNamePrefix+inventoryName)
+		return
+	}
+	validationLevel := inventoryConfiguration.ValidationLevel
+	if validationLevel == "" {
+		validationLevel = constants.DefaultValidationLevel
+	}
+	restoreErr := c.MongoStorageManagerClient.UpdateCollection(ctx, inventoryName, inventoryConfiguration.JsonSchema, validationLevel, IndexedIdentifiers(inventoryConfiguration.InventoryIdentifiers, inventoryConfiguration.SearchableFields))
+	if restoreErr != nil {
+		log.Error("Inside "+methodName+" unable to restore collection to version ", inventoryConfiguration.Version, " : ", constants.InventoryCollectionNamePrefix+inventoryName)
+		return
+	}
+	log.Info("Inside "+methodName+" restored collection to version ", inventoryConfiguration.Version, " : ", constants.InventoryCollectionNamePrefix+inventoryName)
+}
+
+// ValidateInventoryConfiguration : dry run of a schema change, reports the documents and unique identifiers which would break
+func (c InventoryConfigurationService) ValidateInventoryConfiguration(ctx context.Context, inventoryName string, validateConfiguration request_dto.ValidateConfigurationRequestBody) (*inventoryServiceDto.SchemaValidationReport, *dto.ErrorResponseDto) {
+	methodName := "ValidateInventoryConfiguration"
+	log := logger.GetLogger()
+
+	log.Info("Inside "+methodName+" validating existing documents against candidate schema for :", inventoryName)
+
+	inventoryConfiguration, err := c.InventoryConfigurationRepository.FetchInventoryConfigurationByName(ctx, inventoryName)
+	if err != nil {
+		log.Error("Inside "+methodName+" error while fetching inventory configuration for :", inventoryName)
+		return nil, err
+	}
+	isDeletedErr := IsInventoryConfigurationDeleted(*inventoryConfiguration)
+	if isDeletedErr != nil {
+		return nil, isDeletedErr
+	}
+
+	translatedSchema, translateErr := TranslateJsonSchema(validateConfiguration.JsonSchema, validateConfiguration.SchemaDialect)
+	if translateErr != nil {
+		log.Error("Inside "+methodName+" invalid json schema for: "+inventoryName+" : ", translateErr.Message)
+		return nil, translateErr
+	}
+	schemaErr := ValidateConfigurationSchema(translatedSchema.JsonSchema, validateConfiguration.InventoryIdentifiers)
+	if schemaErr != nil {
+		log.Error("Inside "+methodName+" invalid configuration schema for: "+inventoryName+" : ", schemaErr.Message)
+		return nil, schemaErr
+	}
+
+	report, err := c.MongoStorageManagerClient.ValidateCollection(ctx, inventoryName, translatedSchema.JsonSchema, validateConfiguration.InventoryIdentifiers)
+	if err != nil {
+		log.Error("Inside " + methodName + " error occurred when trying to validate collection: " + constants.InventoryCollectionNamePrefix + inventoryName)
+		return nil, err
+	}
+	report.UnenforcedKeywords = translatedSchema.UnenforcedKeywords
+	log.Info("Inside "+methodName+" invalid documents: ", report.InvalidDocuments, " duplicate indexes: ", len(report.DuplicateIndexes), " for: ", inventoryName)
+	return report, nil
+}
+
+// ReconcileInventoryConfigurations : reports drift between the stored configurations and the inventory collections, apply repairs it through the storage manager.
+// Collections without a configuration are only reported, they are never dropped
+func (c InventoryConfigurationService) ReconcileInventoryConfigurations(ctx context.Context, apply bool) (*inventoryServiceDto.DriftReport, *dto.ErrorResponseDto) {
+	methodName := "ReconcileInventoryConfigurations"
+	log := logger.GetLogger()
+
+	log.Info("Inside "+methodName+" reconciling inventory configurations, apply: ", apply)
+
+	inventoryConfigurations, err := c.InventoryConfigurationRepository.FetchAllInventoryConfiguration(ctx)
+	if err != nil {
+		log.Error("Inside " + methodName + " error while fetching all inventory configurations")
+		return nil, err
+	}
+	inventoryCollections, err := c.MongoStorageManagerClient.ListInventoryCollections(ctx)
+	if err != nil {
+		log.Error("Inside " + methodName + " error while listing inventory collections")
+		return nil, err
+	}
+
+	report := inventoryServiceDto.DriftReport{
+		OrphanCollections:   []string{},
+		MissingCollections:  []string{},
+		InventoryDrifts:     []inventoryServiceDto.InventoryDrift{},
+		IsApplied:           apply,
+		RepairedInventories: []string{},
+		RepairFailures:      []string{},
+	}
+
+	existingCollections := make(map[string]bool, len(inventoryCollections))
+	for _, inventoryCollection := range inventoryCollections {
+		existingCollections[inventoryCollection] = true
+	}
+	addRepairResult := func(inventoryName string, repairErr *dto.ErrorResponseDto) {
+		if repairErr != nil {
+			log.Error("Inside "+methodName+" unable to repair collection for: "+inventoryName+" : ", repairErr.Message)
+			report.RepairFailures = append(report.RepairFailures, inventoryName+": "+repairErr.Message)
+			return
+		}
+		report.RepairedInventories = append(report.RepairedInventories, inventoryName)
+	}
+	configuredCollections := make(map[string]bool, len(inventoryConfigurations))
+	for _, inventoryConfiguration := range inventoryConfigurations {
+		configuredCollections[inventoryConfiguration.InventoryName] = true
+	}
+	for _, inventoryCollection := range inventoryCollections {
+		if !configuredCollections[inventoryCollection] {
+			report.OrphanCollections = append(report.OrphanCollections, inventoryCollection)
+		}
+	}
+
+	//Deleted configurations keep their collection until purged, so they only claim ownership of it
+	for _, inventoryConfiguration := range inventoryConfigurations {
+		if IsInventoryConfigurationDeleted(inventoryConfiguration) != nil {
+			continue
+		}
+		inventoryName := inventoryConfiguration.InventoryName
+
+		if !existingCollections[inventoryName] {
+			report.MissingCollections = append(report.MissingCollections, inventoryName)
+			if apply {
+				repairErr := c.MongoStorageManagerClient.CreateCollection(ctx, inventoryName, inventoryConfiguration.JsonSchema, IndexedIdentifiers(inventoryConfiguration.InventoryIdentifiers, inventoryConfiguration.SearchableFields))
+				addRepairResult(inventoryName, repairErr)
+			}
+			continue
+		}
+
+		drift, inspectErr := c.MongoStorageManagerClient.InspectCollection(ctx, inventoryName, inventoryConfiguration.JsonSchema, IndexedIdentifiers(inventoryConfiguration.InventoryIdentifiers, inventoryConfiguration.SearchableFields))
+		if inspectErr != nil {
+			log.Error("Inside "+methodName+" error while inspecting collection for :", inventoryName)
+			return nil, inspectErr
+		}
+		if !drift.HasDrift() {
+			continue
+		}
+		report.InventoryDrifts = append(report.InventoryDrifts, *drift)
+		if apply {
+			validationLevel := inventoryConfiguration.ValidationLevel
+			if validationLevel == "" {
+				validationLevel = constants.DefaultValidationLevel
+			}
+			repairErr := c.MongoStorageManagerClient.UpdateCollection(ctx, inventoryName, inventoryConfiguration.JsonSchema, validationLevel, IndexedIdentifiers(inventoryConfiguration.InventoryIdentifiers, inventoryConfiguration.SearchableFields))
+			addRepairResult(inventoryName, repairErr)
+		}
+	}
+
+	log.Info("Inside "+methodName+" orphan collections: ", len(report.OrphanCollections), " missing collections: ", len(report.MissingCollections), " drifted inventories: ", len(report.InventoryDrifts))
+	return &report, nil
+}
+
+// TranslatedJsonSchema : schema as submitted and as enforced by the collection validator
+type TranslatedJsonSchema struct {
+	SchemaDialect      string
+	SourceJsonSchema   bson.M
+	JsonSchema         bson.M
+	UnenforcedKeywords []string
+}
+
+// TranslateJsonSchema : translates draft-07 and 2020-12 schemas into a $jsonSchema validator, mongo dialect validators are kept as they are
+func TranslateJsonSchema(jsonSchema bson.M, requestedDialect string) (*TranslatedJsonSchema, *dto.ErrorResponseDto) {
+	var domainErr dto.ErrorResponseDto
+
+	//standard schemas may be submitted bare or already wrapped in $jsonSchema
+	sourceSchema, isWrapped := schema.ExtractJsonSchema(jsonSchema)
+	if !isWrapped {
+		sourceSchema = jsonSchema
+	}
+
+	dialect, err := schema.DetectDialect(sourceSchema, requestedDialect)
+	if err != nil {
+		domainErr.SetError(status_code.IMS125)
+		domainErr.Message = domainErr.Message + " : " + err.Error()
+		return nil, &domainErr
+	}
+	if dialect == schema.DialectMongo {
+		return &TranslatedJsonSchema{SchemaDialect: dialect, JsonSchema: jsonSchema}, nil
+	}
+
+	translated, unenforcedKeywords, err := schema.Translate(sourceSchema, dialect)
+	if err != nil {
+		domainErr.SetError(status_code.IMS125)
+		domainErr.Message = domainErr.Message + " : " + err.Error()
+		return nil, &domainErr
+	}
+	return &TranslatedJsonSchema{
+		SchemaDialect:      dialect,
+		SourceJsonSchema:   jsonSchema,
+		JsonSchema:         bson.M{"$jsonSchema": translated},
+		UnenforcedKeywords: unenforcedKeywords,
+	}, nil
+}
+
+// ValidateConfigurationSchema : checks the validator against the $jsonSchema keywords mongo accepts and that every identifier field is a declared property
+func ValidateConfigurationSchema(validator bson.M, inventoryIdentifiers []request_dto.InventoryIdentifier) *dto.ErrorResponseDto {
+	var domainErr dto.ErrorResponseDto
+
+	jsonSchema, hasJsonSchema := schema.ExtractJsonSchema(validator)
+	if !hasJsonSchema {
+		domainErr.SetError(status_code.IMS125)
+		domainErr.Message = domainErr.Message + " : $jsonSchema: must be an object"
+		return &domainErr
+	}
+	problems := schema.ValidateMongoSchema(jsonSchema)
+	if len(problems) > 0 {
+		domainErr.SetError(status_code.IMS125)
+		domainErr.Message = domainErr.Message + " : " + strings.Join(problems, ", ")
+		return &domainErr
+	}
+
+	var missingFields []string
+	for _, inventoryIdentifier := range inventoryIdentifiers {
+		for _, field := range inventoryIdentifier.Fields() {
+			if !schema.HasProperty(jsonSchema, field) {
+				missingFields = append(missingFields, field)
+			}
+		}
+	}
+	if len(missingFields) > 0 {
+		domainErr.SetError(status_code.IMS132)
+		domainErr.Message = domainErr.Message + " : " + strings.Join(missingFields, ", ")
+		return &domainErr
+	}
+	return nil
+}
+
+// ValidateSearchableFields : searchable fields must be properties of the schema. Their text index is the only one a
+// collection can have, so they cannot be combined with a text identifier
+func ValidateSearchableFields(validator bson.M, inventoryIdentifiers []request_dto.InventoryIdentifier, searchableFields []string) *dto.ErrorResponseDto {
+	if len(searchableFields) == 0 {
+		return nil
+	}
+	var problems []string
+	jsonSchema, _ := schema.ExtractJsonSchema(validator)
+	for _, field := range searchableFields {
+		if !schema.HasProperty(jsonSchema, field) {
+			problems = append(problems, field+" not present in json schema properties")
+		}
+	}
+	for _, inventoryIdentifier := range inventoryIdentifiers {
+		if inventoryIdentifier.IsText() {
+			problems = append(problems, inventoryIdentifier.Key+" is a text identifier, only one text index allowed")
+		}
+		if inventoryIdentifier.Key == constants.SearchIdentifierKey {
+			problems = append(problems, "identifier key "+constants.SearchIdentifierKey+" is reserved for the search index")
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	var domainErr dto.ErrorResponseDto
+	domainErr.SetError(status_code.IMS154)
+	domainErr.Message = domainErr.Message + " : " + strings.Join(problems, ", ")
+	return &domainErr
+}
+
+// IndexedIdentifiers : identifiers whose idx_ indexes the collection has, the search index included when fields are searchable
+func IndexedIdentifiers(inventoryIdentifiers []request_dto.InventoryIdentifier, searchableFields []string) []request_dto.InventoryIdentifier {
+	if len(searchableFields) == 0 {
+		return inventoryIdentifiers
+	}
+	return append(append([]request_dto.InventoryIdentifier{}, inventoryIdentifiers...), request_dto.SearchIdentifier(searchableFields))
+}
+
+// ValidatePaginationSettings : checks the page sizes are consistent and the default sort field is declared by the schema or managed by the service
+func ValidatePaginationSettings(validator bson.M, settings *request_dto.PaginationSettings) *dto.ErrorResponseDto {
+	if settings == nil {
+		return nil
+	}
+	var problems []string
+	maxPageSize := settings.MaxPageSize
+	if maxPageSize == 0 {
+		maxPageSize = constants.DefaultMaxPageSize
+	}
+	if settings.DefaultPageSize > maxPageSize {
+		problems = append(problems, "default_page_size exceeds max_page_size "+strconv.FormatInt(maxPageSize, 10))
+	}
+	if settings.DefaultSortField != "" && !isManagedItemField(settings.DefaultSortField) {
+		jsonSchema, _ := schema.ExtractJsonSchema(validator)
+		if !schema.HasProperty(jsonSchema, settings.DefaultSortField) {
+			problems = append(problems, "default_sort_field "+settings.DefaultSortField+" not present in json schema properties")
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	var domainErr dto.ErrorResponseDto
+	domainErr.SetError(status_code.IMS137)
+	domainErr.Message = domainErr.Message + " : " + strings.Join(problems, ", ")
+	return &domainErr
+}
+
 func IsInventoryConfigurationDeleted(inventoryConfiguration inventoryServiceDto.InventoryConfiguration) *dto.ErrorResponseDto {
 	methodName := "IsInventoryConfigurationDeleted"
 	log := logger.GetLogger()
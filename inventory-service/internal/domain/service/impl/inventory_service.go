@@ -2,17 +2,27 @@ package impl
 
 import (
 	"context"
+	"errors"
+	"inventory-system/common/pkg/constants"
 	"inventory-system/common/pkg/dto"
 	"inventory-system/common/pkg/logger"
 	"inventory-system/common/pkg/utils"
 	"inventory-system/inventory-service/internal/adapters/client"
 	"inventory-system/inventory-service/internal/adapters/models"
 	"inventory-system/inventory-service/internal/adapters/repository"
+	"inventory-system/inventory-service/internal/common/cursor"
 	commonDto "inventory-system/inventory-service/internal/common/dto"
 	"inventory-system/inventory-service/internal/common/dto/request_dto"
+	"inventory-system/inventory-service/internal/common/dto/response_dto"
+	"inventory-system/inventory-service/internal/common/filter"
+	"inventory-system/inventory-service/internal/common/projection"
+	"inventory-system/inventory-service/internal/common/schema"
 	"inventory-system/inventory-service/internal/common/status_code"
 	"inventory-system/inventory-service/internal/domain/service"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -53,47 +63,274 @@ func NewInventoryService(inventoryRepository repository.IInventoryRepository, in
 	}
 	return s
 }
-func (c InventoryService) CreateNewInventory(ctx context.Context, item interface{}, inventoryName string) *dto.ErrorResponseDto {
+
+// CreateNewInventory : stamps the item with a generated id and the server managed metadata, validates it and stores it, returns the stored item
+func (c InventoryService) CreateNewInventory(ctx context.Context, item interface{}, inventoryName string, caller string) (bson.M, *dto.ErrorResponseDto) {
 	methodName := "CreateNewInventory"
 	log := logger.GetLogger()
+	var domainErr dto.ErrorResponseDto
 
 	//check if inventory item deleted.
-	_, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, inventoryName)
+	inventoryConfiguration, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, inventoryName)
 	if errDto != nil {
 		if errDto.StatusCode == status_code.IMS204 {
 			log.Info("Inside "+methodName+" inventory item deleted :", inventoryName)
-			return errDto
+			return nil, errDto
 		}
 		log.Info("Inside "+methodName+" unable to fetch inventory item for inventoryName :", inventoryName)
-		return errDto
+		return nil, errDto
+	}
+
+	itemFields, isMap := utils.AsMap(item)
+	if !isMap {
+		log.Error("Inside "+methodName+" item is not an object for: ", inventoryName)
+		domainErr.SetError(status_code.IMS400)
+		return nil, &domainErr
+	}
+	id, errDto := c.newItemId(ctx, inventoryName, inventoryConfiguration.IdStrategy, inventoryConfiguration.IdPrefix)
+	if errDto != nil {
+		return nil, errDto
+	}
+	stampedItem := StampNewItem(itemFields, id, caller, time.Now())
+	CoerceDocumentIntegers(inventoryConfiguration.JsonSchema, stampedItem)
+
+	//Validate item against configuration schema before writing
+	validationErr := ValidateDocument(inventoryConfiguration.JsonSchema, stampedItem)
+	if validationErr != nil {
+		log.Error("Inside "+methodName+" item failed schema validation for "+inventoryName+" : ", validationErr.Message)
+		return nil, validationErr
 	}
 
 	//Create New item
-	errorDto := c.InventoryRepository.CreateNewInventoryGivenInventoryName(ctx, item, inventoryName)
+	errorDto := c.InventoryRepository.CreateNewInventoryGivenInventoryName(ctx, stampedItem, inventoryName)
 	if errorDto != nil {
-		log.Error("Inside "+methodName+" error occurred when trying to add new inventory: ", item)
-		return errorDto
+		log.Error("Inside "+methodName+" error occurred when trying to add new inventory: ", stampedItem)
+		return nil, errorDto
 	}
-	log.Info("Inside " + methodName + "successfully created new inventory inside " + inventoryName)
-	return nil
+	c.recordItemRevisions(ctx, inventoryName, NewItemRevision(commonDto.ItemRevisionCreate, nil, stampedItem))
+	log.Info("Inside " + methodName + "successfully created new inventory " + id + " inside " + inventoryName)
+	return stampedItem, nil
 }
 
-func (c InventoryService) GetInventory(ctx context.Context, inventoryName string, filterMap map[string][]string) (bson.M, *dto.ErrorResponseDto) {
-	methodName := "GetInventory"
+// BulkCreateInventory : stamps and validates every item like CreateNewInventory and writes the valid ones with one bulk write, the report holds the outcome of each item.
+// In ordered mode nothing after the first failing item is written
+func (c InventoryService) BulkCreateInventory(ctx context.Context, items []interface{}, inventoryName string, caller string, ordered bool) (*commonDto.BulkInsertReport, *dto.ErrorResponseDto) {
+	methodName := "BulkCreateInventory"
 	log := logger.GetLogger()
 	var domainErr dto.ErrorResponseDto
-	var InventoryIdentifier string
-	var InventoryIdentifierValue string
 
-	log.Info("Inside "+methodName+" getting inventory item for :", inventoryName)
-
-	//Check if only 1 query param exists.
-	if len(filterMap) > 1 {
-		log.Error("Inside "+methodName+" multiple filters not allowed :", inventoryName, " and filterMap : ", filterMap)
-		domainErr.SetError(status_code.IMS112)
+	if len(items) == 0 || len(items) > constants.MaxBulkInsertItems {
+		log.Error("Inside "+methodName+" invalid number of items for "+inventoryName+" : ", len(items))
+		domainErr.SetError(status_code.IMS139)
+		domainErr.Message = domainErr.Message + " : expected between 1 and " + strconv.Itoa(constants.MaxBulkInsertItems) + " items"
 		return nil, &domainErr
 	}
 
+	inventoryConfiguration, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, inventoryName)
+	if errDto != nil {
+		log.Info("Inside "+methodName+" unable to fetch inventory configuration for inventoryName :", inventoryName)
+		return nil, errDto
+	}
+
+	report := &commonDto.BulkInsertReport{Ordered: ordered}
+	var stampedItems []interface{}
+	var stampedItemIndexes []int
+	var stampedItemIds []string
+	var revisions []commonDto.ItemRevision
+	now := time.Now()
+	for index, item := range items {
+		itemFields, isMap := utils.AsMap(item)
+		if !isMap {
+			var itemErr dto.ErrorResponseDto
+			itemErr.SetError(status_code.IMS400)
+			report.Results = append(report.Results, newBulkInsertItemResult(index, "", itemErr))
+			if ordered {
+				break
+			}
+			continue
+		}
+		id, errDto := c.newItemId(ctx, inventoryName, inventoryConfiguration.IdStrategy, inventoryConfiguration.IdPrefix)
+		if errDto != nil {
+			return nil, errDto
+		}
+		stampedItem := StampNewItem(itemFields, id, caller, now)
+		CoerceDocumentIntegers(inventoryConfiguration.JsonSchema, stampedItem)
+		validationErr := ValidateDocument(inventoryConfiguration.JsonSchema, stampedItem)
+		if validationErr != nil {
+			report.Results = append(report.Results, newBulkInsertItemResult(index, "", *validationErr))
+			if ordered {
+				break
+			}
+			continue
+		}
+		stampedItems = append(stampedItems, stampedItem)
+		stampedItemIndexes = append(stampedItemIndexes, index)
+		stampedItemIds = append(stampedItemIds, id)
+	}
+
+	if len(stampedItems) > 0 {
+		itemErrors, errDto := c.InventoryRepository.BulkInsertInventory(ctx, stampedItems, inventoryName, ordered)
+		if errDto != nil {
+			log.Error("Inside "+methodName+" error occurred when trying to bulk insert into: ", inventoryName)
+			return nil, errDto
+		}
+		var inserted dto.ErrorResponseDto
+		inserted.SetError(status_code.IMS200)
+		for position, index := range stampedItemIndexes {
+			if itemErr, isFailed := itemErrors[position]; isFailed {
+				report.Results = append(report.Results, newBulkInsertItemResult(index, "", *itemErr))
+				//the items after the failure were never written and get no result or revision
+				if ordered {
+					break
+				}
+				continue
+			}
+			report.Results = append(report.Results, newBulkInsertItemResult(index, stampedItemIds[position], inserted))
+			revisions = append(revisions, NewItemRevision(commonDto.ItemRevisionCreate, nil, stampedItems[position].(bson.M)))
+		}
+		c.recordItemRevisions(ctx, inventoryName, revisions...)
+	}
+
+	sort.Slice(report.Results, func(i, j int) bool {
+		return report.Results[i].Index < report.Results[j].Index
+	})
+	for position, result := range report.Results {
+		if result.Id != "" {
+			report.InsertedCount++
+			continue
+		}
+		report.FailedCount++
+		//an ordered write never gets past its first failure
+		if ordered {
+			report.Results = report.Results[:position+1]
+			break
+		}
+	}
+	report.SkippedCount = len(items) - len(report.Results)
+	log.Info("Inside "+methodName+" inserted ", report.InsertedCount, " of ", len(items), " items into "+inventoryName)
+	return report, nil
+}
+
+// UpsertInventory : replaces the live item whose unique identifier key has the value given in the item, or creates it when there is none.
+// Returns the stored item and true when it was created
+func (c InventoryService) UpsertInventory(ctx context.Context, item interface{}, inventoryName string, key string, caller string) (bson.M, bool, *dto.ErrorResponseDto) {
+	methodName := "UpsertInventory"
+	log := logger.GetLogger()
+	var domainErr dto.ErrorResponseDto
+
+	inventoryConfiguration, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, inventoryName)
+	if errDto != nil {
+		log.Info("Inside "+methodName+" unable to fetch inventory configuration for inventoryName :", inventoryName)
+		return nil, false, errDto
+	}
+	if isManagedItemField(key) || !UniqueKeyExists(inventoryConfiguration.InventoryIdentifiers, key) {
+		log.Error("Inside "+methodName+" upsert key "+key+" is not a unique identifier of: ", inventoryName)
+		domainErr.SetError(status_code.IMS140)
+		domainErr.Message = domainErr.Message + " : " + key
+		return nil, false, &domainErr
+	}
+	itemFields, isMap := utils.AsMap(item)
+	if !isMap {
+		log.Error("Inside "+methodName+" item is not an object for: ", inventoryName)
+		domainErr.SetError(status_code.IMS400)
+		return nil, false, &domainErr
+	}
+	keyValue, hasKey := ItemFieldValue(itemFields, key)
+	if !hasKey || keyValue == nil {
+		log.Error("Inside "+methodName+" item has no value for upsert key "+key+" in: ", inventoryName)
+		domainErr.SetError(status_code.IMS400)
+		domainErr.Message = domainErr.Message + " : item has no value for " + key
+		return nil, false, &domainErr
+	}
+	uniqueFilter := bson.M{key: keyValue}
+
+	//an insert losing the race against another upsert of the same key is retried as a replace
+	for attempt := 0; ; attempt++ {
+		now := time.Now()
+		existingItem, errDto := c.InventoryRepository.FetchInventory(ctx, inventoryName, uniqueFilter, nil)
+		if errDto != nil && errDto.StatusCode != dto.GetStatusDetails(status_code.IMS404).StatusCode {
+			log.Error("Inside "+methodName+" error while fetching item for upsert in: ", inventoryName)
+			return nil, false, errDto
+		}
+
+		if existingItem != nil {
+			replacement := ReplacementItem(itemFields, caller, now)
+			CoerceDocumentIntegers(inventoryConfiguration.JsonSchema, replacement)
+			validationErr := ValidateDocument(inventoryConfiguration.JsonSchema, ApplySetFields(PreservedItemFields(existingItem), replacement))
+			if validationErr != nil {
+				log.Error("Inside "+methodName+" item failed schema validation for "+inventoryName+" : ", validationErr.Message)
+				return nil, false, validationErr
+			}
+			storedItem, errDto := c.InventoryRepository.ReplaceInventoryItem(ctx, inventoryName, uniqueFilter, replacement)
+			if errDto != nil {
+				log.Error("Inside "+methodName+" error occurred when trying to replace item in: ", inventoryName)
+				return nil, false, errDto
+			}
+			if storedItem != nil {
+				c.recordItemRevisions(ctx, inventoryName, NewItemRevision(commonDto.ItemRevisionReplace, existingItem, storedItem))
+				log.Info("Inside "+methodName+" replaced item for "+key+" in "+inventoryName+" : ", keyValue)
+				return storedItem, false, nil
+			}
+			//removed since it was fetched, fall through to create it
+		}
+
+		id, errDto := c.newItemId(ctx, inventoryName, inventoryConfiguration.IdStrategy, inventoryConfiguration.IdPrefix)
+		if errDto != nil {
+			return nil, false, errDto
+		}
+		stampedItem := StampNewItem(itemFields, id, caller, now)
+		CoerceDocumentIntegers(inventoryConfiguration.JsonSchema, stampedItem)
+		validationErr := ValidateDocument(inventoryConfiguration.JsonSchema, stampedItem)
+		if validationErr != nil {
+			log.Error("Inside "+methodName+" item failed schema validation for "+inventoryName+" : ", validationErr.Message)
+			return nil, false, validationErr
+		}
+		errDto = c.InventoryRepository.CreateNewInventoryGivenInventoryName(ctx, stampedItem, inventoryName)
+		if errDto == nil {
+			c.recordItemRevisions(ctx, inventoryName, NewItemRevision(commonDto.ItemRevisionCreate, nil, stampedItem))
+			log.Info("Inside " + methodName + " created item " + id + " inside " + inventoryName)
+			return stampedItem, true, nil
+		}
+		if attempt > 0 || errDto.StatusCode != dto.GetStatusDetails(status_code.IMS108).StatusCode {
+			log.Error("Inside "+methodName+" error occurred when trying to create item in: ", inventoryName)
+			return nil, false, errDto
+		}
+	}
+}
+
+// ItemFieldValue : value of a field of the item, dotted paths read nested objects
+func ItemFieldValue(item map[string]interface{}, field string) (interface{}, bool) {
+	path := strings.Split(field, ".")
+	current := item
+	for _, segment := range path[:len(path)-1] {
+		next, ok := utils.AsMap(current[segment])
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+	value, ok := current[path[len(path)-1]]
+	return value, ok
+}
+
+func newBulkInsertItemResult(index int, id string, status dto.ErrorResponseDto) commonDto.BulkInsertItemResult {
+	return commonDto.BulkInsertItemResult{
+		Index:      index,
+		Id:         id,
+		StatusCode: status.StatusCode,
+		Message:    status.Message,
+	}
+}
+
+// GetInventory : the live item matching a unique identifier, restricted to the requested fields or without the excluded ones.
+// id and version are always returned
+func (c InventoryService) GetInventory(ctx context.Context, inventoryName string, filterMap map[string][]string, fields string, exclude string) (bson.M, *dto.ErrorResponseDto) {
+	methodName := "GetInventory"
+	log := logger.GetLogger()
+	var domainErr dto.ErrorResponseDto
+
+	log.Info("Inside "+methodName+" getting inventory item for :", inventoryName)
+
 	//Fetching inventory item to check if not deleted.
 	inventoryConfiguration, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, inventoryName)
 	if errDto != nil {
@@ -105,19 +342,32 @@ func (c InventoryService) GetInventory(ctx context.Context, inventoryName string
 		return nil, errDto
 	}
 
-	//Checking if filter attribute exist in identifier list and is unique
+	//Checking if filter attributes form a unique identifier, multiple attributes only for compound identifiers
+	var filterKeys []string
+	uniqueFilter := bson.M{}
 	for key, value := range filterMap {
-		if UniqueKeyExists(inventoryConfiguration.InventoryIdentifiers, key) == false {
-			log.Error("Inside "+methodName+" filter attribute not present in unique inventoryIdentifiers :", inventoryName, " and filterMap : ", filterMap)
-			domainErr.SetError(status_code.IMS115)
-			return nil, &domainErr
-		}
-		InventoryIdentifier = key
-		InventoryIdentifierValue = value[0]
+		filterKeys = append(filterKeys, key)
+		uniqueFilter[key] = value[0]
+	}
+	if len(filterKeys) > 1 && !UniqueKeysExist(inventoryConfiguration.InventoryIdentifiers, filterKeys) {
+		log.Error("Inside "+methodName+" multiple filters not allowed :", inventoryName, " and filterMap : ", filterMap)
+		domainErr.SetError(status_code.IMS112)
+		return nil, &domainErr
+	}
+	if !UniqueKeysExist(inventoryConfiguration.InventoryIdentifiers, filterKeys) {
+		log.Error("Inside "+methodName+" filter attribute not present in unique inventoryIdentifiers :", inventoryName, " and filterMap : ", filterMap)
+		domainErr.SetError(status_code.IMS115)
+		return nil, &domainErr
+	}
+
+	itemProjection, projectionErr := ResolveProjection(fields, exclude, constants.ItemIdField, constants.ItemVersionField)
+	if projectionErr != nil {
+		log.Error("Inside "+methodName+" invalid projection for "+inventoryName+" : ", projectionErr.Message)
+		return nil, projectionErr
 	}
 
 	//Fetching item from inventory
-	item, adapterError := c.InventoryRepository.FetchInventory(ctx, inventoryName, InventoryIdentifier, InventoryIdentifierValue)
+	item, adapterError := c.InventoryRepository.FetchInventory(ctx, inventoryName, uniqueFilter, itemProjection)
 	if adapterError != nil {
 		log.Error("Inside "+methodName+" error while fetching item for :", inventoryName, " and filterMap : ", filterMap)
 		return nil, adapterError
@@ -125,10 +375,12 @@ func (c InventoryService) GetInventory(ctx context.Context, inventoryName string
 	return item, nil
 
 }
-func (c InventoryService) GetInventoryV2(ctx *gin.Context, from string, to string, inventoryName string, filterMap map[string][]string) ([]bson.M, *commonDto.PaginationResponse, *dto.ErrorResponseDto) {
+
+// GetInventoryV2 : page of the live items matching the filter document, see filter.Parse for its language. Filters are restricted
+// to the inventory identifiers and their values are coerced to the types of the inventory schema
+func (c InventoryService) GetInventoryV2(ctx *gin.Context, from string, to string, inventoryName string, filterDocument map[string]interface{}) ([]bson.M, *commonDto.PaginationResponse, *dto.ErrorResponseDto) {
 	methodName := "GetInventory"
 	log := logger.GetLogger()
-	var domainErr dto.ErrorResponseDto
 
 	log.Info("Inside "+methodName+" getting inventory item for :", inventoryName)
 
@@ -142,40 +394,47 @@ func (c InventoryService) GetInventoryV2(ctx *gin.Context, from string, to strin
 		log.Info("Inside "+methodName+" unable to fetch inventory item for inventoryName :", inventoryName)
 		return nil, nil, errDto
 	}
-	var pagination commonDto.Pagination
-	if inventoryConfiguration.Pagination {
-		pagination.Pagination = true
-		page := ctx.Query("page")
-		cPage, _ := strconv.ParseInt(page, 10, 64)
-
-		pagination.PageNumber = cPage
-
-		pageSize := ctx.Query("page_size")
-
-		cPageSize, _ := strconv.ParseInt(pageSize, 10, 64)
-		log.Info("cPageSize", cPageSize)
-		if cPageSize == 0 {
-			pagination.PageSize = 10
-		} else {
+	//Cursor pages are requested with a cursor parameter, empty for the first page
+	listConfiguration := *inventoryConfiguration
+	cursorToken, isCursor := ctx.GetQuery("cursor")
+	if isCursor {
+		listConfiguration.Pagination = true
+	}
+	pagination, paginationErr := ResolvePagination(listConfiguration, ctx.Query("page"), ctx.Query("page_size"))
+	if paginationErr == nil && ctx.Query("sort") != "" {
+		pagination.Sort, paginationErr = ResolveSort(*inventoryConfiguration, ctx.Query("sort"))
+	}
+	if paginationErr == nil && isCursor {
+		paginationErr = ResolveCursor(pagination, cursorToken, ctx.Query("page"))
+	}
+	if paginationErr != nil {
+		log.Error("Inside "+methodName+" invalid pagination request for "+inventoryName+" : ", paginationErr.Message)
+		return nil, nil, paginationErr
+	}
 
-			pagination.PageSize = cPageSize
+	//Cursors are made from the id and sort fields, so cursor pages always return them
+	requiredFields := []string{constants.ItemIdField}
+	if isCursor {
+		for _, key := range pagination.Order() {
+			requiredFields = append(requiredFields, key.Field)
 		}
 	}
+	itemProjection, projectionErr := ResolveProjection(ctx.Query("fields"), ctx.Query("exclude"), requiredFields...)
+	if projectionErr != nil {
+		log.Error("Inside "+methodName+" invalid projection for "+inventoryName+" : ", projectionErr.Message)
+		return nil, nil, projectionErr
+	}
 
-	//Checking if filter attribute exist in identifier list
-	for key, _ := range filterMap {
-		log.Info("InventoryIdentifiers", inventoryConfiguration.InventoryIdentifiers)
-		if !KeyExists(inventoryConfiguration.InventoryIdentifiers, key) {
-			log.Error("Inside "+methodName+" filter attribute not present in inventoryIdentifiers :", inventoryName, " and filterMap : ", filterMap)
-			domainErr.SetError(status_code.IMS113)
-			return nil, nil, &domainErr
-		}
+	query, filterErr := ParseFilter(*inventoryConfiguration, filterDocument)
+	if filterErr != nil {
+		log.Error("Inside "+methodName+" invalid filter for :", inventoryName, " : ", filterErr.Message)
+		return nil, nil, filterErr
 	}
 
 	//Fetching item from inventory
-	item, paginationData, adapterError := c.InventoryRepository.FetchInventoryList(ctx, from, to, inventoryName, filterMap, pagination)
+	item, paginationData, adapterError := c.InventoryRepository.FetchInventoryList(ctx, from, to, inventoryName, query, *pagination, itemProjection)
 	if adapterError != nil {
-		log.Error("Inside "+methodName+" error while fetching item for :", inventoryName, " and filterMap : ", filterMap)
+		log.Error("Inside "+methodName+" error while fetching item for :", inventoryName, " and filter : ", query)
 		return nil, nil, adapterError
 	}
 
@@ -183,7 +442,8 @@ func (c InventoryService) GetInventoryV2(ctx *gin.Context, from string, to strin
 
 }
 
-func (c InventoryService) RemoveItemFromInventory(ctx context.Context, RemoveInventoryItemRequest *request_dto.RemoveInventoryItem, InventoryName string) *dto.ErrorResponseDto {
+// RemoveItemFromInventory : soft deletes the live item recording who deleted it, when and the optional reason. Removing an item that is already deleted is a no-op
+func (c InventoryService) RemoveItemFromInventory(ctx context.Context, RemoveInventoryItemRequest *request_dto.RemoveInventoryItem, InventoryName string, caller string) *dto.ErrorResponseDto {
 	methodName := "RemoveItemFromInventory"
 	log := logger.GetLogger()
 	var domainErr dto.ErrorResponseDto
@@ -197,28 +457,112 @@ func (c InventoryService) RemoveItemFromInventory(ctx context.Context, RemoveInv
 		return &domainErr
 	}
 
-	RemoveModelError := c.InventoryRepository.RemoveItemFromInventory(ctx, RemoveInventoryItemModel, InventoryName)
+	now := time.Now()
+	removeFields := ItemUpdateMetadata(caller, now)
+	removeFields[constants.ItemDeletedAtField] = now
+	removeFields[constants.ItemDeletedByField] = caller
+	if RemoveInventoryItemRequest.Reason != "" {
+		removeFields[constants.ItemDeletionReasonField] = RemoveInventoryItemRequest.Reason
+	}
+	removedItem, RemoveModelError := c.InventoryRepository.RemoveItemFromInventory(ctx, RemoveInventoryItemModel, InventoryName, removeFields)
 
 	if RemoveModelError != nil {
 		log.Info("Error while removing Item from the inventory", RemoveModelError)
 		return RemoveModelError
 	}
+	if removedItem != nil {
+		removeFields[constants.ItemIsDeletedField] = true
+		c.recordItemRevisions(ctx, InventoryName, NewItemRevision(commonDto.ItemRevisionDelete, removedItem, nextItemState(removedItem, removeFields)))
+	}
 	return nil
 }
 
-func (c InventoryService) UpdateInventory(Id string, InventoryName string, UpdateRequest *interface{}) *dto.ErrorResponseDto {
+// UpdateInventory : applies the update of the given format to the item and returns its new version. When expectedVersion is given the item is only updated at that version.
+// Set updates are written with $set, JSON Patch and Merge Patch updates replace the stored item with the patched one
+func (c InventoryService) UpdateInventory(Id string, InventoryName string, UpdateRequest *interface{}, caller string, expectedVersion *int64, updateFormat string) (int64, *dto.ErrorResponseDto) {
 	log := logger.GetLogger()
 	methodName := "UpdateInventory Repository"
+	var domainErr dto.ErrorResponseDto
+	ctx := context.Background()
 
 	log.Info("Inside " + methodName)
 
 	log.Info("Update Request Body", UpdateRequest)
 
-	AdapterError := c.InventoryRepository.UpdateInventory(Id, InventoryName, UpdateRequest)
-	if AdapterError != nil {
-		return AdapterError
+	if UpdateRequest == nil {
+		domainErr.SetError(status_code.IMS400)
+		return 0, &domainErr
+	}
+	applyUpdate, errDto := newItemUpdate(*UpdateRequest, updateFormat)
+	if errDto != nil {
+		log.Error("Inside "+methodName+" invalid "+updateFormat+" update request for id : ", Id)
+		return 0, errDto
+	}
+
+	inventoryConfiguration, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, InventoryName)
+	if errDto != nil {
+		log.Info("Inside "+methodName+" unable to fetch inventory configuration for inventoryName :", InventoryName)
+		return 0, errDto
+	}
+	updateMetadata := ItemUpdateMetadata(caller, time.Now())
+
+	//The update only applies to the version that was validated, a concurrent write makes it retry unless the client asked for a version
+	retryCount := viper.GetInt(constants.MAX_OPTMISTIC_LOCKING_RETRY_COUNT)
+	for attempt := 0; ; attempt++ {
+		item, adapterError := c.InventoryRepository.FetchInventory(ctx, InventoryName, bson.M{"id": Id}, nil)
+		if adapterError != nil {
+			log.Error("Inside "+methodName+" error while fetching item : ", Id, " for ", InventoryName)
+			return 0, adapterError
+		}
+		currentVersion := item[constants.ItemVersionField]
+		if expectedVersion != nil && ItemVersion(item) != *expectedVersion {
+			log.Error("Inside "+methodName+" version conflict for item : ", Id, " expected ", *expectedVersion, " found ", ItemVersion(item))
+			return 0, NewVersionConflictError(*expectedVersion, ItemVersion(item))
+		}
+
+		updatedItem, errDto := applyUpdate(item)
+		if errDto != nil {
+			log.Error("Inside "+methodName+" unable to apply update to item : "+Id+" : ", errDto.Message)
+			return 0, errDto
+		}
+		changedFields := ProtectedFieldChanges(item, updatedItem, inventoryConfiguration.InventoryIdentifiers)
+		if len(changedFields) > 0 {
+			log.Error("Inside "+methodName+" update of item : "+Id+" modifies protected fields : ", changedFields)
+			return 0, newProtectedFieldError(changedFields)
+		}
+		for field, value := range updateMetadata {
+			updatedItem[field] = value
+		}
+		updatedItem[constants.ItemVersionField] = ItemVersion(item) + 1
+		CoerceDocumentIntegers(inventoryConfiguration.JsonSchema, updatedItem)
+		validationErr := ValidateDocument(inventoryConfiguration.JsonSchema, updatedItem)
+		if validationErr != nil {
+			log.Error("Inside "+methodName+" updated item failed schema validation for "+InventoryName+" : ", validationErr.Message)
+			return 0, validationErr
+		}
+
+		var AdapterError *dto.ErrorResponseDto
+		if updateFormat == request_dto.UpdateFormatJSONPatch || updateFormat == request_dto.UpdateFormatMergePatch {
+			AdapterError = c.InventoryRepository.ReplaceInventory(Id, InventoryName, updatedItem, currentVersion)
+		} else {
+			updateFields, _ := utils.AsMap(*UpdateRequest)
+			stampedFields := StripManagedFields(updateFields)
+			for field, value := range updateMetadata {
+				stampedFields[field] = value
+			}
+			CoerceDocumentIntegers(inventoryConfiguration.JsonSchema, stampedFields)
+			var stampedRequest interface{} = stampedFields
+			AdapterError = c.InventoryRepository.UpdateInventory(Id, InventoryName, &stampedRequest, currentVersion)
+		}
+		if AdapterError == nil {
+			c.recordItemRevisions(ctx, InventoryName, NewItemRevision(commonDto.ItemRevisionUpdate, item, updatedItem))
+			return ItemVersion(item) + 1, nil
+		}
+		if !IsVersionConflict(AdapterError) || expectedVersion != nil || attempt >= retryCount {
+			return 0, AdapterError
+		}
+		log.Info("Inside "+methodName+" item : "+Id+" changed concurrently, retrying attempt ", attempt+1)
 	}
-	return nil
 }
 
 func (c InventoryService) RemoveSubjectTopicsByLessonNameAndSubjectId(ctx context.Context, RemoveSubjectRequest *request_dto.RemoveSubjectRequest, Type string) *dto.ErrorResponseDto {
@@ -244,7 +588,7 @@ func (c InventoryService) RemoveSubjectTopicsByLessonNameAndSubjectId(ctx contex
 	return nil
 }
 
-func (c InventoryService) UpdateInventoryTopic(ctx context.Context, InventoryTopicUpdateRequest *request_dto.InventoryTopicUpdateRequest, TopicId string) *dto.ErrorResponseDto {
+func (c InventoryService) UpdateInventoryTopic(ctx context.Context, InventoryTopicUpdateRequest *request_dto.InventoryTopicUpdateRequest, TopicId string, caller string) *dto.ErrorResponseDto {
 	methodName := "UpdateInventoryTopic"
 	log := logger.GetLogger()
 	var domainErr dto.ErrorResponseDto
@@ -258,29 +602,160 @@ func (c InventoryService) UpdateInventoryTopic(ctx context.Context, InventoryTop
 		return &domainErr
 	}
 
-	UpdateInventoryTopicErr := c.InventoryRepository.UpdateInventoryTopic(ctx, InventoryTopicUpdateModel, TopicId)
+	inventoryConfiguration, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, constants.TopicsInventoryName)
+	if errDto != nil {
+		log.Info("Inside " + methodName + " unable to fetch inventory configuration for topics")
+		return errDto
+	}
 
-	if UpdateInventoryTopicErr != nil {
-		log.Info("Error while Updating Topic", UpdateInventoryTopicErr)
-		return UpdateInventoryTopicErr
+	updatedAt := time.Now()
+	InventoryTopicUpdateModel.UpdatedAt = updatedAt
+	InventoryTopicUpdateModel.UpdatedBy = caller
+	topicFields, typeConvertError := utils.TypeConverter[map[string]interface{}](InventoryTopicUpdateModel)
+	if typeConvertError != nil {
+		log.Info("Error while binding Inventory Topic Update Model", typeConvertError)
+		domainErr.SetError(status_code.IMS400)
+		return &domainErr
+	}
+	//json conversion turns the timestamp into a string, validate the date that is stored
+	(*topicFields)[constants.ItemUpdatedAtField] = updatedAt
+
+	//Validate the topic as it will look after the update, a concurrent write between the read and the update makes it retry
+	retryCount := viper.GetInt(constants.MAX_OPTMISTIC_LOCKING_RETRY_COUNT)
+	for attempt := 0; ; attempt++ {
+		topic, adapterError := c.InventoryRepository.FetchInventory(ctx, constants.TopicsInventoryName, bson.M{"id": TopicId}, nil)
+		if adapterError != nil {
+			log.Error("Inside "+methodName+" error while fetching topic : ", TopicId)
+			if adapterError.StatusCode == dto.GetStatusDetails(status_code.IMS404).StatusCode {
+				domainErr.SetError(status_code.IMS117)
+				return &domainErr
+			}
+			return adapterError
+		}
+		currentVersion := topic[constants.ItemVersionField]
+		updatedTopic := nextItemState(topic, *topicFields)
+		validationErr := ValidateDocument(inventoryConfiguration.JsonSchema, updatedTopic)
+		if validationErr != nil {
+			log.Error("Inside "+methodName+" updated topic failed schema validation : ", validationErr.Message)
+			return validationErr
+		}
+
+		UpdateInventoryTopicErr := c.InventoryRepository.UpdateInventoryTopic(ctx, InventoryTopicUpdateModel, TopicId, currentVersion)
+		if UpdateInventoryTopicErr == nil {
+			c.recordItemRevisions(ctx, constants.TopicsInventoryName, NewItemRevision(commonDto.ItemRevisionUpdate, topic, updatedTopic))
+			return nil
+		}
+		if !IsVersionConflict(UpdateInventoryTopicErr) || attempt >= retryCount {
+			log.Info("Error while Updating Topic", UpdateInventoryTopicErr)
+			return UpdateInventoryTopicErr
+		}
+		log.Info("Inside "+methodName+" topic : "+TopicId+" changed concurrently, retrying attempt ", attempt+1)
 	}
-	return nil
 }
 
-func (c InventoryService) ActivateResourceById(ctx context.Context, InventoryName string, Id string) *dto.ErrorResponseDto {
-	methodName := "UpdateInventoryTopic"
+// ActivateResourceById : restores a soft deleted item. IMS404 for unknown ids, IMS147 when the item is not deleted and IMS148 when
+// a live item already holds the value of one of its unique identifiers
+func (c InventoryService) ActivateResourceById(ctx context.Context, InventoryName string, Id string, caller string) *dto.ErrorResponseDto {
+	methodName := "ActivateResourceById"
 	log := logger.GetLogger()
+	var domainErr dto.ErrorResponseDto
 	log.Info("Inside " + methodName)
 
-	UpdateInventoryTopicErr := c.InventoryRepository.ActivateResourceById(ctx, InventoryName, Id)
+	inventoryConfiguration, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, InventoryName)
+	if errDto != nil {
+		log.Info("Inside "+methodName+" unable to fetch inventory configuration for inventoryName :", InventoryName)
+		return errDto
+	}
+	item, errDto := c.InventoryRepository.FetchInventoryById(ctx, InventoryName, Id)
+	if errDto != nil {
+		log.Error("Inside "+methodName+" unable to fetch item : "+Id+" of ", InventoryName)
+		return errDto
+	}
+	if isDeleted, _ := item[constants.ItemIsDeletedField].(bool); !isDeleted {
+		log.Info("Inside " + methodName + " item : " + Id + " of " + InventoryName + " is not deleted")
+		domainErr.SetError(status_code.IMS147)
+		return &domainErr
+	}
+	for _, identifier := range inventoryConfiguration.InventoryIdentifiers {
+		if !identifier.IsUnique || identifier.IsText() {
+			continue
+		}
+		uniqueFilter, isComplete := identifierFilter(item, identifier.Fields())
+		if !isComplete {
+			continue
+		}
+		_, fetchErr := c.InventoryRepository.FetchInventory(ctx, InventoryName, uniqueFilter, nil)
+		if fetchErr == nil {
+			log.Error("Inside "+methodName+" item : "+Id+" of "+InventoryName+" conflicts with a live item on ", identifier.Fields())
+			domainErr.SetError(status_code.IMS148)
+			domainErr.Message = domainErr.Message + " : " + strings.Join(identifier.Fields(), ", ")
+			return &domainErr
+		}
+		if fetchErr.StatusCode != dto.GetStatusDetails(status_code.IMS404).StatusCode {
+			log.Error("Inside "+methodName+" error while checking unique identifiers of item : "+Id+" for ", InventoryName)
+			return fetchErr
+		}
+	}
+
+	activateFields := ItemUpdateMetadata(caller, time.Now())
+	activatedItem, UpdateInventoryTopicErr := c.InventoryRepository.ActivateResourceById(ctx, InventoryName, Id, activateFields)
 
 	if UpdateInventoryTopicErr != nil {
 		log.Info("Error while Updating Topic", UpdateInventoryTopicErr)
 		return UpdateInventoryTopicErr
 	}
+	activateFields[constants.ItemIsDeletedField] = false
+	restoredItem := nextItemState(activatedItem, activateFields)
+	delete(restoredItem, constants.ItemDeletedAtField)
+	delete(restoredItem, constants.ItemDeletedByField)
+	delete(restoredItem, constants.ItemDeletionReasonField)
+	c.recordItemRevisions(ctx, InventoryName, NewItemRevision(commonDto.ItemRevisionRestore, activatedItem, restoredItem))
 	return nil
 }
 
+// identifierFilter : equality filter on the values of the fields in the item, false when the item lacks one of them
+func identifierFilter(item bson.M, fields []string) (bson.M, bool) {
+	filter := bson.M{}
+	for _, field := range fields {
+		value, exists := ItemFieldValue(item, field)
+		if !exists {
+			return nil, false
+		}
+		filter[field] = value
+	}
+	return filter, true
+}
+
+// GetInventoryTrash : page of the soft deleted items of the inventory, most recently deleted first. The trash is paginated
+// even when the inventory is not
+func (c InventoryService) GetInventoryTrash(ctx context.Context, inventoryName string, page string, pageSize string) ([]bson.M, *commonDto.PaginationResponse, *dto.ErrorResponseDto) {
+	methodName := "GetInventoryTrash"
+	log := logger.GetLogger()
+	log.Info("Inside "+methodName+" getting deleted items for :", inventoryName)
+
+	inventoryConfiguration, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, inventoryName)
+	if errDto != nil {
+		log.Info("Inside "+methodName+" unable to fetch inventory configuration for inventoryName :", inventoryName)
+		return nil, nil, errDto
+	}
+	trashConfiguration := *inventoryConfiguration
+	trashConfiguration.Pagination = true
+	pagination, paginationErr := ResolvePagination(trashConfiguration, page, pageSize)
+	if paginationErr != nil {
+		log.Error("Inside "+methodName+" invalid pagination request for "+inventoryName+" : ", paginationErr.Message)
+		return nil, nil, paginationErr
+	}
+	pagination.SortField = constants.ItemDeletedAtField
+	pagination.SortDirection = -1
+
+	items, paginationData, adapterError := c.InventoryRepository.FetchDeletedInventoryList(ctx, inventoryName, *pagination)
+	if adapterError != nil {
+		log.Error("Inside "+methodName+" error while fetching deleted items for :", inventoryName)
+		return nil, nil, adapterError
+	}
+	return items, paginationData, nil
+}
+
 func (c InventoryService) CreateResource(ctx context.Context, InventoryResourceCreate request_dto.InventoryResourceCreate, topicId string, contentType string, fileExtension string) (*string, *dto.ErrorResponseDto) {
 	methodName := "CreateResource"
 	log := logger.GetLogger()
@@ -309,26 +784,236 @@ func (c InventoryService) CreateResource(ctx context.Context, InventoryResourceC
 	ResourceModelObject.TopicName = InventoryResourceCreate.TopicName
 	ResourceModelObject.Id = uuid.NewString()
 
-	ResourceInterface, BindErr := utils.TypeConverter[interface{}](ResourceModelObject)
+	ResourceFields, BindErr := utils.TypeConverter[map[string]interface{}](ResourceModelObject)
 	if BindErr != nil {
 		log.Info("Error while binding Remove Inventory Subject Model", BindErr)
 		domainErr.SetError(status_code.IMS400)
 		return nil, &domainErr
 	}
+	ResourceItem := StampNewItem(*ResourceFields, ResourceModelObject.Id, InventoryResourceCreate.CreatedBy, time.Now())
 
-	UpdateInventoryTopicErr := c.InventoryRepository.CreateNewInventoryGivenInventoryName(ctx, ResourceInterface, "resources")
+	UpdateInventoryTopicErr := c.InventoryRepository.CreateNewInventoryGivenInventoryName(ctx, ResourceItem, "resources")
 	if UpdateInventoryTopicErr != nil {
 		log.Info("Error while Updating Topic", UpdateInventoryTopicErr)
 		return nil, UpdateInventoryTopicErr
 	}
+	c.recordItemRevisions(ctx, "resources", NewItemRevision(commonDto.ItemRevisionCreate, nil, ResourceItem))
 	log.Info("Update Err", UpdateInventoryTopicErr)
 
 	return &fileUrl, nil
 }
 
+// ResolvePagination : applies the pagination settings of the configuration to the requested page, pages beyond the configured limits are rejected.
+// page and page_size are ignored when the configuration does not paginate
+func ResolvePagination(inventoryConfiguration response_dto.InventoryConfigurationResponseDto, page string, pageSize string) (*commonDto.Pagination, *dto.ErrorResponseDto) {
+	var domainErr dto.ErrorResponseDto
+	settings := request_dto.PaginationSettings{}
+	if inventoryConfiguration.PaginationSettings != nil {
+		settings = *inventoryConfiguration.PaginationSettings
+	}
+
+	pagination := commonDto.Pagination{
+		SortField:     settings.DefaultSortField,
+		SortDirection: -1,
+	}
+	if pagination.SortField == "" {
+		pagination.SortField = constants.DefaultSortField
+	}
+	if settings.DefaultSortDirection == request_dto.SortDirectionAscending || (settings.DefaultSortDirection == "" && constants.DefaultSortDirection == request_dto.SortDirectionAscending) {
+		pagination.SortDirection = 1
+	}
+	if !inventoryConfiguration.Pagination {
+		return &pagination, nil
+	}
+	pagination.Pagination = true
+
+	maxPageSize := settings.MaxPageSize
+	if maxPageSize == 0 {
+		maxPageSize = constants.DefaultMaxPageSize
+	}
+	pagination.PageSize = settings.DefaultPageSize
+	if pagination.PageSize == 0 {
+		pagination.PageSize = constants.DefaultPageSize
+	}
+	if pagination.PageSize > maxPageSize {
+		pagination.PageSize = maxPageSize
+	}
+
+	if page != "" {
+		pageNumber, err := strconv.ParseInt(page, 10, 64)
+		if err != nil || pageNumber < 0 {
+			domainErr.SetError(status_code.IMS138)
+			domainErr.Message = domainErr.Message + " : page must be a non negative integer"
+			return nil, &domainErr
+		}
+		pagination.PageNumber = pageNumber
+	}
+	if pageSize != "" {
+		requestedPageSize, err := strconv.ParseInt(pageSize, 10, 64)
+		if err != nil || requestedPageSize < 1 {
+			domainErr.SetError(status_code.IMS138)
+			domainErr.Message = domainErr.Message + " : page_size must be a positive integer"
+			return nil, &domainErr
+		}
+		if requestedPageSize > maxPageSize {
+			domainErr.SetError(status_code.IMS138)
+			domainErr.Message = domainErr.Message + " : page_size exceeds the maximum of " + strconv.FormatInt(maxPageSize, 10)
+			return nil, &domainErr
+		}
+		pagination.PageSize = requestedPageSize
+	}
+	return &pagination, nil
+}
+
+// ParseFilter : mongo query of a filter document on the inventory identifiers, values are coerced to the schema types.
+// IMS113 when a field is not an identifier
+func ParseFilter(inventoryConfiguration response_dto.InventoryConfigurationResponseDto, filterDocument map[string]interface{}) (bson.M, *dto.ErrorResponseDto) {
+	var domainErr dto.ErrorResponseDto
+	jsonSchema, _ := schema.ExtractJsonSchema(inventoryConfiguration.JsonSchema)
+	query, err := filter.Parse(filterDocument, jsonSchema, func(field string) bool {
+		return KeyExists(inventoryConfiguration.InventoryIdentifiers, field)
+	})
+	if err != nil {
+		if errors.Is(err, filter.ErrFieldNotAllowed) {
+			domainErr.SetError(status_code.IMS113)
+			domainErr.Message = domainErr.Message + " : " + strings.TrimPrefix(err.Error(), filter.ErrFieldNotAllowed.Error()+": ")
+			return nil, &domainErr
+		}
+		domainErr.SetError(status_code.IMS151)
+		domainErr.Message = domainErr.Message + " : " + err.Error()
+		return nil, &domainErr
+	}
+	return query, nil
+}
+
+// ResolveSort : sort keys of a comma separated list of fields, descending when prefixed with -. Only indexed identifiers and
+// the default sort field of the inventory can be sorted on
+func ResolveSort(inventoryConfiguration response_dto.InventoryConfigurationResponseDto, sort string) ([]cursor.SortKey, *dto.ErrorResponseDto) {
+	var domainErr dto.ErrorResponseDto
+	defaultSortField := constants.DefaultSortField
+	if inventoryConfiguration.PaginationSettings != nil && inventoryConfiguration.PaginationSettings.DefaultSortField != "" {
+		defaultSortField = inventoryConfiguration.PaginationSettings.DefaultSortField
+	}
+
+	var sortKeys []cursor.SortKey
+	var sortFields []string
+	for _, field := range strings.Split(sort, ",") {
+		key := cursor.SortKey{Field: strings.TrimSpace(field), Direction: 1}
+		if strings.HasPrefix(key.Field, "-") {
+			key.Field, key.Direction = key.Field[1:], -1
+		}
+		switch {
+		case key.Field == "":
+			domainErr.SetError(status_code.IMS152)
+			domainErr.Message = domainErr.Message + " : empty sort field"
+			return nil, &domainErr
+		case utils.Contains(sortFields, key.Field):
+			domainErr.SetError(status_code.IMS152)
+			domainErr.Message = domainErr.Message + " : " + key.Field + " is sorted on twice"
+			return nil, &domainErr
+		case key.Field != defaultSortField && !KeyExists(inventoryConfiguration.InventoryIdentifiers, key.Field):
+			domainErr.SetError(status_code.IMS152)
+			domainErr.Message = domainErr.Message + " : " + key.Field + " is not an indexed identifier"
+			return nil, &domainErr
+		}
+		sortFields = append(sortFields, key.Field)
+		sortKeys = append(sortKeys, key)
+	}
+	return sortKeys, nil
+}
+
+// ResolveProjection : projection of the fields and exclude parameters, nil when neither is given
+func ResolveProjection(fields string, exclude string, requiredFields ...string) (bson.M, *dto.ErrorResponseDto) {
+	itemProjection, err := projection.Parse(fields, exclude, requiredFields...)
+	if err != nil {
+		var domainErr dto.ErrorResponseDto
+		domainErr.SetError(status_code.IMS153)
+		domainErr.Message = domainErr.Message + " : " + err.Error()
+		return nil, &domainErr
+	}
+	return itemProjection, nil
+}
+
+// ResolveCursor : switches the pagination to cursor mode positioned on the cursor token, an empty token reads the first page.
+// Cursors only continue the order they were made for
+func ResolveCursor(pagination *commonDto.Pagination, cursorToken string, page string) *dto.ErrorResponseDto {
+	var domainErr dto.ErrorResponseDto
+	if page != "" {
+		domainErr.SetError(status_code.IMS138)
+		domainErr.Message = domainErr.Message + " : page and cursor cannot be combined"
+		return &domainErr
+	}
+	pagination.IsCursor = true
+	if cursorToken == "" {
+		return nil
+	}
+	position, err := cursor.Decode(cursorToken)
+	if err != nil {
+		domainErr.SetError(status_code.IMS138)
+		domainErr.Message = domainErr.Message + " : " + err.Error()
+		return &domainErr
+	}
+	if !position.Follows(pagination.Order()) {
+		domainErr.SetError(status_code.IMS138)
+		domainErr.Message = domainErr.Message + " : cursor was made for a different sort order"
+		return &domainErr
+	}
+	pagination.Cursor = position
+	return nil
+}
+
+// ValidateDocument : validates the document against the $jsonSchema of the configuration validator, listing every failing field in the message
+func ValidateDocument(validator map[string]interface{}, document interface{}) *dto.ErrorResponseDto {
+	jsonSchema, hasJsonSchema := schema.ExtractJsonSchema(validator)
+	if !hasJsonSchema {
+		return nil
+	}
+	violations := schema.Validate(jsonSchema, document)
+	if len(violations) == 0 {
+		return nil
+	}
+	var domainErr dto.ErrorResponseDto
+	domainErr.SetError(status_code.IMS109)
+	domainErr.Message = domainErr.Message + " : " + schema.ViolationsMessage(violations)
+	return &domainErr
+}
+
+// CoerceDocumentIntegers : converts in place the numbers of the int and long properties of the document to the integer type
+// mongo expects for them, documents bound from json carry every number as float64
+func CoerceDocumentIntegers(validator map[string]interface{}, document map[string]interface{}) {
+	if jsonSchema, hasJsonSchema := schema.ExtractJsonSchema(validator); hasJsonSchema {
+		schema.CoerceIntegers(jsonSchema, document)
+	}
+}
+
+// ApplySetFields : applies $set style fields, including dotted paths, on top of the existing document
+func ApplySetFields(document bson.M, fields map[string]interface{}) bson.M {
+	if document == nil {
+		document = bson.M{}
+	}
+	for field, value := range fields {
+		path := strings.Split(field, ".")
+		current := map[string]interface{}(document)
+		for _, segment := range path[:len(path)-1] {
+			next, ok := utils.AsMap(current[segment])
+			if !ok {
+				next = map[string]interface{}{}
+				current[segment] = next
+			}
+			current = next
+		}
+		current[path[len(path)-1]] = value
+	}
+	return document
+}
+
+// KeyExists : true when the key is a field of any identifier usable for equality filters, text indexes are excluded
 func KeyExists(list []request_dto.InventoryIdentifier, keyToFind string) bool {
 	for _, item := range list {
-		if item.Key == keyToFind {
+		if item.IsText() {
+			continue
+		}
+		if utils.Contains(item.Fields(), keyToFind) {
 			return true
 		}
 	}
@@ -336,15 +1021,36 @@ func KeyExists(list []request_dto.InventoryIdentifier, keyToFind string) bool {
 }
 
 func UniqueKeyExists(list []request_dto.InventoryIdentifier, keyToFind string) bool {
+	return UniqueKeysExist(list, []string{keyToFind})
+}
+
+// UniqueKeysExist : true when the keys are exactly the fields of a unique identifier
+func UniqueKeysExist(list []request_dto.InventoryIdentifier, keysToFind []string) bool {
 	for _, item := range list {
-		if item.Key == keyToFind && item.IsUnique == true {
+		if !item.IsUnique || item.IsText() {
+			continue
+		}
+		fields := item.Fields()
+		if len(fields) != len(keysToFind) {
+			continue
+		}
+		matchesAll := true
+		for _, key := range keysToFind {
+			if !utils.Contains(fields, key) {
+				matchesAll = false
+				break
+			}
+		}
+		if matchesAll {
 			return true
 		}
 	}
 	return false
 }
 
-func (c InventoryService) GetInventoryFilter(ctx context.Context, InventoryName string, FilterName string, filters map[string][]string) ([]interface{}, *dto.ErrorResponseDto) {
+// GetInventoryFilter : distinct values of the filter identifier among the items matching the filters. When a page, a page size
+// or a value prefix is given the values of the live items are paged in ascending order, the pagination response is nil otherwise
+func (c InventoryService) GetInventoryFilter(ctx context.Context, InventoryName string, FilterName string, filters map[string][]string, prefix string, page string, pageSize string) ([]interface{}, *commonDto.PaginationResponse, *dto.ErrorResponseDto) {
 	methodName := "FilterName"
 	log := logger.GetLogger()
 	var domainErr dto.ErrorResponseDto
@@ -354,24 +1060,24 @@ func (c InventoryService) GetInventoryFilter(ctx context.Context, InventoryName
 	if errDto != nil {
 		if errDto.StatusCode == status_code.IMS204 {
 			log.Info("Inside "+methodName+" inventory item deleted :", InventoryName)
-			return nil, errDto
+			return nil, nil, errDto
 		}
 		log.Info("Inside "+methodName+" unable to fetch inventory item for InventoryName :", InventoryName)
-		return nil, errDto
+		return nil, nil, errDto
 	}
 
 	//Checking if filter attribute exist in identifier list
 	// log.Info("InventoryIdentifiers", inventoryConfiguration.InventoryIdentifiers, FilterName)
 	if !KeyExists(inventoryConfiguration.InventoryIdentifiers, FilterName) {
 		domainErr.SetError(status_code.IMS113)
-		return nil, &domainErr
+		return nil, nil, &domainErr
 	}
 
 	for key, _ := range filters {
 		if !KeyExists(inventoryConfiguration.InventoryIdentifiers, key) {
 			log.Error("Inside "+methodName+" filter attribute not present in inventoryIdentifiers :", InventoryName, " and filterMap : ", filters)
 			domainErr.SetError(status_code.IMS113)
-			return nil, &domainErr
+			return nil, nil, &domainErr
 		}
 	}
 
@@ -380,11 +1086,42 @@ func (c InventoryService) GetInventoryFilter(ctx context.Context, InventoryName
 		filtersBson[key] = bson.M{"$in": value}
 	}
 
-	item, adapterError := c.InventoryRepository.GetInventoryFilter(ctx, InventoryName, FilterName, filtersBson)
+	if prefix == "" && page == "" && pageSize == "" {
+		item, adapterError := c.InventoryRepository.GetInventoryFilter(ctx, InventoryName, FilterName, filtersBson)
+		if adapterError != nil {
+			log.Error("Inside "+methodName+" error while fetching item for :", InventoryName, " and filterMap : ", FilterName)
+			return nil, nil, adapterError
+		}
+		return item, nil, nil
+	}
+
+	//High cardinality identifiers are read a page of values at a time
+	valuesConfiguration := *inventoryConfiguration
+	valuesConfiguration.Pagination = true
+	pagination, paginationErr := ResolvePagination(valuesConfiguration, page, pageSize)
+	if paginationErr != nil {
+		log.Error("Inside "+methodName+" invalid pagination request for "+InventoryName+" : ", paginationErr.Message)
+		return nil, nil, paginationErr
+	}
+	results, adapterError := c.InventoryRepository.AggregateInventory(ctx, InventoryName, FilterValuesPipeline(FilterName, filtersBson, prefix, *pagination))
 	if adapterError != nil {
-		log.Error("Inside "+methodName+" error while fetching item for :", InventoryName, " and filterMap : ", FilterName)
-		return nil, adapterError
+		log.Error("Inside "+methodName+" error while fetching values of :", FilterName, " for : ", InventoryName)
+		return nil, nil, adapterError
 	}
-	return item, nil
+	values := []interface{}{}
+	paginationData := &commonDto.PaginationResponse{PageNumber: pagination.PageNumber, PageSize: pagination.PageSize}
+	if len(results) > 0 {
+		buckets, _ := utils.AsSlice(results[0]["values"])
+		for _, bucket := range buckets {
+			value, _ := utils.AsMap(bucket)
+			values = append(values, value["_id"])
+		}
+		totals, _ := utils.AsSlice(results[0]["total"])
+		if len(totals) > 0 {
+			total, _ := utils.AsMap(totals[0])
+			paginationData.Count = countValue(total[aggregateCountField])
+		}
+	}
+	return values, paginationData, nil
 
 }
@@ -0,0 +1,212 @@
+package impl
+
+import (
+	"context"
+	"inventory-system/common/pkg/constants"
+	"inventory-system/common/pkg/dto"
+	"inventory-system/common/pkg/logger"
+	commonDto "inventory-system/inventory-service/internal/common/dto"
+	"inventory-system/inventory-service/internal/common/patch"
+	"inventory-system/inventory-service/internal/common/status_code"
+	"strconv"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// NewItemRevision : revision recording the mutation of an item from before to after, before is nil for created items.
+// The actor and time of the revision are the updated_by and updated_at stamped on the stored item
+func NewItemRevision(operation string, before map[string]interface{}, after map[string]interface{}) commonDto.ItemRevision {
+	beforeSnapshot := itemSnapshot(before)
+	afterSnapshot := itemSnapshot(after)
+	revision := commonDto.ItemRevision{
+		Version:   ItemVersion(afterSnapshot),
+		Operation: operation,
+		Changes:   patch.Diff(beforeSnapshot, afterSnapshot),
+		Item:      afterSnapshot,
+		CreatedAt: time.Now(),
+	}
+	revision.ItemId, _ = afterSnapshot[constants.ItemIdField].(string)
+	revision.CreatedBy, _ = afterSnapshot[constants.ItemUpdatedByField].(string)
+	if updatedAt, ok := afterSnapshot[constants.ItemUpdatedAtField].(time.Time); ok {
+		revision.CreatedAt = updatedAt
+	}
+	return revision
+}
+
+// itemSnapshot : copy of the item without the mongo _id, an empty item for nil
+func itemSnapshot(item map[string]interface{}) bson.M {
+	if item == nil {
+		return bson.M{}
+	}
+	snapshot := bson.M(patch.Clone(item).(map[string]interface{}))
+	delete(snapshot, "_id")
+	return snapshot
+}
+
+// nextItemState : the item after $set of the fields and the version increment every write of the repository makes
+func nextItemState(item bson.M, setFields map[string]interface{}) bson.M {
+	nextItem := ApplySetFields(itemSnapshot(item), setFields)
+	nextItem[constants.ItemVersionField] = ItemVersion(item) + 1
+	return nextItem
+}
+
+// recordItemRevisions : appends the revisions to the history of the inventory. The mutations are already stored when this runs,
+// so a failure is logged instead of failing the request
+func (c InventoryService) recordItemRevisions(ctx context.Context, inventoryName string, revisions ...commonDto.ItemRevision) {
+	methodName := "recordItemRevisions"
+	log := logger.GetLogger()
+	if len(revisions) == 0 {
+		return
+	}
+	errDto := c.InventoryRepository.CreateItemRevisions(ctx, inventoryName, revisions)
+	if errDto != nil {
+		log.Error("Inside "+methodName+" unable to record ", len(revisions), " revisions for "+inventoryName+" : ", errDto.Message)
+	}
+}
+
+// ListItemRevisions : revisions of the item oldest first, without the item snapshots
+func (c InventoryService) ListItemRevisions(ctx context.Context, inventoryName string, id string) ([]commonDto.ItemRevision, *dto.ErrorResponseDto) {
+	methodName := "ListItemRevisions"
+	log := logger.GetLogger()
+	var domainErr dto.ErrorResponseDto
+
+	_, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, inventoryName)
+	if errDto != nil {
+		log.Info("Inside "+methodName+" unable to fetch inventory configuration for inventoryName :", inventoryName)
+		return nil, errDto
+	}
+	revisions, errDto := c.InventoryRepository.FetchItemRevisions(ctx, inventoryName, id)
+	if errDto != nil {
+		log.Error("Inside "+methodName+" error while fetching revisions of item : "+id+" for ", inventoryName)
+		return nil, errDto
+	}
+	if len(revisions) == 0 {
+		log.Info("Inside "+methodName+" no revisions recorded for item : "+id+" in ", inventoryName)
+		domainErr.SetError(status_code.IMS145)
+		return nil, &domainErr
+	}
+	return revisions, nil
+}
+
+// GetItemRevision : the revision of the item at the given version, its Item is the item as it was stored at that version
+func (c InventoryService) GetItemRevision(ctx context.Context, inventoryName string, id string, version int64) (*commonDto.ItemRevision, *dto.ErrorResponseDto) {
+	methodName := "GetItemRevision"
+	log := logger.GetLogger()
+
+	_, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, inventoryName)
+	if errDto != nil {
+		log.Info("Inside "+methodName+" unable to fetch inventory configuration for inventoryName :", inventoryName)
+		return nil, errDto
+	}
+	revision, errDto := c.InventoryRepository.FetchItemRevision(ctx, inventoryName, id, bson.M{"version": version})
+	if errDto != nil {
+		log.Error("Inside "+methodName+" unable to fetch revision ", version, " of item : "+id)
+		return nil, revisionError(errDto, version)
+	}
+	return revision, nil
+}
+
+// GetItemAsOf : the latest revision of the item recorded at or before asOf
+func (c InventoryService) GetItemAsOf(ctx context.Context, inventoryName string, id string, asOf time.Time) (*commonDto.ItemRevision, *dto.ErrorResponseDto) {
+	methodName := "GetItemAsOf"
+	log := logger.GetLogger()
+
+	_, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, inventoryName)
+	if errDto != nil {
+		log.Info("Inside "+methodName+" unable to fetch inventory configuration for inventoryName :", inventoryName)
+		return nil, errDto
+	}
+	return c.InventoryRepository.FetchItemRevision(ctx, inventoryName, id, bson.M{"created_at": bson.M{"$lte": asOf}})
+}
+
+// DiffItemRevisions : JSON Patch turning the item at fromVersion into the item at toVersion
+func (c InventoryService) DiffItemRevisions(ctx context.Context, inventoryName string, id string, fromVersion int64, toVersion int64) ([]patch.Operation, *dto.ErrorResponseDto) {
+	methodName := "DiffItemRevisions"
+	log := logger.GetLogger()
+
+	_, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, inventoryName)
+	if errDto != nil {
+		log.Info("Inside "+methodName+" unable to fetch inventory configuration for inventoryName :", inventoryName)
+		return nil, errDto
+	}
+	fromRevision, errDto := c.InventoryRepository.FetchItemRevision(ctx, inventoryName, id, bson.M{"version": fromVersion})
+	if errDto != nil {
+		log.Error("Inside "+methodName+" unable to fetch revision ", fromVersion, " of item : "+id)
+		return nil, revisionError(errDto, fromVersion)
+	}
+	toRevision, errDto := c.InventoryRepository.FetchItemRevision(ctx, inventoryName, id, bson.M{"version": toVersion})
+	if errDto != nil {
+		log.Error("Inside "+methodName+" unable to fetch revision ", toVersion, " of item : "+id)
+		return nil, revisionError(errDto, toVersion)
+	}
+	return patch.Diff(fromRevision.Item, toRevision.Item), nil
+}
+
+// RevertItem : replaces the live item with its content at the given version and records the revert as a new revision.
+// The id, creation stamps and deleted flag of the live item are kept, when expectedVersion is given the item is only reverted at that version
+func (c InventoryService) RevertItem(ctx context.Context, inventoryName string, id string, version int64, caller string, expectedVersion *int64) (bson.M, *dto.ErrorResponseDto) {
+	methodName := "RevertItem"
+	log := logger.GetLogger()
+
+	inventoryConfiguration, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, inventoryName)
+	if errDto != nil {
+		log.Info("Inside "+methodName+" unable to fetch inventory configuration for inventoryName :", inventoryName)
+		return nil, errDto
+	}
+	revision, errDto := c.InventoryRepository.FetchItemRevision(ctx, inventoryName, id, bson.M{"version": version})
+	if errDto != nil {
+		log.Error("Inside "+methodName+" unable to fetch revision ", version, " of item : "+id)
+		return nil, revisionError(errDto, version)
+	}
+
+	retryCount := viper.GetInt(constants.MAX_OPTMISTIC_LOCKING_RETRY_COUNT)
+	for attempt := 0; ; attempt++ {
+		item, errDto := c.InventoryRepository.FetchInventory(ctx, inventoryName, bson.M{"id": id}, nil)
+		if errDto != nil {
+			log.Error("Inside "+methodName+" error while fetching item : ", id, " for ", inventoryName)
+			return nil, errDto
+		}
+		currentVersion := item[constants.ItemVersionField]
+		if expectedVersion != nil && ItemVersion(item) != *expectedVersion {
+			log.Error("Inside "+methodName+" version conflict for item : ", id, " expected ", *expectedVersion, " found ", ItemVersion(item))
+			return nil, NewVersionConflictError(*expectedVersion, ItemVersion(item))
+		}
+
+		revertedItem := PreservedItemFields(item)
+		for field, value := range ReplacementItem(patch.Clone(revision.Item).(map[string]interface{}), caller, time.Now()) {
+			revertedItem[field] = value
+		}
+		revertedItem[constants.ItemIsDeletedField] = item[constants.ItemIsDeletedField]
+		validationErr := ValidateDocument(inventoryConfiguration.JsonSchema, revertedItem)
+		if validationErr != nil {
+			log.Error("Inside "+methodName+" reverted item failed schema validation for "+inventoryName+" : ", validationErr.Message)
+			return nil, validationErr
+		}
+
+		errDto = c.InventoryRepository.ReplaceInventory(id, inventoryName, revertedItem, currentVersion)
+		if errDto == nil {
+			revertRevision := NewItemRevision(commonDto.ItemRevisionRevert, item, revertedItem)
+			revertRevision.RevertedTo = version
+			c.recordItemRevisions(ctx, inventoryName, revertRevision)
+			log.Info("Inside "+methodName+" reverted item : "+id+" of "+inventoryName+" to version ", version)
+			return revertedItem, nil
+		}
+		if !IsVersionConflict(errDto) || expectedVersion != nil || attempt >= retryCount {
+			return nil, errDto
+		}
+		log.Info("Inside "+methodName+" item : "+id+" changed concurrently, retrying attempt ", attempt+1)
+	}
+}
+
+// revisionError : names the missing version when a revision is not found
+func revisionError(errDto *dto.ErrorResponseDto, version int64) *dto.ErrorResponseDto {
+	if errDto.StatusCode != dto.GetStatusDetails(status_code.IMS145).StatusCode {
+		return errDto
+	}
+	var domainErr dto.ErrorResponseDto
+	domainErr.SetError(status_code.IMS145)
+	domainErr.Message = domainErr.Message + " : version " + strconv.FormatInt(version, 10)
+	return &domainErr
+}
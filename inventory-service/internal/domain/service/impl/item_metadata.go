@@ -0,0 +1,147 @@
+package impl
+
+import (
+	"context"
+	"inventory-system/common/pkg/constants"
+	"inventory-system/common/pkg/dto"
+	"inventory-system/common/pkg/logger"
+	"inventory-system/inventory-service/internal/common/dto/request_dto"
+	"inventory-system/inventory-service/internal/common/identifier"
+	"inventory-system/inventory-service/internal/common/status_code"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// managedItemFields : fields owned by the service, client values for them are never written
+var managedItemFields = []string{
+	constants.ItemIdField,
+	constants.ItemCreatedAtField,
+	constants.ItemUpdatedAtField,
+	constants.ItemCreatedByField,
+	constants.ItemUpdatedByField,
+	constants.ItemVersionField,
+	constants.ItemIsDeletedField,
+	constants.ItemDeletedAtField,
+	constants.ItemDeletedByField,
+	constants.ItemDeletionReasonField,
+}
+
+// StampNewItem : returns a copy of the item with the server managed fields set, a new item is always stored as not deleted
+func StampNewItem(item map[string]interface{}, id string, caller string, now time.Time) bson.M {
+	stampedItem := StripManagedFields(item)
+	stampedItem[constants.ItemIdField] = id
+	stampedItem[constants.ItemCreatedAtField] = now
+	stampedItem[constants.ItemUpdatedAtField] = now
+	stampedItem[constants.ItemCreatedByField] = caller
+	stampedItem[constants.ItemUpdatedByField] = caller
+	stampedItem[constants.ItemVersionField] = int64(1)
+	stampedItem[constants.ItemIsDeletedField] = false
+	return stampedItem
+}
+
+// ItemUpdateMetadata : fields set on every update of an item, the version is incremented by the repository
+func ItemUpdateMetadata(caller string, now time.Time) bson.M {
+	return bson.M{
+		constants.ItemUpdatedAtField: now,
+		constants.ItemUpdatedByField: caller,
+	}
+}
+
+// ReplacementItem : the fields written when an item is replaced, the repository keeps the id and creation stamps of the stored item and increments its version
+func ReplacementItem(item map[string]interface{}, caller string, now time.Time) bson.M {
+	replacement := StripManagedFields(item)
+	for field, value := range ItemUpdateMetadata(caller, now) {
+		replacement[field] = value
+	}
+	replacement[constants.ItemIsDeletedField] = false
+	return replacement
+}
+
+// PreservedItemFields : the managed fields of a stored item that survive a replace, with the version it will have afterwards
+func PreservedItemFields(item bson.M) bson.M {
+	preservedFields := bson.M{}
+	for _, field := range []string{constants.ItemIdField, constants.ItemCreatedAtField, constants.ItemCreatedByField} {
+		if value, ok := item[field]; ok {
+			preservedFields[field] = value
+		}
+	}
+	preservedFields[constants.ItemVersionField] = ItemVersion(item) + 1
+	return preservedFields
+}
+
+// ItemVersion : version of a stored item, 0 for items written before versions were stamped
+func ItemVersion(item bson.M) int64 {
+	switch version := item[constants.ItemVersionField].(type) {
+	case int64:
+		return version
+	case int32:
+		return int64(version)
+	case float64:
+		return int64(version)
+	}
+	return 0
+}
+
+// NewVersionConflictError : error returned when an item is not at the version the caller expects
+func NewVersionConflictError(expectedVersion int64, currentVersion int64) *dto.ErrorResponseDto {
+	var domainErr dto.ErrorResponseDto
+	domainErr.SetError(status_code.IMS141)
+	domainErr.Message = domainErr.Message + " : expected version " + strconv.FormatInt(expectedVersion, 10) + ", current version " + strconv.FormatInt(currentVersion, 10)
+	return &domainErr
+}
+
+// IsVersionConflict : true when the repository did not write because the item changed since it was read
+func IsVersionConflict(errDto *dto.ErrorResponseDto) bool {
+	return errDto != nil && errDto.StatusCode == dto.GetStatusDetails(status_code.IMS141).StatusCode
+}
+
+// StripManagedFields : returns a copy of the fields without the server managed ones, dotted paths below them included
+func StripManagedFields(fields map[string]interface{}) bson.M {
+	strippedFields := bson.M{}
+	for field, value := range fields {
+		if isManagedItemField(field) {
+			continue
+		}
+		strippedFields[field] = value
+	}
+	return strippedFields
+}
+
+func isManagedItemField(field string) bool {
+	for _, managedField := range managedItemFields {
+		if field == managedField || strings.HasPrefix(field, managedField+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// newItemId : generates the id of a new item with the id strategy of the configuration, uuid when none is configured
+func (c InventoryService) newItemId(ctx context.Context, inventoryName string, idStrategy string, idPrefix string) (string, *dto.ErrorResponseDto) {
+	methodName := "newItemId"
+	log := logger.GetLogger()
+	var domainErr dto.ErrorResponseDto
+
+	switch idStrategy {
+	case request_dto.IdStrategyULID:
+		id, err := identifier.NewULID(time.Now())
+		if err != nil {
+			log.Error("Inside "+methodName+" error: "+err.Error()+" while generating ulid for: ", inventoryName)
+			domainErr.SetError(status_code.IMS136)
+			return "", &domainErr
+		}
+		return id, nil
+	case request_dto.IdStrategySequence:
+		sequence, err := c.InventoryRepository.NextInventorySequence(ctx, inventoryName)
+		if err != nil {
+			log.Error("Inside "+methodName+" error while generating sequence id for: ", inventoryName)
+			return "", err
+		}
+		return idPrefix + strconv.FormatInt(sequence, 10), nil
+	}
+	return uuid.NewString(), nil
+}
@@ -0,0 +1,109 @@
+package impl
+
+import (
+	"inventory-system/common/pkg/dto"
+	"inventory-system/common/pkg/utils"
+	"inventory-system/inventory-service/internal/common/dto/request_dto"
+	"inventory-system/inventory-service/internal/common/patch"
+	"inventory-system/inventory-service/internal/common/status_code"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// itemUpdate : returns the item as it looks after the update, the stored item is not modified
+type itemUpdate func(item bson.M) (map[string]interface{}, *dto.ErrorResponseDto)
+
+// newItemUpdate : parses the update request of the given format, set updates are objects of dotted paths, JSON Patch updates
+// are RFC 6902 operation lists and Merge Patch updates are RFC 7396 objects
+func newItemUpdate(updateRequest interface{}, updateFormat string) (itemUpdate, *dto.ErrorResponseDto) {
+	var domainErr dto.ErrorResponseDto
+	switch updateFormat {
+	case request_dto.UpdateFormatJSONPatch:
+		if _, isSlice := utils.AsSlice(updateRequest); !isSlice {
+			domainErr.SetError(status_code.IMS143)
+			domainErr.Message = domainErr.Message + " : json patch must be an array of operations"
+			return nil, &domainErr
+		}
+		operations, err := utils.TypeConverter[[]patch.Operation](updateRequest)
+		if err != nil {
+			domainErr.SetError(status_code.IMS143)
+			domainErr.Message = domainErr.Message + " : " + err.Error()
+			return nil, &domainErr
+		}
+		return func(item bson.M) (map[string]interface{}, *dto.ErrorResponseDto) {
+			patchedItem, err := patch.ApplyJSONPatch(item, *operations)
+			if err != nil {
+				var patchErr dto.ErrorResponseDto
+				patchErr.SetError(status_code.IMS143)
+				patchErr.Message = patchErr.Message + " : " + err.Error()
+				return nil, &patchErr
+			}
+			return patchedItem, nil
+		}, nil
+	case request_dto.UpdateFormatMergePatch:
+		mergePatch, isMap := utils.AsMap(updateRequest)
+		if !isMap {
+			domainErr.SetError(status_code.IMS143)
+			domainErr.Message = domainErr.Message + " : merge patch must be an object"
+			return nil, &domainErr
+		}
+		return func(item bson.M) (map[string]interface{}, *dto.ErrorResponseDto) {
+			return patch.ApplyMergePatch(item, mergePatch), nil
+		}, nil
+	}
+
+	updateFields, isMap := utils.AsMap(updateRequest)
+	if !isMap || len(updateFields) == 0 {
+		domainErr.SetError(status_code.IMS400)
+		domainErr.Message = domainErr.Message + " : update request must be an object with at least one field"
+		return nil, &domainErr
+	}
+	return func(item bson.M) (map[string]interface{}, *dto.ErrorResponseDto) {
+		//managed fields are protected like in the patch formats, they may only be given with their stored value
+		updatedItem := ApplySetFields(patch.Clone(item).(map[string]interface{}), updateFields)
+		if changedFields := ProtectedFieldChanges(item, updatedItem, nil); len(changedFields) > 0 {
+			return nil, newProtectedFieldError(changedFields)
+		}
+		if len(StripManagedFields(updateFields)) == 0 {
+			var emptyErr dto.ErrorResponseDto
+			emptyErr.SetError(status_code.IMS400)
+			emptyErr.Message = emptyErr.Message + " : update request must set at least one field which is not managed by the service"
+			return nil, &emptyErr
+		}
+		return updatedItem, nil
+	}, nil
+}
+
+// ProtectedFieldChanges : server managed and unique identifier fields whose value differs between the stored and the updated item
+func ProtectedFieldChanges(item bson.M, updatedItem map[string]interface{}, inventoryIdentifiers []request_dto.InventoryIdentifier) []string {
+	protectedFields := append([]string{}, managedItemFields...)
+	for _, identifier := range inventoryIdentifiers {
+		if identifier.IsUnique && !identifier.IsText() {
+			protectedFields = append(protectedFields, identifier.Fields()...)
+		}
+	}
+
+	var changedFields []string
+	isChecked := map[string]bool{}
+	for _, field := range protectedFields {
+		if isChecked[field] {
+			continue
+		}
+		isChecked[field] = true
+		storedValue, isStored := ItemFieldValue(item, field)
+		updatedValue, isUpdated := ItemFieldValue(updatedItem, field)
+		if isStored != isUpdated || !reflect.DeepEqual(patch.Clone(storedValue), patch.Clone(updatedValue)) {
+			changedFields = append(changedFields, field)
+		}
+	}
+	return changedFields
+}
+
+func newProtectedFieldError(changedFields []string) *dto.ErrorResponseDto {
+	var domainErr dto.ErrorResponseDto
+	domainErr.SetError(status_code.IMS142)
+	domainErr.Message = domainErr.Message + " : " + strings.Join(changedFields, ", ")
+	return &domainErr
+}
@@ -0,0 +1,99 @@
+package impl
+
+import (
+	"context"
+	"inventory-system/common/pkg/dto"
+	"inventory-system/common/pkg/logger"
+	commonDto "inventory-system/inventory-service/internal/common/dto"
+	"inventory-system/inventory-service/internal/common/dto/response_dto"
+	"inventory-system/inventory-service/internal/common/status_code"
+	"time"
+)
+
+// EnforceRetentionPolicies : applies the retention policy of every live inventory that has one. An inventory that fails is
+// reported and the others are still purged, on a dry run only the counts are reported
+func (c InventoryService) EnforceRetentionPolicies(ctx context.Context, dryRun bool) (*commonDto.RetentionReport, *dto.ErrorResponseDto) {
+	methodName := "EnforceRetentionPolicies"
+	log := logger.GetLogger()
+	log.Info("Inside "+methodName+" enforcing retention policies, dry run: ", dryRun)
+
+	inventoryConfigurations, errDto := c.InventoryConfigurationService.GetAllInventoryConfiguration(ctx, false)
+	if errDto != nil {
+		log.Error("Inside " + methodName + " error while fetching inventory configurations")
+		return nil, errDto
+	}
+
+	report := commonDto.RetentionReport{
+		IsDryRun:    dryRun,
+		Inventories: []commonDto.InventoryRetention{},
+		Failures:    []string{},
+	}
+	now := time.Now()
+	for _, inventoryConfiguration := range inventoryConfigurations {
+		if inventoryConfiguration.RetentionPolicy == nil {
+			continue
+		}
+		retention, retentionErr := c.applyRetentionPolicy(ctx, inventoryConfiguration, now, dryRun)
+		if retentionErr != nil {
+			report.Failures = append(report.Failures, inventoryConfiguration.InventoryName+": "+retentionErr.Message)
+		}
+		report.Inventories = append(report.Inventories, *retention)
+		report.PurgedItems += retention.PurgedItems
+		report.PurgedRevisions += retention.PurgedRevisions
+	}
+	log.Info("Inside "+methodName+" retention run finished, dry run: ", dryRun, " inventories: ", len(report.Inventories), " purged items: ", report.PurgedItems, " purged revisions: ", report.PurgedRevisions, " failures: ", len(report.Failures))
+	return &report, nil
+}
+
+// PurgeInventory : applies the retention policy of the inventory now, IMS149 when it has none
+func (c InventoryService) PurgeInventory(ctx context.Context, inventoryName string, dryRun bool) (*commonDto.InventoryRetention, *dto.ErrorResponseDto) {
+	methodName := "PurgeInventory"
+	log := logger.GetLogger()
+	var domainErr dto.ErrorResponseDto
+
+	inventoryConfiguration, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, inventoryName)
+	if errDto != nil {
+		log.Info("Inside "+methodName+" unable to fetch inventory configuration for inventoryName :", inventoryName)
+		return nil, errDto
+	}
+	if inventoryConfiguration.RetentionPolicy == nil {
+		log.Info("Inside "+methodName+" no retention policy configured for inventoryName :", inventoryName)
+		domainErr.SetError(status_code.IMS149)
+		return nil, &domainErr
+	}
+	retention, retentionErr := c.applyRetentionPolicy(ctx, *inventoryConfiguration, time.Now(), dryRun)
+	if retentionErr != nil {
+		return nil, retentionErr
+	}
+	return retention, nil
+}
+
+// applyRetentionPolicy : purges the deleted items and revisions the policy no longer keeps. The counts of what was purged
+// before a failure are returned along with the error
+func (c InventoryService) applyRetentionPolicy(ctx context.Context, inventoryConfiguration response_dto.InventoryConfigurationResponseDto, now time.Time, dryRun bool) (*commonDto.InventoryRetention, *dto.ErrorResponseDto) {
+	methodName := "applyRetentionPolicy"
+	log := logger.GetLogger()
+	inventoryName := inventoryConfiguration.InventoryName
+	policy := inventoryConfiguration.RetentionPolicy
+	retention := commonDto.InventoryRetention{InventoryName: inventoryName, IsDryRun: dryRun}
+
+	if policy.PurgeDeletedAfterDays > 0 {
+		deletedBefore := now.AddDate(0, 0, -int(policy.PurgeDeletedAfterDays))
+		purgedItems, errDto := c.InventoryRepository.PurgeDeletedItems(ctx, inventoryName, deletedBefore, dryRun)
+		if errDto != nil {
+			log.Error("Inside "+methodName+" unable to purge deleted items of "+inventoryName+" : ", errDto.Message)
+			return &retention, errDto
+		}
+		retention.PurgedItems = purgedItems
+	}
+	if policy.MaxRevisions > 0 {
+		purgedRevisions, errDto := c.InventoryRepository.PurgeItemRevisions(ctx, inventoryName, policy.MaxRevisions, dryRun)
+		retention.PurgedRevisions = purgedRevisions
+		if errDto != nil {
+			log.Error("Inside "+methodName+" unable to purge revisions of "+inventoryName+" : ", errDto.Message)
+			return &retention, errDto
+		}
+	}
+	log.Info("Inside "+methodName+" "+inventoryName+" dry run: ", dryRun, " purged items: ", retention.PurgedItems, " purged revisions: ", retention.PurgedRevisions)
+	return &retention, nil
+}
@@ -0,0 +1,100 @@
+package impl
+
+import (
+	"context"
+	"inventory-system/common/pkg/constants"
+	"inventory-system/common/pkg/dto"
+	"inventory-system/common/pkg/logger"
+	"inventory-system/common/pkg/utils"
+	commonDto "inventory-system/inventory-service/internal/common/dto"
+	"inventory-system/inventory-service/internal/common/dto/response_dto"
+	"inventory-system/inventory-service/internal/common/highlight"
+	"inventory-system/inventory-service/internal/common/status_code"
+	"strings"
+)
+
+// SearchInventory : page of the live items matching the text search, most relevant first, with highlighted snippets of the
+// searchable fields. Inventories without searchable fields are searched through their text identifier when they have one
+func (c InventoryService) SearchInventory(ctx context.Context, inventoryName string, search string, page string, pageSize string) ([]commonDto.SearchResult, *commonDto.PaginationResponse, *dto.ErrorResponseDto) {
+	methodName := "SearchInventory"
+	log := logger.GetLogger()
+	var domainErr dto.ErrorResponseDto
+	log.Info("Inside "+methodName+" searching items of :", inventoryName, " for : ", search)
+
+	inventoryConfiguration, errDto := c.InventoryConfigurationService.GetInventoryConfiguration(ctx, inventoryName)
+	if errDto != nil {
+		log.Info("Inside "+methodName+" unable to fetch inventory configuration for inventoryName :", inventoryName)
+		return nil, nil, errDto
+	}
+	searchableFields := SearchableFields(*inventoryConfiguration)
+	if len(searchableFields) == 0 {
+		log.Info("Inside "+methodName+" no searchable fields configured for inventoryName :", inventoryName)
+		domainErr.SetError(status_code.IMS155)
+		return nil, nil, &domainErr
+	}
+	search = strings.TrimSpace(search)
+	terms := highlight.Terms(search)
+	if len(terms) == 0 {
+		domainErr.SetError(status_code.IMS156)
+		domainErr.Message = domainErr.Message + " : q must contain a word to search for"
+		return nil, nil, &domainErr
+	}
+
+	searchConfiguration := *inventoryConfiguration
+	searchConfiguration.Pagination = true
+	pagination, paginationErr := ResolvePagination(searchConfiguration, page, pageSize)
+	if paginationErr != nil {
+		log.Error("Inside "+methodName+" invalid pagination request for "+inventoryName+" : ", paginationErr.Message)
+		return nil, nil, paginationErr
+	}
+
+	items, paginationData, adapterError := c.InventoryRepository.SearchInventory(ctx, inventoryName, search, *pagination)
+	if adapterError != nil {
+		log.Error("Inside "+methodName+" error while searching items of :", inventoryName)
+		return nil, nil, adapterError
+	}
+
+	results := make([]commonDto.SearchResult, 0, len(items))
+	for _, item := range items {
+		score, _ := item[constants.SearchScoreField].(float64)
+		delete(item, constants.SearchScoreField)
+		results = append(results, commonDto.SearchResult{Item: item, Score: score, Highlights: highlightFields(item, searchableFields, terms)})
+	}
+	return results, paginationData, nil
+}
+
+// SearchableFields : fields covered by the text index of the inventory, the searchable fields or else the text identifier fields
+func SearchableFields(inventoryConfiguration response_dto.InventoryConfigurationResponseDto) []string {
+	if len(inventoryConfiguration.SearchableFields) > 0 {
+		return inventoryConfiguration.SearchableFields
+	}
+	for _, inventoryIdentifier := range inventoryConfiguration.InventoryIdentifiers {
+		if inventoryIdentifier.IsText() {
+			return inventoryIdentifier.Fields()
+		}
+	}
+	return nil
+}
+
+// highlightFields : snippet of each searchable field matching a term, arrays of strings are highlighted on their first matching element
+func highlightFields(item map[string]interface{}, searchableFields []string, terms []string) map[string]string {
+	highlights := make(map[string]string)
+	for _, field := range searchableFields {
+		value, _ := ItemFieldValue(item, field)
+		texts, isList := utils.AsSlice(value)
+		if !isList {
+			texts = []interface{}{value}
+		}
+		for _, text := range texts {
+			textValue, isString := text.(string)
+			if !isString {
+				continue
+			}
+			if snippet, found := highlight.Snippet(textValue, terms); found {
+				highlights[field] = snippet
+				break
+			}
+		}
+	}
+	return highlights
+}
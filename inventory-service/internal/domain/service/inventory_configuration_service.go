@@ -3,14 +3,20 @@ package service
 import (
 	"context"
 	"inventory-system/common/pkg/dto"
+	commonDto "inventory-system/inventory-service/internal/common/dto"
 	"inventory-system/inventory-service/internal/common/dto/request_dto"
 	"inventory-system/inventory-service/internal/common/dto/response_dto"
 )
 
 //go:generate mockgen -destination=mocks/mock_inventory_configuration_service.go -package=mocks . IInventoryConfigurationService
 type IInventoryConfigurationService interface {
-	CreateNewConfiguration(ctx context.Context, configurationDto request_dto.CreateNewConfigurationRequestBody) *dto.ErrorResponseDto
+	CreateNewConfiguration(ctx context.Context, configurationDto request_dto.CreateNewConfigurationRequestBody) ([]string, *dto.ErrorResponseDto)
 	GetInventoryConfiguration(ctx context.Context, baseConfigurationName string) (*response_dto.InventoryConfigurationResponseDto, *dto.ErrorResponseDto)
 	DeleteInventoryConfiguration(ctx context.Context, baseConfigurationName string) *dto.ErrorResponseDto
-	GetAllInventoryConfiguration(ctx context.Context) ([]response_dto.InventoryConfigurationResponseDto, *dto.ErrorResponseDto)
+	GetAllInventoryConfiguration(ctx context.Context, includeDeleted bool) ([]response_dto.InventoryConfigurationResponseDto, *dto.ErrorResponseDto)
+	RestoreInventoryConfiguration(ctx context.Context, baseConfigurationName string) *dto.ErrorResponseDto
+	PurgeInventoryConfiguration(ctx context.Context, baseConfigurationName string, confirmationToken string) *dto.ErrorResponseDto
+	ValidateInventoryConfiguration(ctx context.Context, baseConfigurationName string, validateConfigurationDto request_dto.ValidateConfigurationRequestBody) (*commonDto.SchemaValidationReport, *dto.ErrorResponseDto)
+	UpdateInventoryConfiguration(ctx context.Context, baseConfigurationName string, updateConfigurationDto request_dto.UpdateConfigurationRequestBody) (*response_dto.InventoryConfigurationResponseDto, *dto.ErrorResponseDto)
+	ReconcileInventoryConfigurations(ctx context.Context, apply bool) (*commonDto.DriftReport, *dto.ErrorResponseDto)
 }
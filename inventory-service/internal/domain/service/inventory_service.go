@@ -5,6 +5,9 @@ import (
 	"inventory-system/common/pkg/dto"
 	commonDto "inventory-system/inventory-service/internal/common/dto"
 	"inventory-system/inventory-service/internal/common/dto/request_dto"
+	"inventory-system/inventory-service/internal/common/patch"
+	"io"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
@@ -13,14 +16,29 @@ import (
 //go:generate mockgen -destination=mocks/mock_inventory_service.go -package=mocks . IInventoryService
 
 type IInventoryService interface {
-	CreateNewInventory(ctx context.Context, configuration interface{}, baseConfigurationName string) *dto.ErrorResponseDto
-	GetInventory(ctx context.Context, baseConfigurationName string, filterAttribute map[string][]string) (bson.M, *dto.ErrorResponseDto)
-	GetInventoryV2(ctx *gin.Context, from string, to string, baseConfigurationName string, filterAttribute map[string][]string) ([]bson.M, *commonDto.PaginationResponse, *dto.ErrorResponseDto)
-	RemoveItemFromInventory(ctx context.Context, RemoveInventoryItemRequest *request_dto.RemoveInventoryItem, InventoryName string) *dto.ErrorResponseDto
+	CreateNewInventory(ctx context.Context, configuration interface{}, baseConfigurationName string, caller string) (bson.M, *dto.ErrorResponseDto)
+	BulkCreateInventory(ctx context.Context, items []interface{}, inventoryName string, caller string, ordered bool) (*commonDto.BulkInsertReport, *dto.ErrorResponseDto)
+	UpsertInventory(ctx context.Context, item interface{}, inventoryName string, key string, caller string) (bson.M, bool, *dto.ErrorResponseDto)
+	GetInventory(ctx context.Context, baseConfigurationName string, filterAttribute map[string][]string, fields string, exclude string) (bson.M, *dto.ErrorResponseDto)
+	GetInventoryV2(ctx *gin.Context, from string, to string, baseConfigurationName string, filterDocument map[string]interface{}) ([]bson.M, *commonDto.PaginationResponse, *dto.ErrorResponseDto)
+	RemoveItemFromInventory(ctx context.Context, RemoveInventoryItemRequest *request_dto.RemoveInventoryItem, InventoryName string, caller string) *dto.ErrorResponseDto
 	RemoveSubjectTopicsByLessonNameAndSubjectId(ctx context.Context, RemoveSubjectRequest *request_dto.RemoveSubjectRequest, Type string) *dto.ErrorResponseDto
-	UpdateInventoryTopic(ctx context.Context, InventoryTopicUpdateRequest *request_dto.InventoryTopicUpdateRequest, TopicId string) *dto.ErrorResponseDto
-	ActivateResourceById(ctx context.Context, InventoryName string, Id string) *dto.ErrorResponseDto
-	UpdateInventory(Id string, InventoryName string, UpdateRequest *interface{}) *dto.ErrorResponseDto
+	UpdateInventoryTopic(ctx context.Context, InventoryTopicUpdateRequest *request_dto.InventoryTopicUpdateRequest, TopicId string, caller string) *dto.ErrorResponseDto
+	ActivateResourceById(ctx context.Context, InventoryName string, Id string, caller string) *dto.ErrorResponseDto
+	GetInventoryTrash(ctx context.Context, inventoryName string, page string, pageSize string) ([]bson.M, *commonDto.PaginationResponse, *dto.ErrorResponseDto)
+	SearchInventory(ctx context.Context, inventoryName string, search string, page string, pageSize string) ([]commonDto.SearchResult, *commonDto.PaginationResponse, *dto.ErrorResponseDto)
+	AggregateInventory(ctx context.Context, inventoryName string, aggregateRequest request_dto.AggregateRequest) (*commonDto.AggregateResult, *dto.ErrorResponseDto)
+	ExportInventory(ctx context.Context, inventoryName string, exportRequest request_dto.ExportRequest, writer io.Writer) *dto.ErrorResponseDto
+	ImportInventory(ctx context.Context, inventoryName string, importRequest request_dto.ImportRequest, file io.ReadSeeker, caller string) (*commonDto.ImportReport, *dto.ErrorResponseDto)
+	PurgeInventory(ctx context.Context, inventoryName string, dryRun bool) (*commonDto.InventoryRetention, *dto.ErrorResponseDto)
+	EnforceRetentionPolicies(ctx context.Context, dryRun bool) (*commonDto.RetentionReport, *dto.ErrorResponseDto)
+	UpdateInventory(Id string, InventoryName string, UpdateRequest *interface{}, caller string, expectedVersion *int64, updateFormat string) (int64, *dto.ErrorResponseDto)
 	CreateResource(ctx context.Context, InventoryResourceCreate request_dto.InventoryResourceCreate, topicId string, contentType string, FileExtension string) (*string, *dto.ErrorResponseDto)
-	GetInventoryFilter(ctx context.Context, InventoryName string, FilterName string, filters map[string][]string) ([]interface{}, *dto.ErrorResponseDto)
+	GetInventoryFilter(ctx context.Context, InventoryName string, FilterName string, filters map[string][]string, prefix string, page string, pageSize string) ([]interface{}, *commonDto.PaginationResponse, *dto.ErrorResponseDto)
+	GetInventoryFacets(ctx context.Context, inventoryName string, facetRequest request_dto.FacetRequest) ([]commonDto.Facet, *dto.ErrorResponseDto)
+	ListItemRevisions(ctx context.Context, inventoryName string, id string) ([]commonDto.ItemRevision, *dto.ErrorResponseDto)
+	GetItemRevision(ctx context.Context, inventoryName string, id string, version int64) (*commonDto.ItemRevision, *dto.ErrorResponseDto)
+	GetItemAsOf(ctx context.Context, inventoryName string, id string, asOf time.Time) (*commonDto.ItemRevision, *dto.ErrorResponseDto)
+	DiffItemRevisions(ctx context.Context, inventoryName string, id string, fromVersion int64, toVersion int64) ([]patch.Operation, *dto.ErrorResponseDto)
+	RevertItem(ctx context.Context, inventoryName string, id string, version int64, caller string, expectedVersion *int64) (bson.M, *dto.ErrorResponseDto)
 }
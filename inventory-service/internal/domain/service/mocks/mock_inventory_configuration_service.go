@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	dto "inventory-system/common/pkg/dto"
+	dto0 "inventory-system/inventory-service/internal/common/dto"
 	request_dto "inventory-system/inventory-service/internal/common/dto/request_dto"
 	response_dto "inventory-system/inventory-service/internal/common/dto/response_dto"
 	reflect "reflect"
@@ -38,11 +39,12 @@ func (m *MockIInventoryConfigurationService) EXPECT() *MockIInventoryConfigurati
 }
 
 // CreateNewConfiguration mocks base method.
-func (m *MockIInventoryConfigurationService) CreateNewConfiguration(arg0 context.Context, arg1 request_dto.CreateNewConfigurationRequestBody) *dto.ErrorResponseDto {
+func (m *MockIInventoryConfigurationService) CreateNewConfiguration(arg0 context.Context, arg1 request_dto.CreateNewConfigurationRequestBody) ([]string, *dto.ErrorResponseDto) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateNewConfiguration", arg0, arg1)
-	ret0, _ := ret[0].(*dto.ErrorResponseDto)
-	return ret0
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
 }
 
 // CreateNewConfiguration indicates an expected call of CreateNewConfiguration.
@@ -66,18 +68,18 @@ func (mr *MockIInventoryConfigurationServiceMockRecorder) DeleteInventoryConfigu
 }
 
 // GetAllInventoryConfiguration mocks base method.
-func (m *MockIInventoryConfigurationService) GetAllInventoryConfiguration(arg0 context.Context) ([]response_dto.InventoryConfigurationResponseDto, *dto.ErrorResponseDto) {
+func (m *MockIInventoryConfigurationService) GetAllInventoryConfiguration(arg0 context.Context, arg1 bool) ([]response_dto.InventoryConfigurationResponseDto, *dto.ErrorResponseDto) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllInventoryConfiguration", arg0)
+	ret := m.ctrl.Call(m, "GetAllInventoryConfiguration", arg0, arg1)
 	ret0, _ := ret[0].([]response_dto.InventoryConfigurationResponseDto)
 	ret1, _ := ret[1].(*dto.ErrorResponseDto)
 	return ret0, ret1
 }
 
 // GetAllInventoryConfiguration indicates an expected call of GetAllInventoryConfiguration.
-func (mr *MockIInventoryConfigurationServiceMockRecorder) GetAllInventoryConfiguration(arg0 interface{}) *gomock.Call {
+func (mr *MockIInventoryConfigurationServiceMockRecorder) GetAllInventoryConfiguration(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllInventoryConfiguration", reflect.TypeOf((*MockIInventoryConfigurationService)(nil).GetAllInventoryConfiguration), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllInventoryConfiguration", reflect.TypeOf((*MockIInventoryConfigurationService)(nil).GetAllInventoryConfiguration), arg0, arg1)
 }
 
 // GetInventoryConfiguration mocks base method.
@@ -94,3 +96,76 @@ func (mr *MockIInventoryConfigurationServiceMockRecorder) GetInventoryConfigurat
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryConfiguration", reflect.TypeOf((*MockIInventoryConfigurationService)(nil).GetInventoryConfiguration), arg0, arg1)
 }
+
+// PurgeInventoryConfiguration mocks base method.
+func (m *MockIInventoryConfigurationService) PurgeInventoryConfiguration(arg0 context.Context, arg1, arg2 string) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeInventoryConfiguration", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// PurgeInventoryConfiguration indicates an expected call of PurgeInventoryConfiguration.
+func (mr *MockIInventoryConfigurationServiceMockRecorder) PurgeInventoryConfiguration(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeInventoryConfiguration", reflect.TypeOf((*MockIInventoryConfigurationService)(nil).PurgeInventoryConfiguration), arg0, arg1, arg2)
+}
+
+// ReconcileInventoryConfigurations mocks base method.
+func (m *MockIInventoryConfigurationService) ReconcileInventoryConfigurations(arg0 context.Context, arg1 bool) (*dto0.DriftReport, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileInventoryConfigurations", arg0, arg1)
+	ret0, _ := ret[0].(*dto0.DriftReport)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// ReconcileInventoryConfigurations indicates an expected call of ReconcileInventoryConfigurations.
+func (mr *MockIInventoryConfigurationServiceMockRecorder) ReconcileInventoryConfigurations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileInventoryConfigurations", reflect.TypeOf((*MockIInventoryConfigurationService)(nil).ReconcileInventoryConfigurations), arg0, arg1)
+}
+
+// RestoreInventoryConfiguration mocks base method.
+func (m *MockIInventoryConfigurationService) RestoreInventoryConfiguration(arg0 context.Context, arg1 string) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreInventoryConfiguration", arg0, arg1)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// RestoreInventoryConfiguration indicates an expected call of RestoreInventoryConfiguration.
+func (mr *MockIInventoryConfigurationServiceMockRecorder) RestoreInventoryConfiguration(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreInventoryConfiguration", reflect.TypeOf((*MockIInventoryConfigurationService)(nil).RestoreInventoryConfiguration), arg0, arg1)
+}
+
+// UpdateInventoryConfiguration mocks base method.
+func (m *MockIInventoryConfigurationService) UpdateInventoryConfiguration(arg0 context.Context, arg1 string, arg2 request_dto.UpdateConfigurationRequestBody) (*response_dto.InventoryConfigurationResponseDto, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInventoryConfiguration", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*response_dto.InventoryConfigurationResponseDto)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// UpdateInventoryConfiguration indicates an expected call of UpdateInventoryConfiguration.
+func (mr *MockIInventoryConfigurationServiceMockRecorder) UpdateInventoryConfiguration(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInventoryConfiguration", reflect.TypeOf((*MockIInventoryConfigurationService)(nil).UpdateInventoryConfiguration), arg0, arg1, arg2)
+}
+
+// ValidateInventoryConfiguration mocks base method.
+func (m *MockIInventoryConfigurationService) ValidateInventoryConfiguration(arg0 context.Context, arg1 string, arg2 request_dto.ValidateConfigurationRequestBody) (*dto0.SchemaValidationReport, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateInventoryConfiguration", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto0.SchemaValidationReport)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// ValidateInventoryConfiguration indicates an expected call of ValidateInventoryConfiguration.
+func (mr *MockIInventoryConfigurationServiceMockRecorder) ValidateInventoryConfiguration(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateInventoryConfiguration", reflect.TypeOf((*MockIInventoryConfigurationService)(nil).ValidateInventoryConfiguration), arg0, arg1, arg2)
+}
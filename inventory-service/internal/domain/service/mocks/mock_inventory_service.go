@@ -7,8 +7,14 @@ package mocks
 import (
 	context "context"
 	dto "inventory-system/common/pkg/dto"
+	dto0 "inventory-system/inventory-service/internal/common/dto"
+	request_dto "inventory-system/inventory-service/internal/common/dto/request_dto"
+	patch "inventory-system/inventory-service/internal/common/patch"
+	io "io"
 	reflect "reflect"
+	time "time"
 
+	gin "github.com/gin-gonic/gin"
 	gomock "github.com/golang/mock/gomock"
 	primitive "go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -36,31 +42,377 @@ func (m *MockIInventoryService) EXPECT() *MockIInventoryServiceMockRecorder {
 	return m.recorder
 }
 
-// CreateNewInventory mocks base method.
-func (m *MockIInventoryService) CreateNewInventory(arg0 context.Context, arg1 interface{}, arg2 string) *dto.ErrorResponseDto {
+// ActivateResourceById mocks base method.
+func (m *MockIInventoryService) ActivateResourceById(arg0 context.Context, arg1, arg2, arg3 string) *dto.ErrorResponseDto {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateNewInventory", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "ActivateResourceById", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(*dto.ErrorResponseDto)
 	return ret0
 }
 
+// ActivateResourceById indicates an expected call of ActivateResourceById.
+func (mr *MockIInventoryServiceMockRecorder) ActivateResourceById(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateResourceById", reflect.TypeOf((*MockIInventoryService)(nil).ActivateResourceById), arg0, arg1, arg2, arg3)
+}
+
+// AggregateInventory mocks base method.
+func (m *MockIInventoryService) AggregateInventory(arg0 context.Context, arg1 string, arg2 request_dto.AggregateRequest) (*dto0.AggregateResult, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateInventory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto0.AggregateResult)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// AggregateInventory indicates an expected call of AggregateInventory.
+func (mr *MockIInventoryServiceMockRecorder) AggregateInventory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateInventory", reflect.TypeOf((*MockIInventoryService)(nil).AggregateInventory), arg0, arg1, arg2)
+}
+
+// BulkCreateInventory mocks base method.
+func (m *MockIInventoryService) BulkCreateInventory(arg0 context.Context, arg1 []interface{}, arg2, arg3 string, arg4 bool) (*dto0.BulkInsertReport, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCreateInventory", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*dto0.BulkInsertReport)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// BulkCreateInventory indicates an expected call of BulkCreateInventory.
+func (mr *MockIInventoryServiceMockRecorder) BulkCreateInventory(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreateInventory", reflect.TypeOf((*MockIInventoryService)(nil).BulkCreateInventory), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CreateNewInventory mocks base method.
+func (m *MockIInventoryService) CreateNewInventory(arg0 context.Context, arg1 interface{}, arg2, arg3 string) (primitive.M, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNewInventory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(primitive.M)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
 // CreateNewInventory indicates an expected call of CreateNewInventory.
-func (mr *MockIInventoryServiceMockRecorder) CreateNewInventory(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockIInventoryServiceMockRecorder) CreateNewInventory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNewInventory", reflect.TypeOf((*MockIInventoryService)(nil).CreateNewInventory), arg0, arg1, arg2, arg3)
+}
+
+// CreateResource mocks base method.
+func (m *MockIInventoryService) CreateResource(arg0 context.Context, arg1 request_dto.InventoryResourceCreate, arg2, arg3, arg4 string) (*string, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateResource", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// CreateResource indicates an expected call of CreateResource.
+func (mr *MockIInventoryServiceMockRecorder) CreateResource(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateResource", reflect.TypeOf((*MockIInventoryService)(nil).CreateResource), arg0, arg1, arg2, arg3, arg4)
+}
+
+// DiffItemRevisions mocks base method.
+func (m *MockIInventoryService) DiffItemRevisions(arg0 context.Context, arg1, arg2 string, arg3, arg4 int64) ([]patch.Operation, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffItemRevisions", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]patch.Operation)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// DiffItemRevisions indicates an expected call of DiffItemRevisions.
+func (mr *MockIInventoryServiceMockRecorder) DiffItemRevisions(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffItemRevisions", reflect.TypeOf((*MockIInventoryService)(nil).DiffItemRevisions), arg0, arg1, arg2, arg3, arg4)
+}
+
+// EnforceRetentionPolicies mocks base method.
+func (m *MockIInventoryService) EnforceRetentionPolicies(arg0 context.Context, arg1 bool) (*dto0.RetentionReport, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnforceRetentionPolicies", arg0, arg1)
+	ret0, _ := ret[0].(*dto0.RetentionReport)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// EnforceRetentionPolicies indicates an expected call of EnforceRetentionPolicies.
+func (mr *MockIInventoryServiceMockRecorder) EnforceRetentionPolicies(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNewInventory", reflect.TypeOf((*MockIInventoryService)(nil).CreateNewInventory), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnforceRetentionPolicies", reflect.TypeOf((*MockIInventoryService)(nil).EnforceRetentionPolicies), arg0, arg1)
+}
+
+// ExportInventory mocks base method.
+func (m *MockIInventoryService) ExportInventory(arg0 context.Context, arg1 string, arg2 request_dto.ExportRequest, arg3 io.Writer) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportInventory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// ExportInventory indicates an expected call of ExportInventory.
+func (mr *MockIInventoryServiceMockRecorder) ExportInventory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportInventory", reflect.TypeOf((*MockIInventoryService)(nil).ExportInventory), arg0, arg1, arg2, arg3)
 }
 
 // GetInventory mocks base method.
-func (m *MockIInventoryService) GetInventory(arg0 context.Context, arg1 string, arg2 map[string][]string) (primitive.M, *dto.ErrorResponseDto) {
+func (m *MockIInventoryService) GetInventory(arg0 context.Context, arg1 string, arg2 map[string][]string, arg3, arg4 string) (primitive.M, *dto.ErrorResponseDto) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInventory", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetInventory", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(primitive.M)
 	ret1, _ := ret[1].(*dto.ErrorResponseDto)
 	return ret0, ret1
 }
 
 // GetInventory indicates an expected call of GetInventory.
-func (mr *MockIInventoryServiceMockRecorder) GetInventory(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockIInventoryServiceMockRecorder) GetInventory(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventory", reflect.TypeOf((*MockIInventoryService)(nil).GetInventory), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetInventoryFacets mocks base method.
+func (m *MockIInventoryService) GetInventoryFacets(arg0 context.Context, arg1 string, arg2 request_dto.FacetRequest) ([]dto0.Facet, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryFacets", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]dto0.Facet)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// GetInventoryFacets indicates an expected call of GetInventoryFacets.
+func (mr *MockIInventoryServiceMockRecorder) GetInventoryFacets(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryFacets", reflect.TypeOf((*MockIInventoryService)(nil).GetInventoryFacets), arg0, arg1, arg2)
+}
+
+// GetInventoryFilter mocks base method.
+func (m *MockIInventoryService) GetInventoryFilter(arg0 context.Context, arg1, arg2 string, arg3 map[string][]string, arg4, arg5, arg6 string) ([]interface{}, *dto0.PaginationResponse, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryFilter", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].([]interface{})
+	ret1, _ := ret[1].(*dto0.PaginationResponse)
+	ret2, _ := ret[2].(*dto.ErrorResponseDto)
+	return ret0, ret1, ret2
+}
+
+// GetInventoryFilter indicates an expected call of GetInventoryFilter.
+func (mr *MockIInventoryServiceMockRecorder) GetInventoryFilter(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryFilter", reflect.TypeOf((*MockIInventoryService)(nil).GetInventoryFilter), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// GetInventoryTrash mocks base method.
+func (m *MockIInventoryService) GetInventoryTrash(arg0 context.Context, arg1, arg2, arg3 string) ([]primitive.M, *dto0.PaginationResponse, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryTrash", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]primitive.M)
+	ret1, _ := ret[1].(*dto0.PaginationResponse)
+	ret2, _ := ret[2].(*dto.ErrorResponseDto)
+	return ret0, ret1, ret2
+}
+
+// GetInventoryTrash indicates an expected call of GetInventoryTrash.
+func (mr *MockIInventoryServiceMockRecorder) GetInventoryTrash(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryTrash", reflect.TypeOf((*MockIInventoryService)(nil).GetInventoryTrash), arg0, arg1, arg2, arg3)
+}
+
+// GetInventoryV2 mocks base method.
+func (m *MockIInventoryService) GetInventoryV2(arg0 *gin.Context, arg1, arg2, arg3 string, arg4 map[string]interface{}) ([]primitive.M, *dto0.PaginationResponse, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryV2", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]primitive.M)
+	ret1, _ := ret[1].(*dto0.PaginationResponse)
+	ret2, _ := ret[2].(*dto.ErrorResponseDto)
+	return ret0, ret1, ret2
+}
+
+// GetInventoryV2 indicates an expected call of GetInventoryV2.
+func (mr *MockIInventoryServiceMockRecorder) GetInventoryV2(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryV2", reflect.TypeOf((*MockIInventoryService)(nil).GetInventoryV2), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetItemAsOf mocks base method.
+func (m *MockIInventoryService) GetItemAsOf(arg0 context.Context, arg1, arg2 string, arg3 time.Time) (*dto0.ItemRevision, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemAsOf", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto0.ItemRevision)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// GetItemAsOf indicates an expected call of GetItemAsOf.
+func (mr *MockIInventoryServiceMockRecorder) GetItemAsOf(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemAsOf", reflect.TypeOf((*MockIInventoryService)(nil).GetItemAsOf), arg0, arg1, arg2, arg3)
+}
+
+// GetItemRevision mocks base method.
+func (m *MockIInventoryService) GetItemRevision(arg0 context.Context, arg1, arg2 string, arg3 int64) (*dto0.ItemRevision, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemRevision", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto0.ItemRevision)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// GetItemRevision indicates an expected call of GetItemRevision.
+func (mr *MockIInventoryServiceMockRecorder) GetItemRevision(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemRevision", reflect.TypeOf((*MockIInventoryService)(nil).GetItemRevision), arg0, arg1, arg2, arg3)
+}
+
+// ImportInventory mocks base method.
+func (m *MockIInventoryService) ImportInventory(arg0 context.Context, arg1 string, arg2 request_dto.ImportRequest, arg3 io.ReadSeeker, arg4 string) (*dto0.ImportReport, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportInventory", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*dto0.ImportReport)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// ImportInventory indicates an expected call of ImportInventory.
+func (mr *MockIInventoryServiceMockRecorder) ImportInventory(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportInventory", reflect.TypeOf((*MockIInventoryService)(nil).ImportInventory), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ListItemRevisions mocks base method.
+func (m *MockIInventoryService) ListItemRevisions(arg0 context.Context, arg1, arg2 string) ([]dto0.ItemRevision, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItemRevisions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]dto0.ItemRevision)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// ListItemRevisions indicates an expected call of ListItemRevisions.
+func (mr *MockIInventoryServiceMockRecorder) ListItemRevisions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItemRevisions", reflect.TypeOf((*MockIInventoryService)(nil).ListItemRevisions), arg0, arg1, arg2)
+}
+
+// PurgeInventory mocks base method.
+func (m *MockIInventoryService) PurgeInventory(arg0 context.Context, arg1 string, arg2 bool) (*dto0.InventoryRetention, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeInventory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto0.InventoryRetention)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// PurgeInventory indicates an expected call of PurgeInventory.
+func (mr *MockIInventoryServiceMockRecorder) PurgeInventory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeInventory", reflect.TypeOf((*MockIInventoryService)(nil).PurgeInventory), arg0, arg1, arg2)
+}
+
+// RemoveItemFromInventory mocks base method.
+func (m *MockIInventoryService) RemoveItemFromInventory(arg0 context.Context, arg1 *request_dto.RemoveInventoryItem, arg2, arg3 string) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveItemFromInventory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// RemoveItemFromInventory indicates an expected call of RemoveItemFromInventory.
+func (mr *MockIInventoryServiceMockRecorder) RemoveItemFromInventory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItemFromInventory", reflect.TypeOf((*MockIInventoryService)(nil).RemoveItemFromInventory), arg0, arg1, arg2, arg3)
+}
+
+// RemoveSubjectTopicsByLessonNameAndSubjectId mocks base method.
+func (m *MockIInventoryService) RemoveSubjectTopicsByLessonNameAndSubjectId(arg0 context.Context, arg1 *request_dto.RemoveSubjectRequest, arg2 string) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSubjectTopicsByLessonNameAndSubjectId", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// RemoveSubjectTopicsByLessonNameAndSubjectId indicates an expected call of RemoveSubjectTopicsByLessonNameAndSubjectId.
+func (mr *MockIInventoryServiceMockRecorder) RemoveSubjectTopicsByLessonNameAndSubjectId(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubjectTopicsByLessonNameAndSubjectId", reflect.TypeOf((*MockIInventoryService)(nil).RemoveSubjectTopicsByLessonNameAndSubjectId), arg0, arg1, arg2)
+}
+
+// RevertItem mocks base method.
+func (m *MockIInventoryService) RevertItem(arg0 context.Context, arg1, arg2 string, arg3 int64, arg4 string, arg5 *int64) (primitive.M, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertItem", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(primitive.M)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// RevertItem indicates an expected call of RevertItem.
+func (mr *MockIInventoryServiceMockRecorder) RevertItem(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertItem", reflect.TypeOf((*MockIInventoryService)(nil).RevertItem), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// SearchInventory mocks base method.
+func (m *MockIInventoryService) SearchInventory(arg0 context.Context, arg1, arg2, arg3, arg4 string) ([]dto0.SearchResult, *dto0.PaginationResponse, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchInventory", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]dto0.SearchResult)
+	ret1, _ := ret[1].(*dto0.PaginationResponse)
+	ret2, _ := ret[2].(*dto.ErrorResponseDto)
+	return ret0, ret1, ret2
+}
+
+// SearchInventory indicates an expected call of SearchInventory.
+func (mr *MockIInventoryServiceMockRecorder) SearchInventory(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchInventory", reflect.TypeOf((*MockIInventoryService)(nil).SearchInventory), arg0, arg1, arg2, arg3, arg4)
+}
+
+// UpdateInventory mocks base method.
+func (m *MockIInventoryService) UpdateInventory(arg0, arg1 string, arg2 *interface{}, arg3 string, arg4 *int64, arg5 string) (int64, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInventory", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(*dto.ErrorResponseDto)
+	return ret0, ret1
+}
+
+// UpdateInventory indicates an expected call of UpdateInventory.
+func (mr *MockIInventoryServiceMockRecorder) UpdateInventory(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInventory", reflect.TypeOf((*MockIInventoryService)(nil).UpdateInventory), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// UpdateInventoryTopic mocks base method.
+func (m *MockIInventoryService) UpdateInventoryTopic(arg0 context.Context, arg1 *request_dto.InventoryTopicUpdateRequest, arg2, arg3 string) *dto.ErrorResponseDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInventoryTopic", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto.ErrorResponseDto)
+	return ret0
+}
+
+// UpdateInventoryTopic indicates an expected call of UpdateInventoryTopic.
+func (mr *MockIInventoryServiceMockRecorder) UpdateInventoryTopic(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInventoryTopic", reflect.TypeOf((*MockIInventoryService)(nil).UpdateInventoryTopic), arg0, arg1, arg2, arg3)
+}
+
+// UpsertInventory mocks base method.
+func (m *MockIInventoryService) UpsertInventory(arg0 context.Context, arg1 interface{}, arg2, arg3, arg4 string) (primitive.M, bool, *dto.ErrorResponseDto) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertInventory", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(primitive.M)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(*dto.ErrorResponseDto)
+	return ret0, ret1, ret2
+}
+
+// UpsertInventory indicates an expected call of UpsertInventory.
+func (mr *MockIInventoryServiceMockRecorder) UpsertInventory(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventory", reflect.TypeOf((*MockIInventoryService)(nil).GetInventory), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertInventory", reflect.TypeOf((*MockIInventoryService)(nil).UpsertInventory), arg0, arg1, arg2, arg3, arg4)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
 	"inventory-system/common/pkg/dto"
 	"inventory-system/common/pkg/logger"
 	mockClient "inventory-system/inventory-service/internal/adapters/client/mocks"
@@ -25,11 +26,19 @@ var mockInventoryConfigurationRepo *mockRepo.MockIInventoryConfigurationReposito
 var mockMongoStorageManagerClient *mockClient.MockIMongoStorageManager
 var createdTime, _ = time.Parse(time.RFC3339, "2006-01-02T15:04:05Z")
 
+var courseValidator = map[string]interface{}{"$jsonSchema": map[string]interface{}{
+	"bsonType": "object",
+	"properties": map[string]interface{}{
+		"name":      map[string]interface{}{"bsonType": "string"},
+		"course_id": map[string]interface{}{"bsonType": "string"},
+	},
+}}
+
 var configRequestDto = request_dto.CreateNewConfigurationRequestBody{
 	InventoryName:        "Course",
 	CreatedBy:            "admin",
-	JsonSchema:           map[string]interface{}{},
-	InventoryIdentifiers: []string{"name", "course_id"},
+	JsonSchema:           courseValidator,
+	InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "name"}, {Key: "course_id"}},
 }
 
 func TestCreateNewConfiguration(t *testing.T) {
@@ -41,32 +50,129 @@ func TestCreateNewConfiguration(t *testing.T) {
 
 	sut := serviceImpl.NewInventoryConfigurationService(mockInventoryConfigurationRepo, mockMongoStorageManagerClient)
 
+	savedConfigRequestDto := configRequestDto
+	savedConfigRequestDto.SchemaDialect = "mongo"
+
 	t.Run("TestCreateNewConfiguration_ShouldReturnNilError_WhenNoErrorOccursInRepoAndClient", func(t *testing.T) {
-		mockInventoryConfigurationRepo.EXPECT().CreateNewConfiguration(gomock.Any(), configRequestDto).Return(nil)
+		mockInventoryConfigurationRepo.EXPECT().CreateNewConfiguration(gomock.Any(), savedConfigRequestDto).Return(nil)
 		mockMongoStorageManagerClient.EXPECT().CreateCollection(gomock.Any(), configRequestDto.InventoryName, configRequestDto.JsonSchema, configRequestDto.InventoryIdentifiers).Return(nil)
 
-		err := sut.CreateNewConfiguration(context.Background(), configRequestDto)
+		unenforcedKeywords, err := sut.CreateNewConfiguration(context.Background(), configRequestDto)
+		assert.Nil(t, err)
+		assert.Empty(t, unenforcedKeywords)
+	})
+	t.Run("TestCreateNewConfiguration_ShouldBuildSearchIndex_WhenFieldsAreSearchable", func(t *testing.T) {
+		searchableConfigRequestDto := configRequestDto
+		searchableConfigRequestDto.SearchableFields = []string{"name"}
+		savedSearchableConfigRequestDto := searchableConfigRequestDto
+		savedSearchableConfigRequestDto.SchemaDialect = "mongo"
+		indexedIdentifiers := []request_dto.InventoryIdentifier{{Key: "name"}, {Key: "course_id"}, {Key: "search", Keys: []string{"name"}, IndexType: request_dto.IndexTypeText}}
+
+		mockInventoryConfigurationRepo.EXPECT().CreateNewConfiguration(gomock.Any(), savedSearchableConfigRequestDto).Return(nil)
+		mockMongoStorageManagerClient.EXPECT().CreateCollection(gomock.Any(), configRequestDto.InventoryName, configRequestDto.JsonSchema, indexedIdentifiers).Return(nil)
+
+		_, err := sut.CreateNewConfiguration(context.Background(), searchableConfigRequestDto)
 		assert.Nil(t, err)
 	})
+	t.Run("TestCreateNewConfiguration_ShouldTranslateSchemaAndReportUnenforcedKeywords_WhenDraft07SchemaGiven", func(t *testing.T) {
+		draftConfigRequestDto := configRequestDto
+		draftConfigRequestDto.JsonSchema = map[string]interface{}{
+			"$schema":  "http://json-schema.org/draft-07/schema#",
+			"type":     "object",
+			"required": []interface{}{"name"},
+			"properties": map[string]interface{}{
+				"name":      map[string]interface{}{"type": "string", "format": "email"},
+				"course_id": map[string]interface{}{"type": "string"},
+				"price":     map[string]interface{}{"type": "number", "exclusiveMinimum": float64(0)},
+			},
+		}
+		translatedSchema := bson.M{"$jsonSchema": map[string]interface{}{
+			"bsonType": "object",
+			"required": []interface{}{"name"},
+			"properties": map[string]interface{}{
+				"name":      map[string]interface{}{"bsonType": "string"},
+				"course_id": map[string]interface{}{"bsonType": "string"},
+				"price":     map[string]interface{}{"bsonType": "number", "minimum": float64(0), "exclusiveMinimum": true},
+			},
+		}}
+		savedDraftConfigRequestDto := draftConfigRequestDto
+		savedDraftConfigRequestDto.SchemaDialect = "draft-07"
+		savedDraftConfigRequestDto.SourceJsonSchema = draftConfigRequestDto.JsonSchema
+		savedDraftConfigRequestDto.JsonSchema = translatedSchema
+		savedDraftConfigRequestDto.UnenforcedKeywords = []string{"/properties/name: format"}
+
+		mockInventoryConfigurationRepo.EXPECT().CreateNewConfiguration(gomock.Any(), savedDraftConfigRequestDto).Return(nil)
+		mockMongoStorageManagerClient.EXPECT().CreateCollection(gomock.Any(), configRequestDto.InventoryName, translatedSchema, configRequestDto.InventoryIdentifiers).Return(nil)
+
+		unenforcedKeywords, err := sut.CreateNewConfiguration(context.Background(), draftConfigRequestDto)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"/properties/name: format"}, unenforcedKeywords)
+	})
+	t.Run("TestCreateNewConfiguration_ShouldReturnError_WhenSchemaDraftIsUnsupported", func(t *testing.T) {
+		unsupportedConfigRequestDto := configRequestDto
+		unsupportedConfigRequestDto.JsonSchema = map[string]interface{}{"$schema": "http://json-schema.org/draft-03/schema#"}
+		var errDto dto.ErrorResponseDto
+		errDto.SetError(status_code.IMS125)
+
+		_, err := sut.CreateNewConfiguration(context.Background(), unsupportedConfigRequestDto)
+		assert.Equal(t, errDto.StatusCode, err.StatusCode)
+	})
+	t.Run("TestCreateNewConfiguration_ShouldReturnErrorWithoutSaving_WhenSchemaKeywordInvalid", func(t *testing.T) {
+		invalidConfigRequestDto := configRequestDto
+		invalidConfigRequestDto.JsonSchema = map[string]interface{}{"$jsonSchema": map[string]interface{}{
+			"bsonType":   "object",
+			"required":   []interface{}{},
+			"properties": map[string]interface{}{"name": map[string]interface{}{"bsonType": "text"}, "course_id": map[string]interface{}{"format": "uuid"}},
+		}}
+		var errDto dto.ErrorResponseDto
+		errDto.SetError(status_code.IMS125)
+
+		_, err := sut.CreateNewConfiguration(context.Background(), invalidConfigRequestDto)
+		assert.Equal(t, errDto.StatusCode, err.StatusCode)
+		assert.Equal(t, errDto.Message+" : /properties/course_id/format: unknown keyword, /properties/name/bsonType: must be a bson type or an array of bson types, /required: must be a non empty array of unique strings", err.Message)
+	})
+	t.Run("TestCreateNewConfiguration_ShouldReturnErrorWithoutSaving_WhenIdentifierNotInSchemaProperties", func(t *testing.T) {
+		invalidConfigRequestDto := configRequestDto
+		invalidConfigRequestDto.InventoryIdentifiers = []request_dto.InventoryIdentifier{{Key: "name"}, {Key: "lesson", Keys: []string{"lesson.id", "course_id"}}}
+		var errDto dto.ErrorResponseDto
+		errDto.SetError(status_code.IMS132)
+
+		_, err := sut.CreateNewConfiguration(context.Background(), invalidConfigRequestDto)
+		assert.Equal(t, errDto.StatusCode, err.StatusCode)
+		assert.Equal(t, errDto.Message+" : lesson.id", err.Message)
+	})
 	t.Run("TestCreateNewConfiguration_ShouldReturnError_WhenErrorReturnedByRepo", func(t *testing.T) {
 		var errDto dto.ErrorResponseDto
 		errDto.SetError(status_code.IMS500)
 
-		mockInventoryConfigurationRepo.EXPECT().CreateNewConfiguration(gomock.Any(), configRequestDto).Return(&errDto)
-		err := sut.CreateNewConfiguration(context.Background(), configRequestDto)
+		mockInventoryConfigurationRepo.EXPECT().CreateNewConfiguration(gomock.Any(), savedConfigRequestDto).Return(&errDto)
+		_, err := sut.CreateNewConfiguration(context.Background(), configRequestDto)
 		assert.Equal(t, errDto.StatusCode, err.StatusCode)
 		assert.Equal(t, errDto.Message, err.Message)
 	})
-	t.Run("TestCreateNewConfiguration_ShouldReturnErrorAndSaveDataInRepo_ErrorReturnedByClient", func(t *testing.T) {
+	t.Run("TestCreateNewConfiguration_ShouldReturnErrorAndRollBack_WhenErrorReturnedByClient", func(t *testing.T) {
 		var errDto dto.ErrorResponseDto
 		errDto.SetError(status_code.IMS500)
 
-		mockInventoryConfigurationRepo.EXPECT().CreateNewConfiguration(gomock.Any(), configRequestDto).Return(nil)
+		mockInventoryConfigurationRepo.EXPECT().CreateNewConfiguration(gomock.Any(), savedConfigRequestDto).Return(nil)
 		mockMongoStorageManagerClient.EXPECT().CreateCollection(gomock.Any(), configRequestDto.InventoryName, configRequestDto.JsonSchema, configRequestDto.InventoryIdentifiers).Return(&errDto)
-		err := sut.CreateNewConfiguration(context.Background(), configRequestDto)
+		mockMongoStorageManagerClient.EXPECT().DropCollection(gomock.Any(), configRequestDto.InventoryName).Return(nil)
+		mockInventoryConfigurationRepo.EXPECT().RemoveInventoryConfigurationByName(gomock.Any(), configRequestDto.InventoryName).Return(nil)
+		_, err := sut.CreateNewConfiguration(context.Background(), configRequestDto)
 		assert.Equal(t, errDto.StatusCode, err.StatusCode)
 		assert.Equal(t, errDto.Message, err.Message)
 	})
+	t.Run("TestCreateNewConfiguration_ShouldKeepExistingCollection_WhenCollectionAlreadyExists", func(t *testing.T) {
+		var errDto dto.ErrorResponseDto
+		errDto.SetError(status_code.IMS102)
+
+		mockInventoryConfigurationRepo.EXPECT().CreateNewConfiguration(gomock.Any(), savedConfigRequestDto).Return(nil)
+		mockMongoStorageManagerClient.EXPECT().CreateCollection(gomock.Any(), configRequestDto.InventoryName, configRequestDto.JsonSchema, configRequestDto.InventoryIdentifiers).Return(&errDto)
+		mockMongoStorageManagerClient.EXPECT().DropCollection(gomock.Any(), gomock.Any()).Times(0)
+		mockInventoryConfigurationRepo.EXPECT().RemoveInventoryConfigurationByName(gomock.Any(), configRequestDto.InventoryName).Return(nil)
+		_, err := sut.CreateNewConfiguration(context.Background(), configRequestDto)
+		assert.Equal(t, errDto.StatusCode, err.StatusCode)
+	})
 
 }
 
@@ -82,7 +188,7 @@ func TestGetInventoryConfiguration(t *testing.T) {
 
 	var configRepoResponse = inventoryServiceDto.InventoryConfiguration{
 		InventoryName:        "Course",
-		InventoryIdentifiers: []string{"name", "course_id"},
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "name"}, {Key: "course_id"}},
 		CreatedBy:            "admin",
 		CreatedOn:            createdTime,
 		JsonSchema:           map[string]interface{}{},
@@ -90,7 +196,7 @@ func TestGetInventoryConfiguration(t *testing.T) {
 	}
 	var serviceResponse = response_dto.InventoryConfigurationResponseDto{
 		InventoryName:        "Course",
-		InventoryIdentifiers: []string{"name", "course_id"},
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "name"}, {Key: "course_id"}},
 		CreatedBy:            "admin",
 		CreatedOn:            createdTime,
 		JsonSchema:           map[string]interface{}{},
@@ -111,8 +217,10 @@ func TestGetInventoryConfiguration(t *testing.T) {
 
 		var expectedErr dto.ErrorResponseDto
 		expectedErr.SetError(status_code.IMS204)
+		deletedServiceResponse := serviceResponse
+		deletedServiceResponse.IsDeleted = true
 		assert.Equal(t, err, &expectedErr)
-		assert.Equal(t, resp, &serviceResponse)
+		assert.Equal(t, resp, &deletedServiceResponse)
 	})
 
 	t.Run("TestGetInventoryConfiguration_ShouldReturnError_WhenErrorWhileFetchingConfigFromRepo", func(t *testing.T) {
@@ -166,7 +274,7 @@ func TestGetAllInventoryConfiguration(t *testing.T) {
 
 	var configRepoResponse1 = inventoryServiceDto.InventoryConfiguration{
 		InventoryName:        "Course",
-		InventoryIdentifiers: []string{"name", "course_id"},
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "name"}, {Key: "course_id"}},
 		CreatedBy:            "admin",
 		CreatedOn:            createdTime,
 		JsonSchema:           map[string]interface{}{},
@@ -174,7 +282,7 @@ func TestGetAllInventoryConfiguration(t *testing.T) {
 	}
 	var configRepoResponse2 = inventoryServiceDto.InventoryConfiguration{
 		InventoryName:        "Placement",
-		InventoryIdentifiers: []string{"name", "placement_id"},
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "name"}, {Key: "placement_id"}},
 		CreatedBy:            "admin",
 		CreatedOn:            createdTime,
 		JsonSchema:           map[string]interface{}{},
@@ -190,20 +298,364 @@ func TestGetAllInventoryConfiguration(t *testing.T) {
 		//Only 1 active config in list of 2
 
 		mockInventoryConfigurationRepo.EXPECT().FetchAllInventoryConfiguration(gomock.Any()).Return(configListRepo, nil)
-		resp, err := sut.GetAllInventoryConfiguration(context.Background())
+		resp, err := sut.GetAllInventoryConfiguration(context.Background(), false)
 		assert.Nil(t, err)
 		assert.Equal(t, len(resp), 1)
 
 	})
+	t.Run("TestTestGetAllInventoryConfiguration_ShouldReturnDeletedConfigurations_WhenIncludeDeleted", func(t *testing.T) {
+		mockInventoryConfigurationRepo.EXPECT().FetchAllInventoryConfiguration(gomock.Any()).Return(configListRepo, nil)
+		resp, err := sut.GetAllInventoryConfiguration(context.Background(), true)
+		assert.Nil(t, err)
+		assert.Equal(t, len(resp), 2)
+		assert.True(t, resp[1].IsDeleted)
+	})
 	t.Run("TestTestGetAllInventoryConfiguration_ShouldReturnError_WhenErrorOccursInRepo", func(t *testing.T) {
 		var errDto dto.ErrorResponseDto
 		errDto.SetError(status_code.IMS500)
 
 		mockInventoryConfigurationRepo.EXPECT().FetchAllInventoryConfiguration(gomock.Any()).Return(nil, &errDto)
-		resp, err := sut.GetAllInventoryConfiguration(context.Background())
+		resp, err := sut.GetAllInventoryConfiguration(context.Background(), false)
 		assert.Equal(t, err.StatusCode, errDto.StatusCode)
 		assert.Equal(t, err.Message, errDto.Message)
 		assert.Nil(t, resp)
 	})
 
 }
+
+func TestUpdateInventoryConfiguration(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryConfigurationRepo = mockRepo.NewMockIInventoryConfigurationRepository(mockController)
+	mockMongoStorageManagerClient = mockClient.NewMockIMongoStorageManager(mockController)
+
+	sut := serviceImpl.NewInventoryConfigurationService(mockInventoryConfigurationRepo, mockMongoStorageManagerClient)
+	inventoryName := "Course"
+
+	updateRequestDto := request_dto.UpdateConfigurationRequestBody{
+		UpdatedBy:            "admin",
+		JsonSchema:           courseValidator,
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "name", IsUnique: true}},
+		ValidationLevel:      "strict",
+		SchemaDialect:        "mongo",
+	}
+	var configRepoResponse = inventoryServiceDto.InventoryConfiguration{
+		InventoryName:        "Course",
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "name"}},
+		CreatedBy:            "admin",
+		CreatedOn:            createdTime,
+		JsonSchema:           map[string]interface{}{},
+		Version:              1,
+	}
+	var updatedRepoResponse = inventoryServiceDto.InventoryConfiguration{
+		InventoryName:        "Course",
+		InventoryIdentifiers: updateRequestDto.InventoryIdentifiers,
+		CreatedBy:            "admin",
+		CreatedOn:            createdTime,
+		JsonSchema:           map[string]interface{}{},
+		ValidationLevel:      "strict",
+		Version:              2,
+	}
+	//Pagination is carried over from the stored configuration when not given
+	savedRequestDto := updateRequestDto
+	savedRequestDto.Pagination = &configRepoResponse.Pagination
+
+	t.Run("TestUpdateInventoryConfiguration_ShouldReturnNextVersion_WhenNoErrorOccurs", func(t *testing.T) {
+		mockInventoryConfigurationRepo.EXPECT().FetchInventoryConfigurationByName(gomock.Any(), inventoryName).Return(&configRepoResponse, nil)
+		mockMongoStorageManagerClient.EXPECT().UpdateCollection(gomock.Any(), inventoryName, updateRequestDto.JsonSchema, "strict", updateRequestDto.InventoryIdentifiers).Return(nil)
+		mockInventoryConfigurationRepo.EXPECT().UpdateInventoryConfigurationByName(gomock.Any(), inventoryName, int64(1), savedRequestDto).Return(&updatedRepoResponse, nil)
+		mockInventoryConfigurationRepo.EXPECT().CreateInventoryConfigurationRevision(gomock.Any(), updatedRepoResponse).Return(nil)
+
+		resp, err := sut.UpdateInventoryConfiguration(context.Background(), inventoryName, updateRequestDto)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), resp.Version)
+	})
+	t.Run("TestUpdateInventoryConfiguration_ShouldReturnError_WhenPaginationSettingsAreInvalid", func(t *testing.T) {
+		invalidRequestDto := updateRequestDto
+		invalidRequestDto.PaginationSettings = &request_dto.PaginationSettings{DefaultPageSize: 50, MaxPageSize: 20}
+		mockInventoryConfigurationRepo.EXPECT().FetchInventoryConfigurationByName(gomock.Any(), inventoryName).Return(&configRepoResponse, nil)
+
+		resp, err := sut.UpdateInventoryConfiguration(context.Background(), inventoryName, invalidRequestDto)
+		assert.Nil(t, resp)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS137).StatusCode, err.StatusCode)
+	})
+	t.Run("TestUpdateInventoryConfiguration_ShouldReturnError_WhenConfigurationIsDeleted", func(t *testing.T) {
+		deletedConfiguration := configRepoResponse
+		deletedConfiguration.IsDeleted = true
+		mockInventoryConfigurationRepo.EXPECT().FetchInventoryConfigurationByName(gomock.Any(), inventoryName).Return(&deletedConfiguration, nil)
+
+		var expectedErr dto.ErrorResponseDto
+		expectedErr.SetError(status_code.IMS204)
+		resp, err := sut.UpdateInventoryConfiguration(context.Background(), inventoryName, updateRequestDto)
+		assert.Nil(t, resp)
+		assert.Equal(t, &expectedErr, err)
+	})
+	t.Run("TestUpdateInventoryConfiguration_ShouldNotSaveConfiguration_WhenCollectionUpdateFails", func(t *testing.T) {
+		var errDto dto.ErrorResponseDto
+		errDto.SetError(status_code.IMS120)
+
+		mockInventoryConfigurationRepo.EXPECT().FetchInventoryConfigurationByName(gomock.Any(), inventoryName).Return(&configRepoResponse, nil)
+		mockMongoStorageManagerClient.EXPECT().UpdateCollection(gomock.Any(), inventoryName, updateRequestDto.JsonSchema, "strict", updateRequestDto.InventoryIdentifiers).Return(&errDto)
+		//The collection is restored to the saved configuration
+		mockInventoryConfigurationRepo.EXPECT().FetchInventoryConfigurationByName(gomock.Any(), inventoryName).Return(&configRepoResponse, nil)
+		mockMongoStorageManagerClient.EXPECT().UpdateCollection(gomock.Any(), inventoryName, configRepoResponse.JsonSchema, "strict", configRepoResponse.InventoryIdentifiers).Return(nil)
+
+		resp, err := sut.UpdateInventoryConfiguration(context.Background(), inventoryName, updateRequestDto)
+		assert.Nil(t, resp)
+		assert.Equal(t, errDto.StatusCode, err.StatusCode)
+	})
+	t.Run("TestUpdateInventoryConfiguration_ShouldRestoreCollectionToSavedConfiguration_WhenVersionCheckFails", func(t *testing.T) {
+		var errDto dto.ErrorResponseDto
+		errDto.SetError(status_code.IMS123)
+		//A concurrent update saved version 2 with its own identifiers while this one was migrating
+		concurrentConfiguration := updatedRepoResponse
+		concurrentConfiguration.InventoryIdentifiers = []request_dto.InventoryIdentifier{{Key: "code", IsUnique: true}}
+		concurrentConfiguration.SearchableFields = []string{"name"}
+
+		mockInventoryConfigurationRepo.EXPECT().FetchInventoryConfigurationByName(gomock.Any(), inventoryName).Return(&configRepoResponse, nil)
+		mockMongoStorageManagerClient.EXPECT().UpdateCollection(gomock.Any(), inventoryName, updateRequestDto.JsonSchema, "strict", updateRequestDto.InventoryIdentifiers).Return(nil)
+		mockInventoryConfigurationRepo.EXPECT().UpdateInventoryConfigurationByName(gomock.Any(), inventoryName, int64(1), savedRequestDto).Return(nil, &errDto)
+		mockInventoryConfigurationRepo.EXPECT().FetchInventoryConfigurationByName(gomock.Any(), inventoryName).Return(&concurrentConfiguration, nil)
+		mockMongoStorageManagerClient.EXPECT().UpdateCollection(gomock.Any(), inventoryName, concurrentConfiguration.JsonSchema, "strict", serviceImpl.IndexedIdentifiers(concurrentConfiguration.InventoryIdentifiers, concurrentConfiguration.SearchableFields)).Return(nil)
+		mockInventoryConfigurationRepo.EXPECT().CreateInventoryConfigurationRevision(gomock.Any(), gomock.Any()).Times(0)
+
+		resp, err := sut.UpdateInventoryConfiguration(context.Background(), inventoryName, updateRequestDto)
+		assert.Nil(t, resp)
+		assert.Equal(t, &errDto, err)
+	})
+	t.Run("TestUpdateInventoryConfiguration_ShouldDefaultValidationLevelToStrict_WhenNotProvided", func(t *testing.T) {
+		requestDto := updateRequestDto
+		requestDto.ValidationLevel = ""
+
+		mockInventoryConfigurationRepo.EXPECT().FetchInventoryConfigurationByName(gomock.Any(), inventoryName).Return(&configRepoResponse, nil)
+		mockMongoStorageManagerClient.EXPECT().UpdateCollection(gomock.Any(), inventoryName, requestDto.JsonSchema, "strict", requestDto.InventoryIdentifiers).Return(nil)
+		mockInventoryConfigurationRepo.EXPECT().UpdateInventoryConfigurationByName(gomock.Any(), inventoryName, int64(1), savedRequestDto).Return(&updatedRepoResponse, nil)
+		mockInventoryConfigurationRepo.EXPECT().CreateInventoryConfigurationRevision(gomock.Any(), updatedRepoResponse).Return(nil)
+
+		_, err := sut.UpdateInventoryConfiguration(context.Background(), inventoryName, requestDto)
+		assert.Nil(t, err)
+	})
+}
+
+func TestValidateInventoryConfiguration(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryConfigurationRepo = mockRepo.NewMockIInventoryConfigurationRepository(mockController)
+	mockMongoStorageManagerClient = mockClient.NewMockIMongoStorageManager(mockController)
+
+	sut := serviceImpl.NewInventoryConfigurationService(mockInventoryConfigurationRepo, mockMongoStorageManagerClient)
+	inventoryName := "Course"
+
+	validateRequestDto := request_dto.ValidateConfigurationRequestBody{
+		JsonSchema:           map[string]interface{}{"$jsonSchema": map[string]interface{}{"required": []interface{}{"name"}, "properties": map[string]interface{}{"name": map[string]interface{}{"bsonType": "string"}}}},
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "name", IsUnique: true}},
+	}
+	var configRepoResponse = inventoryServiceDto.InventoryConfiguration{
+		InventoryName: "Course",
+		CreatedBy:     "admin",
+		CreatedOn:     createdTime,
+		JsonSchema:    map[string]interface{}{},
+	}
+
+	t.Run("TestValidateInventoryConfiguration_ShouldReturnReport_WhenNoErrorOccurs", func(t *testing.T) {
+		report := inventoryServiceDto.SchemaValidationReport{TotalDocuments: 4, InvalidDocuments: 1}
+		mockInventoryConfigurationRepo.EXPECT().FetchInventoryConfigurationByName(gomock.Any(), inventoryName).Return(&configRepoResponse, nil)
+		mockMongoStorageManagerClient.EXPECT().ValidateCollection(gomock.Any(), inventoryName, validateRequestDto.JsonSchema, validateRequestDto.InventoryIdentifiers).Return(&report, nil)
+
+		resp, err := sut.ValidateInventoryConfiguration(context.Background(), inventoryName, validateRequestDto)
+		assert.Nil(t, err)
+		assert.Equal(t, &report, resp)
+	})
+	t.Run("TestValidateInventoryConfiguration_ShouldReturnError_WhenSchemaInvalid", func(t *testing.T) {
+		var errDto dto.ErrorResponseDto
+		errDto.SetError(status_code.IMS125)
+		mockInventoryConfigurationRepo.EXPECT().FetchInventoryConfigurationByName(gomock.Any(), inventoryName).Return(&configRepoResponse, nil)
+		mockMongoStorageManagerClient.EXPECT().ValidateCollection(gomock.Any(), inventoryName, validateRequestDto.JsonSchema, validateRequestDto.InventoryIdentifiers).Return(nil, &errDto)
+
+		resp, err := sut.ValidateInventoryConfiguration(context.Background(), inventoryName, validateRequestDto)
+		assert.Nil(t, resp)
+		assert.Equal(t, errDto.StatusCode, err.StatusCode)
+	})
+	t.Run("TestValidateInventoryConfiguration_ShouldReturnError_WhenConfigurationIsDeleted", func(t *testing.T) {
+		deletedConfiguration := configRepoResponse
+		deletedConfiguration.IsDeleted = true
+		mockInventoryConfigurationRepo.EXPECT().FetchInventoryConfigurationByName(gomock.Any(), inventoryName).Return(&deletedConfiguration, nil)
+
+		resp, err := sut.ValidateInventoryConfiguration(context.Background(), inventoryName, validateRequestDto)
+		assert.Nil(t, resp)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS204).StatusCode, err.StatusCode)
+	})
+}
+
+func TestValidatePaginationSettings(t *testing.T) {
+	t.Run("TestValidatePaginationSettings_ShouldReturnNil_WhenSortFieldIsDeclaredOrManaged", func(t *testing.T) {
+		assert.Nil(t, serviceImpl.ValidatePaginationSettings(courseValidator, &request_dto.PaginationSettings{DefaultPageSize: 20, MaxPageSize: 50, DefaultSortField: "name"}))
+		assert.Nil(t, serviceImpl.ValidatePaginationSettings(courseValidator, &request_dto.PaginationSettings{DefaultSortField: "updated_at"}))
+		assert.Nil(t, serviceImpl.ValidatePaginationSettings(courseValidator, nil))
+	})
+	t.Run("TestValidatePaginationSettings_ShouldReturnError_WhenSettingsAreInconsistent", func(t *testing.T) {
+		var expectedErr dto.ErrorResponseDto
+		expectedErr.SetError(status_code.IMS137)
+
+		err := serviceImpl.ValidatePaginationSettings(courseValidator, &request_dto.PaginationSettings{DefaultPageSize: 200, DefaultSortField: "price"})
+		assert.Equal(t, expectedErr.StatusCode, err.StatusCode)
+		assert.Equal(t, expectedErr.Message+" : default_page_size exceeds max_page_size 100, default_sort_field price not present in json schema properties", err.Message)
+	})
+}
+
+func TestValidateSearchableFields(t *testing.T) {
+	t.Run("TestValidateSearchableFields_ShouldReturnNil_WhenFieldsAreDeclared", func(t *testing.T) {
+		assert.Nil(t, serviceImpl.ValidateSearchableFields(courseValidator, configRequestDto.InventoryIdentifiers, []string{"name", "course_id"}))
+		assert.Nil(t, serviceImpl.ValidateSearchableFields(courseValidator, []request_dto.InventoryIdentifier{{Key: "name", IndexType: request_dto.IndexTypeText}}, nil))
+	})
+	t.Run("TestValidateSearchableFields_ShouldReturnError_WhenFieldsCannotBeIndexed", func(t *testing.T) {
+		var expectedErr dto.ErrorResponseDto
+		expectedErr.SetError(status_code.IMS154)
+		identifiers := []request_dto.InventoryIdentifier{{Key: "course_id", IndexType: request_dto.IndexTypeText}, {Key: "search", Keys: []string{"name"}}}
+
+		err := serviceImpl.ValidateSearchableFields(courseValidator, identifiers, []string{"name", "description"})
+		assert.Equal(t, expectedErr.StatusCode, err.StatusCode)
+		assert.Equal(t, expectedErr.Message+" : description not present in json schema properties, course_id is a text identifier, only one text index allowed, identifier key search is reserved for the search index", err.Message)
+	})
+}
+
+func TestRestoreInventoryConfiguration(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryConfigurationRepo = mockRepo.NewMockIInventoryConfigurationRepository(mockController)
+	mockMongoStorageManagerClient = mockClient.NewMockIMongoStorageManager(mockController)
+
+	sut := serviceImpl.NewInventoryConfigurationService(mockInventoryConfigurationRepo, mockMongoStorageManagerClient)
+	inventoryName := "Course"
+
+	t.Run("TestRestoreInventoryConfiguration_ShouldReturnNoError_WhenNoErrorOccursInRepo", func(t *testing.T) {
+		mockInventoryConfigurationRepo.EXPECT().RestoreInventoryConfigurationByName(gomock.Any(), inventoryName).Return(nil)
+		err := sut.RestoreInventoryConfiguration(context.Background(), inventoryName)
+		assert.Nil(t, err)
+	})
+	t.Run("TestRestoreInventoryConfiguration_ShouldReturnError_WhenConfigurationNotDeleted", func(t *testing.T) {
+		var errDto dto.ErrorResponseDto
+		errDto.SetError(status_code.IMS127)
+
+		mockInventoryConfigurationRepo.EXPECT().RestoreInventoryConfigurationByName(gomock.Any(), inventoryName).Return(&errDto)
+		err := sut.RestoreInventoryConfiguration(context.Background(), inventoryName)
+		assert.Equal(t, errDto.StatusCode, err.StatusCode)
+	})
+}
+
+func TestPurgeInventoryConfiguration(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryConfigurationRepo = mockRepo.NewMockIInventoryConfigurationRepository(mockController)
+	mockMongoStorageManagerClient = mockClient.NewMockIMongoStorageManager(mockController)
+
+	sut := serviceImpl.NewInventoryConfigurationService(mockInventoryConfigurationRepo, mockMongoStorageManagerClient)
+	inventoryName := "Course"
+	var configRepoResponse = inventoryServiceDto.InventoryConfiguration{
+		InventoryName: "Course",
+		CreatedBy:     "admin",
+		CreatedOn:     createdTime,
+		IsDeleted:     true,
+	}
+
+	t.Run("TestPurgeInventoryConfiguration_ShouldDropCollectionAndPurge_WhenConfigurationDeleted", func(t *testing.T) {
+		mockInventoryConfigurationRepo.EXPECT().FetchInventoryConfigurationByName(gomock.Any(), inventoryName).Return(&configRepoResponse, nil)
+		mockMongoStorageManagerClient.EXPECT().DropCollection(gomock.Any(), inventoryName).Return(nil)
+		mockInventoryConfigurationRepo.EXPECT().PurgeInventoryConfigurationByName(gomock.Any(), inventoryName).Return(nil)
+
+		err := sut.PurgeInventoryConfiguration(context.Background(), inventoryName, inventoryName)
+		assert.Nil(t, err)
+	})
+	t.Run("TestPurgeInventoryConfiguration_ShouldReturnError_WhenConfirmationTokenMismatch", func(t *testing.T) {
+		err := sut.PurgeInventoryConfiguration(context.Background(), inventoryName, "course")
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS128).StatusCode, err.StatusCode)
+	})
+	t.Run("TestPurgeInventoryConfiguration_ShouldReturnError_WhenConfigurationNotDeleted", func(t *testing.T) {
+		activeConfiguration := configRepoResponse
+		activeConfiguration.IsDeleted = false
+		mockInventoryConfigurationRepo.EXPECT().FetchInventoryConfigurationByName(gomock.Any(), inventoryName).Return(&activeConfiguration, nil)
+
+		err := sut.PurgeInventoryConfiguration(context.Background(), inventoryName, inventoryName)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS127).StatusCode, err.StatusCode)
+	})
+	t.Run("TestPurgeInventoryConfiguration_ShouldKeepConfiguration_WhenDropCollectionFails", func(t *testing.T) {
+		var errDto dto.ErrorResponseDto
+		errDto.SetError(status_code.IMS129)
+		mockInventoryConfigurationRepo.EXPECT().FetchInventoryConfigurationByName(gomock.Any(), inventoryName).Return(&configRepoResponse, nil)
+		mockMongoStorageManagerClient.EXPECT().DropCollection(gomock.Any(), inventoryName).Return(&errDto)
+
+		err := sut.PurgeInventoryConfiguration(context.Background(), inventoryName, inventoryName)
+		assert.Equal(t, errDto.StatusCode, err.StatusCode)
+	})
+}
+
+func TestReconcileInventoryConfigurations(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryConfigurationRepo = mockRepo.NewMockIInventoryConfigurationRepository(mockController)
+	mockMongoStorageManagerClient = mockClient.NewMockIMongoStorageManager(mockController)
+
+	sut := serviceImpl.NewInventoryConfigurationService(mockInventoryConfigurationRepo, mockMongoStorageManagerClient)
+	inventoryIdentifiers := []request_dto.InventoryIdentifier{{Key: "name", IsUnique: true}}
+	var configRepoResponse = []inventoryServiceDto.InventoryConfiguration{
+		{InventoryName: "Course", JsonSchema: courseValidator, InventoryIdentifiers: inventoryIdentifiers},
+		{InventoryName: "Lesson", JsonSchema: courseValidator, InventoryIdentifiers: inventoryIdentifiers, ValidationLevel: "moderate"},
+		{InventoryName: "Archived", JsonSchema: courseValidator, IsDeleted: true},
+	}
+	courseDrift := inventoryServiceDto.InventoryDrift{InventoryName: "Course"}
+	lessonDrift := inventoryServiceDto.InventoryDrift{InventoryName: "Lesson", MissingIndexes: []string{"idx_name"}, IsValidatorDrift: true}
+
+	t.Run("TestReconcileInventoryConfigurations_ShouldReportDrift_WhenApplyIsFalse", func(t *testing.T) {
+		mockInventoryConfigurationRepo.EXPECT().FetchAllInventoryConfiguration(gomock.Any()).Return(configRepoResponse, nil)
+		mockMongoStorageManagerClient.EXPECT().ListInventoryCollections(gomock.Any()).Return([]string{"Archived", "Lesson", "Orphan"}, nil)
+		mockMongoStorageManagerClient.EXPECT().InspectCollection(gomock.Any(), "Lesson", courseValidator, inventoryIdentifiers).Return(&lessonDrift, nil)
+
+		report, err := sut.ReconcileInventoryConfigurations(context.Background(), false)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Orphan"}, report.OrphanCollections)
+		assert.Equal(t, []string{"Course"}, report.MissingCollections)
+		assert.Equal(t, []inventoryServiceDto.InventoryDrift{lessonDrift}, report.InventoryDrifts)
+		assert.False(t, report.IsApplied)
+		assert.Empty(t, report.RepairedInventories)
+	})
+	t.Run("TestReconcileInventoryConfigurations_ShouldRepairDrift_WhenApplyIsTrue", func(t *testing.T) {
+		var errDto dto.ErrorResponseDto
+		errDto.SetError(status_code.IMS102)
+		mockInventoryConfigurationRepo.EXPECT().FetchAllInventoryConfiguration(gomock.Any()).Return(configRepoResponse, nil)
+		mockMongoStorageManagerClient.EXPECT().ListInventoryCollections(gomock.Any()).Return([]string{"Lesson"}, nil)
+		mockMongoStorageManagerClient.EXPECT().CreateCollection(gomock.Any(), "Course", courseValidator, inventoryIdentifiers).Return(&errDto)
+		mockMongoStorageManagerClient.EXPECT().InspectCollection(gomock.Any(), "Lesson", courseValidator, inventoryIdentifiers).Return(&lessonDrift, nil)
+		mockMongoStorageManagerClient.EXPECT().UpdateCollection(gomock.Any(), "Lesson", courseValidator, "moderate", inventoryIdentifiers).Return(nil)
+
+		report, err := sut.ReconcileInventoryConfigurations(context.Background(), true)
+		assert.Nil(t, err)
+		assert.True(t, report.IsApplied)
+		assert.Equal(t, []string{"Lesson"}, report.RepairedInventories)
+		assert.Equal(t, []string{"Course: " + errDto.Message}, report.RepairFailures)
+	})
+	t.Run("TestReconcileInventoryConfigurations_ShouldSkipRepair_WhenCollectionIsInSync", func(t *testing.T) {
+		mockInventoryConfigurationRepo.EXPECT().FetchAllInventoryConfiguration(gomock.Any()).Return(configRepoResponse[:1], nil)
+		mockMongoStorageManagerClient.EXPECT().ListInventoryCollections(gomock.Any()).Return([]string{"Course"}, nil)
+		mockMongoStorageManagerClient.EXPECT().InspectCollection(gomock.Any(), "Course", courseValidator, inventoryIdentifiers).Return(&courseDrift, nil)
+
+		report, err := sut.ReconcileInventoryConfigurations(context.Background(), true)
+		assert.Nil(t, err)
+		assert.Empty(t, report.InventoryDrifts)
+		assert.Empty(t, report.RepairedInventories)
+	})
+	t.Run("TestReconcileInventoryConfigurations_ShouldReturnError_WhenListingCollectionsFails", func(t *testing.T) {
+		var errDto dto.ErrorResponseDto
+		errDto.SetError(status_code.IMS134)
+		mockInventoryConfigurationRepo.EXPECT().FetchAllInventoryConfiguration(gomock.Any()).Return(configRepoResponse, nil)
+		mockMongoStorageManagerClient.EXPECT().ListInventoryCollections(gomock.Any()).Return(nil, &errDto)
+
+		report, err := sut.ReconcileInventoryConfigurations(context.Background(), false)
+		assert.Nil(t, report)
+		assert.Equal(t, errDto.StatusCode, err.StatusCode)
+	})
+}
@@ -1,18 +1,30 @@
 package tests
 
 import (
+	"bytes"
 	"context"
+	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson"
+	"inventory-system/common/pkg/constants"
 	"inventory-system/common/pkg/dto"
 	"inventory-system/common/pkg/logger"
+	"inventory-system/inventory-service/internal/adapters/models"
 	mockRepo "inventory-system/inventory-service/internal/adapters/repository/mocks"
+	"inventory-system/inventory-service/internal/common/cursor"
+	commonDto "inventory-system/inventory-service/internal/common/dto"
+	"inventory-system/inventory-service/internal/common/dto/request_dto"
 	"inventory-system/inventory-service/internal/common/dto/response_dto"
+	"inventory-system/inventory-service/internal/common/patch"
 	"inventory-system/inventory-service/internal/common/status_code"
 	serviceImpl "inventory-system/inventory-service/internal/domain/service/impl"
 	mockServices "inventory-system/inventory-service/internal/domain/service/mocks"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func init() {
@@ -29,30 +41,128 @@ func TestCreateNewInventory(t *testing.T) {
 	mockInventoryRepo = mockRepo.NewMockIInventoryRepository(mockController)
 	mockInventoryConfigurationService = mockServices.NewMockIInventoryConfigurationService(mockController)
 
-	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService)
+	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService, nil)
 	inventoryName := "Course"
-	var item interface{}
+	var item interface{} = map[string]interface{}{"name": "DSA", "id": "client-id", "version": float64(7), "is_deleted": true}
 	var serviceResponse = response_dto.InventoryConfigurationResponseDto{
 		InventoryName:        "Course",
-		InventoryIdentifiers: []string{"name", "course_id"},
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "name"}, {Key: "course_id"}},
 		CreatedBy:            "admin",
 		CreatedOn:            createdTime,
 		JsonSchema:           map[string]interface{}{},
 	}
 
 	t.Run("TestCreateNewInventory_ShouldReturnNilError_WhenNoErrorOccursInFetchConfigAndCreateItem", func(t *testing.T) {
+		var storedItem bson.M
 		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
-		mockInventoryRepo.EXPECT().CreateNewInventoryGivenInventoryName(gomock.Any(), item, inventoryName).Return(nil)
-		err := sut.CreateNewInventory(context.Background(), item, inventoryName)
+		mockInventoryRepo.EXPECT().CreateNewInventoryGivenInventoryName(gomock.Any(), gomock.Any(), inventoryName).DoAndReturn(func(ctx context.Context, item interface{}, inventoryName string) *dto.ErrorResponseDto {
+			storedItem = item.(bson.M)
+			return nil
+		})
+		var revisions []commonDto.ItemRevision
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).DoAndReturn(func(ctx context.Context, inventoryName string, itemRevisions []commonDto.ItemRevision) *dto.ErrorResponseDto {
+			revisions = itemRevisions
+			return nil
+		})
+		createdItem, err := sut.CreateNewInventory(context.Background(), item, inventoryName, "admin")
 
 		assert.Nil(t, err)
+		assert.Equal(t, storedItem, createdItem)
+		assert.Equal(t, "DSA", createdItem["name"])
+		assert.NotEqual(t, "client-id", createdItem["id"])
+		assert.Len(t, createdItem["id"], 36)
+		assert.Equal(t, int64(1), createdItem["version"])
+		assert.Equal(t, "admin", createdItem["created_by"])
+		assert.Equal(t, "admin", createdItem["updated_by"])
+		assert.Equal(t, false, createdItem["is_deleted"])
+		assert.IsType(t, time.Time{}, createdItem["created_at"])
+		assert.Equal(t, createdItem["created_at"], createdItem["updated_at"])
+		assert.Len(t, revisions, 1)
+		assert.Equal(t, commonDto.ItemRevisionCreate, revisions[0].Operation)
+		assert.Equal(t, createdItem["id"], revisions[0].ItemId)
+		assert.Equal(t, int64(1), revisions[0].Version)
+		assert.Equal(t, "admin", revisions[0].CreatedBy)
+		assert.Equal(t, createdItem, revisions[0].Item)
+	})
+	t.Run("TestCreateNewInventory_ShouldStoreWholeNumbersAsIntegers_WhenSchemaDeclaresIntOrLong", func(t *testing.T) {
+		var storedItem bson.M
+		var typedResponse = serviceResponse
+		typedResponse.JsonSchema = map[string]interface{}{"$jsonSchema": map[string]interface{}{
+			"bsonType": "object",
+			"properties": map[string]interface{}{
+				"level":    map[string]interface{}{"bsonType": "int"},
+				"duration": map[string]interface{}{"bsonType": "long"},
+				"price":    map[string]interface{}{"bsonType": "double"},
+			},
+		}}
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&typedResponse, nil)
+		mockInventoryRepo.EXPECT().CreateNewInventoryGivenInventoryName(gomock.Any(), gomock.Any(), inventoryName).DoAndReturn(func(ctx context.Context, item interface{}, inventoryName string) *dto.ErrorResponseDto {
+			storedItem = item.(bson.M)
+			return nil
+		})
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).Return(nil)
+		_, err := sut.CreateNewInventory(context.Background(), map[string]interface{}{"level": float64(2), "duration": float64(90), "price": float64(10)}, inventoryName, "admin")
+
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), storedItem["level"])
+		assert.Equal(t, int64(90), storedItem["duration"])
+		assert.Equal(t, float64(10), storedItem["price"])
+	})
+	t.Run("TestCreateNewInventory_ShouldUseSequenceId_WhenConfigurationUsesSequenceStrategy", func(t *testing.T) {
+		var sequenceResponse = serviceResponse
+		sequenceResponse.IdStrategy = request_dto.IdStrategySequence
+		sequenceResponse.IdPrefix = "CRS-"
+
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&sequenceResponse, nil)
+		mockInventoryRepo.EXPECT().NextInventorySequence(gomock.Any(), inventoryName).Return(int64(42), nil)
+		mockInventoryRepo.EXPECT().CreateNewInventoryGivenInventoryName(gomock.Any(), gomock.Any(), inventoryName).Return(nil)
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).Return(nil)
+		createdItem, err := sut.CreateNewInventory(context.Background(), item, inventoryName, "admin")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "CRS-42", createdItem["id"])
+	})
+	t.Run("TestCreateNewInventory_ShouldUseUlid_WhenConfigurationUsesUlidStrategy", func(t *testing.T) {
+		var ulidResponse = serviceResponse
+		ulidResponse.IdStrategy = request_dto.IdStrategyULID
+
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&ulidResponse, nil)
+		mockInventoryRepo.EXPECT().CreateNewInventoryGivenInventoryName(gomock.Any(), gomock.Any(), inventoryName).Return(nil)
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).Return(nil)
+		createdItem, err := sut.CreateNewInventory(context.Background(), item, inventoryName, "admin")
+
+		assert.Nil(t, err)
+		assert.Regexp(t, "^[0-9A-HJKMNP-TV-Z]{26}$", createdItem["id"])
+	})
+	t.Run("TestCreateNewInventory_ShouldReturnError_WhenItemIsNotAnObject", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		createdItem, err := sut.CreateNewInventory(context.Background(), []interface{}{"DSA"}, inventoryName, "admin")
+
+		assert.Nil(t, createdItem)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS400).StatusCode, err.StatusCode)
+	})
+	t.Run("TestCreateNewInventory_ShouldReturnFieldErrors_WhenItemFailsSchemaValidation", func(t *testing.T) {
+		var schemaResponse = serviceResponse
+		schemaResponse.JsonSchema = map[string]interface{}{"$jsonSchema": map[string]interface{}{
+			"bsonType":   "object",
+			"required":   []interface{}{"name"},
+			"properties": map[string]interface{}{"price": map[string]interface{}{"bsonType": "number", "minimum": float64(0)}},
+		}}
+		var expectedErr dto.ErrorResponseDto
+		expectedErr.SetError(status_code.IMS109)
+
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&schemaResponse, nil)
+		_, err := sut.CreateNewInventory(context.Background(), map[string]interface{}{"price": float64(-5)}, inventoryName, "admin")
+
+		assert.Equal(t, expectedErr.StatusCode, err.StatusCode)
+		assert.Equal(t, expectedErr.Message+" : /name: required, /price: minimum 0", err.Message)
 	})
 	t.Run("TestCreateNewInventory_ShouldReturnError_WhenNoErrorOccursInFetchConfig", func(t *testing.T) {
 		var errorDto dto.ErrorResponseDto
 		errorDto.SetError(status_code.IMS204)
 
 		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(nil, &errorDto)
-		err := sut.CreateNewInventory(context.Background(), item, inventoryName)
+		_, err := sut.CreateNewInventory(context.Background(), item, inventoryName, "admin")
 
 		assert.Equal(t, err.StatusCode, errorDto.StatusCode)
 		assert.Equal(t, err.Message, errorDto.Message)
@@ -62,14 +172,198 @@ func TestCreateNewInventory(t *testing.T) {
 		errorDto.SetError(status_code.IMS500)
 
 		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
-		mockInventoryRepo.EXPECT().CreateNewInventoryGivenInventoryName(gomock.Any(), item, inventoryName).Return(&errorDto)
-		err := sut.CreateNewInventory(context.Background(), item, inventoryName)
+		mockInventoryRepo.EXPECT().CreateNewInventoryGivenInventoryName(gomock.Any(), gomock.Any(), inventoryName).Return(&errorDto)
+		_, err := sut.CreateNewInventory(context.Background(), item, inventoryName, "admin")
 
 		assert.Equal(t, err.StatusCode, errorDto.StatusCode)
 		assert.Equal(t, err.Message, errorDto.Message)
 	})
 
 }
+func TestBulkCreateInventory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryRepo = mockRepo.NewMockIInventoryRepository(mockController)
+	mockInventoryConfigurationService = mockServices.NewMockIInventoryConfigurationService(mockController)
+
+	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService, nil)
+	inventoryName := "Course"
+	var serviceResponse = response_dto.InventoryConfigurationResponseDto{
+		InventoryName: "Course",
+		IdStrategy:    request_dto.IdStrategySequence,
+		JsonSchema: map[string]interface{}{"$jsonSchema": map[string]interface{}{
+			"bsonType": "object",
+			"required": []interface{}{"name"},
+		}},
+	}
+	items := []interface{}{
+		map[string]interface{}{"name": "DSA"},
+		"not an object",
+		map[string]interface{}{"price": float64(5)},
+		map[string]interface{}{"name": "DSA"},
+		map[string]interface{}{"name": "OS"},
+	}
+	var duplicateErr dto.ErrorResponseDto
+	duplicateErr.SetError(status_code.IMS108)
+
+	t.Run("TestBulkCreateInventory_ShouldReportEveryItem_WhenUnordered", func(t *testing.T) {
+		var writtenItems []interface{}
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().NextInventorySequence(gomock.Any(), inventoryName).Return(int64(1), nil)
+		mockInventoryRepo.EXPECT().NextInventorySequence(gomock.Any(), inventoryName).Return(int64(2), nil)
+		mockInventoryRepo.EXPECT().NextInventorySequence(gomock.Any(), inventoryName).Return(int64(3), nil)
+		mockInventoryRepo.EXPECT().NextInventorySequence(gomock.Any(), inventoryName).Return(int64(4), nil)
+		mockInventoryRepo.EXPECT().BulkInsertInventory(gomock.Any(), gomock.Any(), inventoryName, false).DoAndReturn(func(ctx context.Context, items []interface{}, inventoryName string, ordered bool) (map[int]*dto.ErrorResponseDto, *dto.ErrorResponseDto) {
+			writtenItems = items
+			return map[int]*dto.ErrorResponseDto{1: &duplicateErr}, nil
+		})
+		var revisions []commonDto.ItemRevision
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).DoAndReturn(func(ctx context.Context, inventoryName string, itemRevisions []commonDto.ItemRevision) *dto.ErrorResponseDto {
+			revisions = itemRevisions
+			return nil
+		})
+		report, err := sut.BulkCreateInventory(context.Background(), items, inventoryName, "admin", false)
+
+		assert.Nil(t, err)
+		assert.Len(t, writtenItems, 3)
+		assert.Equal(t, 2, report.InsertedCount)
+		assert.Equal(t, 3, report.FailedCount)
+		assert.Equal(t, 0, report.SkippedCount)
+		assert.Equal(t, []string{"1", "", "", "", "4"}, []string{report.Results[0].Id, report.Results[1].Id, report.Results[2].Id, report.Results[3].Id, report.Results[4].Id})
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS400).StatusCode, report.Results[1].StatusCode)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS109).StatusCode, report.Results[2].StatusCode)
+		assert.Equal(t, duplicateErr.StatusCode, report.Results[3].StatusCode)
+		assert.Equal(t, []string{"1", "4"}, []string{revisions[0].ItemId, revisions[1].ItemId})
+	})
+	t.Run("TestBulkCreateInventory_ShouldStopAtFirstFailure_WhenOrdered", func(t *testing.T) {
+		var writtenItems []interface{}
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().NextInventorySequence(gomock.Any(), inventoryName).Return(int64(1), nil)
+		mockInventoryRepo.EXPECT().BulkInsertInventory(gomock.Any(), gomock.Any(), inventoryName, true).DoAndReturn(func(ctx context.Context, items []interface{}, inventoryName string, ordered bool) (map[int]*dto.ErrorResponseDto, *dto.ErrorResponseDto) {
+			writtenItems = items
+			return map[int]*dto.ErrorResponseDto{}, nil
+		})
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).Return(nil)
+		report, err := sut.BulkCreateInventory(context.Background(), items, inventoryName, "admin", true)
+
+		assert.Nil(t, err)
+		assert.Len(t, writtenItems, 1)
+		assert.Equal(t, 1, report.InsertedCount)
+		assert.Equal(t, 1, report.FailedCount)
+		assert.Equal(t, 3, report.SkippedCount)
+		assert.Equal(t, 1, report.Results[1].Index)
+	})
+	t.Run("TestBulkCreateInventory_ShouldDropResultsAfterWriteFailure_WhenOrdered", func(t *testing.T) {
+		objectItems := []interface{}{items[0], items[3], items[4]}
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().NextInventorySequence(gomock.Any(), inventoryName).Return(int64(1), nil).Times(3)
+		mockInventoryRepo.EXPECT().BulkInsertInventory(gomock.Any(), gomock.Any(), inventoryName, true).Return(map[int]*dto.ErrorResponseDto{1: &duplicateErr}, nil)
+		var revisions []commonDto.ItemRevision
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).DoAndReturn(func(ctx context.Context, inventoryName string, itemRevisions []commonDto.ItemRevision) *dto.ErrorResponseDto {
+			revisions = itemRevisions
+			return nil
+		})
+		report, err := sut.BulkCreateInventory(context.Background(), objectItems, inventoryName, "admin", true)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, report.InsertedCount)
+		assert.Equal(t, 1, report.FailedCount)
+		assert.Equal(t, 1, report.SkippedCount)
+		assert.Len(t, report.Results, 2)
+		//the item after the failure was never written and has no revision
+		assert.Len(t, revisions, 1)
+	})
+	t.Run("TestBulkCreateInventory_ShouldReturnError_WhenNoItemsGiven", func(t *testing.T) {
+		report, err := sut.BulkCreateInventory(context.Background(), nil, inventoryName, "admin", false)
+
+		assert.Nil(t, report)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS139).StatusCode, err.StatusCode)
+	})
+}
+
+func TestUpsertInventory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryRepo = mockRepo.NewMockIInventoryRepository(mockController)
+	mockInventoryConfigurationService = mockServices.NewMockIInventoryConfigurationService(mockController)
+
+	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService, nil)
+	inventoryName := "Course"
+	item := map[string]interface{}{"course_id": "C1", "name": "DSA", "id": "client-id"}
+	var serviceResponse = response_dto.InventoryConfigurationResponseDto{
+		InventoryName:        "Course",
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "course_id", IsUnique: true}, {Key: "name"}},
+		JsonSchema:           map[string]interface{}{},
+	}
+	var notFoundErr dto.ErrorResponseDto
+	notFoundErr.SetError(status_code.IMS404)
+
+	t.Run("TestUpsertInventory_ShouldReplaceItem_WhenKeyMatches", func(t *testing.T) {
+		var replacement bson.M
+		storedItem := bson.M{"id": "1", "course_id": "C1", "version": int64(3)}
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"course_id": "C1"}, nil).Return(storedItem, nil)
+		mockInventoryRepo.EXPECT().ReplaceInventoryItem(gomock.Any(), inventoryName, bson.M{"course_id": "C1"}, gomock.Any()).DoAndReturn(func(ctx context.Context, inventoryName string, uniqueFilter bson.M, item bson.M) (bson.M, *dto.ErrorResponseDto) {
+			replacement = item
+			return bson.M{"id": "1", "course_id": "C1", "version": int64(4)}, nil
+		})
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).Return(nil)
+		upsertedItem, isCreated, err := sut.UpsertInventory(context.Background(), item, inventoryName, "course_id", "editor")
+
+		assert.Nil(t, err)
+		assert.False(t, isCreated)
+		assert.Equal(t, "1", upsertedItem["id"])
+		assert.NotContains(t, replacement, "id")
+		assert.Equal(t, "editor", replacement["updated_by"])
+		assert.Equal(t, false, replacement["is_deleted"])
+	})
+	t.Run("TestUpsertInventory_ShouldCreateItem_WhenKeyDoesNotMatch", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"course_id": "C1"}, nil).Return(nil, &notFoundErr)
+		mockInventoryRepo.EXPECT().CreateNewInventoryGivenInventoryName(gomock.Any(), gomock.Any(), inventoryName).Return(nil)
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).Return(nil)
+		upsertedItem, isCreated, err := sut.UpsertInventory(context.Background(), item, inventoryName, "course_id", "editor")
+
+		assert.Nil(t, err)
+		assert.True(t, isCreated)
+		assert.NotEqual(t, "client-id", upsertedItem["id"])
+		assert.Equal(t, int64(1), upsertedItem["version"])
+	})
+	t.Run("TestUpsertInventory_ShouldReplaceItem_WhenCreateLosesRace", func(t *testing.T) {
+		var duplicateErr dto.ErrorResponseDto
+		duplicateErr.SetError(status_code.IMS108)
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		gomock.InOrder(
+			mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"course_id": "C1"}, nil).Return(nil, &notFoundErr),
+			mockInventoryRepo.EXPECT().CreateNewInventoryGivenInventoryName(gomock.Any(), gomock.Any(), inventoryName).Return(&duplicateErr),
+			mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"course_id": "C1"}, nil).Return(bson.M{"id": "2", "course_id": "C1"}, nil),
+			mockInventoryRepo.EXPECT().ReplaceInventoryItem(gomock.Any(), inventoryName, bson.M{"course_id": "C1"}, gomock.Any()).Return(bson.M{"id": "2"}, nil),
+		)
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).Return(nil)
+		upsertedItem, isCreated, err := sut.UpsertInventory(context.Background(), item, inventoryName, "course_id", "editor")
+
+		assert.Nil(t, err)
+		assert.False(t, isCreated)
+		assert.Equal(t, "2", upsertedItem["id"])
+	})
+	t.Run("TestUpsertInventory_ShouldReturnError_WhenKeyIsNotUnique", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		upsertedItem, _, err := sut.UpsertInventory(context.Background(), item, inventoryName, "name", "editor")
+
+		assert.Nil(t, upsertedItem)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS140).StatusCode, err.StatusCode)
+	})
+	t.Run("TestUpsertInventory_ShouldReturnError_WhenItemHasNoKeyValue", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		upsertedItem, _, err := sut.UpsertInventory(context.Background(), map[string]interface{}{"name": "DSA"}, inventoryName, "course_id", "editor")
+
+		assert.Nil(t, upsertedItem)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS400).StatusCode, err.StatusCode)
+	})
+}
+
 func TestGetInventory(t *testing.T) {
 	var mockController = gomock.NewController(t)
 	defer mockController.Finish()
@@ -77,7 +371,7 @@ func TestGetInventory(t *testing.T) {
 	mockInventoryRepo = mockRepo.NewMockIInventoryRepository(mockController)
 	mockInventoryConfigurationService = mockServices.NewMockIInventoryConfigurationService(mockController)
 
-	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService)
+	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService, nil)
 	inventoryName := "Course"
 
 	item := bson.M{"course_name": "DSA", "course_id": "123456", "duration": 2}
@@ -85,7 +379,7 @@ func TestGetInventory(t *testing.T) {
 	t.Run("TestGetInventory_ShouldReturnNilError_WhenNoErrorOccurs", func(t *testing.T) {
 		var serviceResponse = response_dto.InventoryConfigurationResponseDto{
 			InventoryName:        "Course",
-			InventoryIdentifiers: []string{"course_name", "course_id"},
+			InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "course_name", IsUnique: true}, {Key: "course_id"}},
 			CreatedBy:            "admin",
 			CreatedOn:            createdTime,
 			JsonSchema:           map[string]interface{}{},
@@ -95,12 +389,34 @@ func TestGetInventory(t *testing.T) {
 		item = bson.M{"course_name": "DSA", "course_id": "123456", "duration": 2}
 
 		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
-		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, gomock.Any(), gomock.Any()).Return(item, nil)
-		resp, err := sut.GetInventory(context.Background(), inventoryName, filterMap)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, gomock.Any(), nil).Return(item, nil)
+		resp, err := sut.GetInventory(context.Background(), inventoryName, filterMap, "", "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, resp, item)
 	})
+	t.Run("TestGetInventory_ShouldProjectFields_KeepingIdAndVersion", func(t *testing.T) {
+		var serviceResponse = response_dto.InventoryConfigurationResponseDto{
+			InventoryName:        "Course",
+			InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "course_name", IsUnique: true}},
+		}
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"course_name": "DSA"}, bson.M{"_id": 0, "course_name": 1, "id": 1, "version": 1}).Return(bson.M{"id": "1", "course_name": "DSA"}, nil)
+		_, err := sut.GetInventory(context.Background(), inventoryName, map[string][]string{"course_name": {"DSA"}}, "course_name", "")
+
+		assert.Nil(t, err)
+	})
+	t.Run("TestGetInventory_ShouldReturnStatus153_WhenProjectionIsInvalid", func(t *testing.T) {
+		var serviceResponse = response_dto.InventoryConfigurationResponseDto{
+			InventoryName:        "Course",
+			InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "course_name", IsUnique: true}},
+		}
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		_, err := sut.GetInventory(context.Background(), inventoryName, map[string][]string{"course_name": {"DSA"}}, "", "version")
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS153).StatusCode, err.StatusCode)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS153).Message+" : version cannot be excluded", err.Message)
+	})
 	t.Run("TestGetInventory_ShouldReturnError_WhenMoreThan1AttributeInFilterMap", func(t *testing.T) {
 		filterMap := make(map[string][]string)
 		filterMap["course_name"] = append(filterMap["course_name"], "123")
@@ -108,11 +424,39 @@ func TestGetInventory(t *testing.T) {
 		var expectedErr dto.ErrorResponseDto
 		expectedErr.SetError(status_code.IMS112)
 		filterMap["course_id"] = append(filterMap["course_id"], "123")
-		resp, err := sut.GetInventory(context.Background(), inventoryName, filterMap)
+		var serviceResponse = response_dto.InventoryConfigurationResponseDto{
+			InventoryName:        "Course",
+			InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "course_name", IsUnique: true}, {Key: "course_id"}},
+			CreatedBy:            "admin",
+			CreatedOn:            createdTime,
+			JsonSchema:           map[string]interface{}{},
+		}
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		resp, err := sut.GetInventory(context.Background(), inventoryName, filterMap, "", "")
 		assert.Nil(t, resp)
 		assert.Equal(t, err.StatusCode, expectedErr.StatusCode)
 		assert.Equal(t, err.Message, expectedErr.Message)
 	})
+	t.Run("TestGetInventory_ShouldReturnNilError_WhenFilterMatchesCompoundUniqueIdentifier", func(t *testing.T) {
+		var serviceResponse = response_dto.InventoryConfigurationResponseDto{
+			InventoryName:        "Course",
+			InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "course_name_id", Keys: []string{"course_name", "course_id"}, IsUnique: true}},
+			CreatedBy:            "admin",
+			CreatedOn:            createdTime,
+			JsonSchema:           map[string]interface{}{},
+		}
+		filterMap := make(map[string][]string)
+		filterMap["course_name"] = append(filterMap["course_name"], "DSA")
+		filterMap["course_id"] = append(filterMap["course_id"], "123456")
+		item = bson.M{"course_name": "DSA", "course_id": "123456", "duration": 2}
+
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"course_name": "DSA", "course_id": "123456"}, nil).Return(item, nil)
+		resp, err := sut.GetInventory(context.Background(), inventoryName, filterMap, "", "")
+
+		assert.Nil(t, err)
+		assert.Equal(t, resp, item)
+	})
 	t.Run("TestGetInventory_ShouldReturnError_WhenConfigurationIsDeleted", func(t *testing.T) {
 		filterMap := make(map[string][]string)
 		filterMap["course_name"] = append(filterMap["course_name"], "123")
@@ -120,7 +464,7 @@ func TestGetInventory(t *testing.T) {
 
 		var serviceResponse = response_dto.InventoryConfigurationResponseDto{
 			InventoryName:        "Course",
-			InventoryIdentifiers: []string{"course_name", "course_id"},
+			InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "course_name", IsUnique: true}, {Key: "course_id"}},
 			CreatedBy:            "admin",
 			CreatedOn:            createdTime,
 			JsonSchema:           map[string]interface{}{},
@@ -129,7 +473,7 @@ func TestGetInventory(t *testing.T) {
 		expectedErr.SetError(status_code.IMS204)
 		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, &expectedErr)
 
-		resp, err := sut.GetInventory(context.Background(), inventoryName, filterMap)
+		resp, err := sut.GetInventory(context.Background(), inventoryName, filterMap, "", "")
 		assert.Nil(t, resp)
 		assert.Equal(t, err.StatusCode, expectedErr.StatusCode)
 		assert.Equal(t, err.Message, expectedErr.Message)
@@ -141,7 +485,7 @@ func TestGetInventory(t *testing.T) {
 
 		var serviceResponse = response_dto.InventoryConfigurationResponseDto{
 			InventoryName:        "Course",
-			InventoryIdentifiers: []string{"course_name", "course_id"},
+			InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "course_name", IsUnique: true}, {Key: "course_id"}},
 			CreatedBy:            "admin",
 			CreatedOn:            createdTime,
 			JsonSchema:           map[string]interface{}{},
@@ -150,7 +494,7 @@ func TestGetInventory(t *testing.T) {
 		expectedErr.SetError(status_code.IMS500)
 		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, &expectedErr)
 
-		resp, err := sut.GetInventory(context.Background(), inventoryName, filterMap)
+		resp, err := sut.GetInventory(context.Background(), inventoryName, filterMap, "", "")
 		assert.Nil(t, resp)
 		assert.Equal(t, err.StatusCode, expectedErr.StatusCode)
 		assert.Equal(t, err.Message, expectedErr.Message)
@@ -159,7 +503,7 @@ func TestGetInventory(t *testing.T) {
 	t.Run("TestGetInventory_ShouldReturnError_WhenFilterAttributeNotInventoryIdentifierList", func(t *testing.T) {
 		var serviceResponse = response_dto.InventoryConfigurationResponseDto{
 			InventoryName:        "Course",
-			InventoryIdentifiers: []string{"course_name", "course_id"},
+			InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "course_name", IsUnique: true}, {Key: "course_id"}},
 			CreatedBy:            "admin",
 			CreatedOn:            createdTime,
 			JsonSchema:           map[string]interface{}{},
@@ -168,12 +512,12 @@ func TestGetInventory(t *testing.T) {
 		filterMap["course_name"] = append(filterMap["course_name"], "123")
 
 		var expectedErr dto.ErrorResponseDto
-		expectedErr.SetError(status_code.IMS113)
+		expectedErr.SetError(status_code.IMS115)
 
-		serviceResponse.InventoryIdentifiers = []string{"dummy"}
+		serviceResponse.InventoryIdentifiers = []request_dto.InventoryIdentifier{{Key: "dummy"}}
 
 		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
-		resp, err := sut.GetInventory(context.Background(), inventoryName, filterMap)
+		resp, err := sut.GetInventory(context.Background(), inventoryName, filterMap, "", "")
 		assert.Nil(t, resp)
 		assert.Equal(t, err.StatusCode, expectedErr.StatusCode)
 		assert.Equal(t, err.Message, expectedErr.Message)
@@ -181,7 +525,7 @@ func TestGetInventory(t *testing.T) {
 	t.Run("TestGetInventory_ShouldReturnError_WhenFetchFromRepoReturnError", func(t *testing.T) {
 		var serviceResponse = response_dto.InventoryConfigurationResponseDto{
 			InventoryName:        "Course",
-			InventoryIdentifiers: []string{"course_name", "course_id"},
+			InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "course_name", IsUnique: true}, {Key: "course_id"}},
 			CreatedBy:            "admin",
 			CreatedOn:            createdTime,
 			JsonSchema:           map[string]interface{}{},
@@ -194,12 +538,1206 @@ func TestGetInventory(t *testing.T) {
 		expectedErr.SetError(status_code.IMS500)
 
 		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
-		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, gomock.Any(), gomock.Any()).Return(item, &expectedErr)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, gomock.Any(), nil).Return(item, &expectedErr)
 
-		resp, err := sut.GetInventory(context.Background(), inventoryName, filterMap)
+		resp, err := sut.GetInventory(context.Background(), inventoryName, filterMap, "", "")
 		assert.Nil(t, resp)
 		assert.Equal(t, err.StatusCode, expectedErr.StatusCode)
 		assert.Equal(t, err.Message, expectedErr.Message)
 	})
 
 }
+
+func TestUpdateInventory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryRepo = mockRepo.NewMockIInventoryRepository(mockController)
+	mockInventoryConfigurationService = mockServices.NewMockIInventoryConfigurationService(mockController)
+
+	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService, nil)
+	inventoryName := "Course"
+	var serviceResponse = response_dto.InventoryConfigurationResponseDto{
+		InventoryName:        "Course",
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "course_id", IsUnique: true}},
+		JsonSchema: map[string]interface{}{"$jsonSchema": map[string]interface{}{
+			"bsonType": "object",
+			"required": []interface{}{"course_id"},
+			"properties": map[string]interface{}{
+				"details": map[string]interface{}{
+					"bsonType":   "object",
+					"properties": map[string]interface{}{"price": map[string]interface{}{"bsonType": "number", "minimum": float64(0)}},
+				},
+			},
+		}},
+	}
+
+	t.Run("TestUpdateInventory_ShouldReturnNilError_WhenMergedDocumentIsValid", func(t *testing.T) {
+		var updateRequest interface{} = map[string]interface{}{"details.price": float64(10), "version": int64(2)}
+		var storedFields bson.M
+
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"id": "1"}, nil).Return(bson.M{"id": "1", "course_id": "C1", "version": int64(2)}, nil)
+		mockInventoryRepo.EXPECT().UpdateInventory("1", inventoryName, gomock.Any(), int64(2)).DoAndReturn(func(Id string, InventoryName string, UpdateRequest *interface{}, currentVersion interface{}) *dto.ErrorResponseDto {
+			storedFields = (*UpdateRequest).(bson.M)
+			return nil
+		})
+		var revisions []commonDto.ItemRevision
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).DoAndReturn(func(ctx context.Context, inventoryName string, itemRevisions []commonDto.ItemRevision) *dto.ErrorResponseDto {
+			revisions = itemRevisions
+			return nil
+		})
+		version, err := sut.UpdateInventory("1", inventoryName, &updateRequest, "editor", nil, request_dto.UpdateFormatSet)
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), version)
+		assert.Equal(t, float64(10), storedFields["details.price"])
+		assert.Equal(t, "editor", storedFields["updated_by"])
+		assert.IsType(t, time.Time{}, storedFields["updated_at"])
+		assert.NotContains(t, storedFields, "version")
+		assert.Equal(t, commonDto.ItemRevisionUpdate, revisions[0].Operation)
+		assert.Equal(t, int64(3), revisions[0].Version)
+		assert.Equal(t, "editor", revisions[0].CreatedBy)
+		assert.Equal(t, []patch.Operation{
+			{Op: patch.OperationAdd, Path: "/details", Value: map[string]interface{}{"price": float64(10)}},
+			{Op: patch.OperationAdd, Path: "/updated_at", Value: revisions[0].CreatedAt},
+			{Op: patch.OperationAdd, Path: "/updated_by", Value: "editor"},
+			{Op: patch.OperationReplace, Path: "/version", Value: int64(3)},
+		}, revisions[0].Changes)
+	})
+	t.Run("TestUpdateInventory_ShouldReturnFieldErrors_WhenMergedDocumentIsInvalid", func(t *testing.T) {
+		var updateRequest interface{} = map[string]interface{}{"details.price": float64(-10)}
+		var expectedErr dto.ErrorResponseDto
+		expectedErr.SetError(status_code.IMS109)
+
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"id": "1"}, nil).Return(bson.M{"id": "1", "course_id": "C1"}, nil)
+		_, err := sut.UpdateInventory("1", inventoryName, &updateRequest, "editor", nil, request_dto.UpdateFormatSet)
+
+		assert.Equal(t, expectedErr.StatusCode, err.StatusCode)
+		assert.Equal(t, expectedErr.Message+" : /details/price: minimum 0", err.Message)
+	})
+	t.Run("TestUpdateInventory_ShouldReturnConflict_WhenIfMatchVersionDiffers", func(t *testing.T) {
+		var updateRequest interface{} = map[string]interface{}{"details.price": float64(10)}
+		expectedVersion := int64(1)
+
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"id": "1"}, nil).Return(bson.M{"id": "1", "course_id": "C1", "version": int64(2)}, nil)
+		_, err := sut.UpdateInventory("1", inventoryName, &updateRequest, "editor", &expectedVersion, request_dto.UpdateFormatSet)
+
+		assert.Equal(t, serviceImpl.NewVersionConflictError(1, 2), err)
+	})
+	t.Run("TestUpdateInventory_ShouldRetry_WhenItemChangesConcurrently", func(t *testing.T) {
+		viper.Set(constants.MAX_OPTMISTIC_LOCKING_RETRY_COUNT, 1)
+		defer viper.Set(constants.MAX_OPTMISTIC_LOCKING_RETRY_COUNT, 0)
+		var updateRequest interface{} = map[string]interface{}{"details.price": float64(10)}
+		var conflictErr dto.ErrorResponseDto
+		conflictErr.SetError(status_code.IMS141)
+
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		gomock.InOrder(
+			mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"id": "1"}, nil).Return(bson.M{"id": "1", "course_id": "C1", "version": int64(2)}, nil),
+			mockInventoryRepo.EXPECT().UpdateInventory("1", inventoryName, gomock.Any(), int64(2)).Return(&conflictErr),
+			mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"id": "1"}, nil).Return(bson.M{"id": "1", "course_id": "C1", "version": int64(3)}, nil),
+			mockInventoryRepo.EXPECT().UpdateInventory("1", inventoryName, gomock.Any(), int64(3)).Return(nil),
+		)
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).Return(nil)
+		version, err := sut.UpdateInventory("1", inventoryName, &updateRequest, "editor", nil, request_dto.UpdateFormatSet)
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(4), version)
+	})
+	t.Run("TestUpdateInventory_ShouldReturnConflict_WhenRetriesAreExhausted", func(t *testing.T) {
+		var updateRequest interface{} = map[string]interface{}{"details.price": float64(10)}
+		var conflictErr dto.ErrorResponseDto
+		conflictErr.SetError(status_code.IMS141)
+
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"id": "1"}, nil).Return(bson.M{"id": "1", "course_id": "C1"}, nil)
+		mockInventoryRepo.EXPECT().UpdateInventory("1", inventoryName, gomock.Any(), nil).Return(&conflictErr)
+		_, err := sut.UpdateInventory("1", inventoryName, &updateRequest, "editor", nil, request_dto.UpdateFormatSet)
+
+		assert.Equal(t, &conflictErr, err)
+	})
+	t.Run("TestUpdateInventory_ShouldReplaceItem_WhenJSONPatchApplies", func(t *testing.T) {
+		var updateRequest interface{} = []interface{}{
+			map[string]interface{}{"op": "test", "path": "/version", "value": float64(2)},
+			map[string]interface{}{"op": "add", "path": "/resources/-", "value": map[string]interface{}{"type": "quiz"}},
+		}
+		var storedItem map[string]interface{}
+
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"id": "1"}, nil).Return(bson.M{"id": "1", "course_id": "C1", "version": int64(2), "resources": bson.A{bson.M{"type": "video"}}}, nil)
+		mockInventoryRepo.EXPECT().ReplaceInventory("1", inventoryName, gomock.Any(), int64(2)).DoAndReturn(func(Id string, InventoryName string, item map[string]interface{}, currentVersion interface{}) *dto.ErrorResponseDto {
+			storedItem = item
+			return nil
+		})
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).Return(nil)
+		version, err := sut.UpdateInventory("1", inventoryName, &updateRequest, "editor", nil, request_dto.UpdateFormatJSONPatch)
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), version)
+		assert.Equal(t, []interface{}{map[string]interface{}{"type": "video"}, map[string]interface{}{"type": "quiz"}}, storedItem["resources"])
+		assert.Equal(t, int64(3), storedItem["version"])
+		assert.Equal(t, "editor", storedItem["updated_by"])
+	})
+	t.Run("TestUpdateInventory_ShouldRemoveField_WhenMergePatchMemberIsNull", func(t *testing.T) {
+		var updateRequest interface{} = map[string]interface{}{"details": nil, "title": "DSA"}
+		var storedItem map[string]interface{}
+
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"id": "1"}, nil).Return(bson.M{"id": "1", "course_id": "C1", "version": int64(2), "details": bson.M{"price": float64(5)}}, nil)
+		mockInventoryRepo.EXPECT().ReplaceInventory("1", inventoryName, gomock.Any(), int64(2)).DoAndReturn(func(Id string, InventoryName string, item map[string]interface{}, currentVersion interface{}) *dto.ErrorResponseDto {
+			storedItem = item
+			return nil
+		})
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).Return(nil)
+		_, err := sut.UpdateInventory("1", inventoryName, &updateRequest, "editor", nil, request_dto.UpdateFormatMergePatch)
+
+		assert.Nil(t, err)
+		assert.NotContains(t, storedItem, "details")
+		assert.Equal(t, "DSA", storedItem["title"])
+		assert.Equal(t, "C1", storedItem["course_id"])
+	})
+	t.Run("TestUpdateInventory_ShouldReturnProtectedFieldError_WhenPatchChangesUniqueIdentifier", func(t *testing.T) {
+		var updateRequest interface{} = []interface{}{
+			map[string]interface{}{"op": "replace", "path": "/course_id", "value": "C2"},
+			map[string]interface{}{"op": "remove", "path": "/created_by"},
+		}
+
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"id": "1"}, nil).Return(bson.M{"id": "1", "course_id": "C1", "version": int64(2), "created_by": "owner"}, nil)
+		_, err := sut.UpdateInventory("1", inventoryName, &updateRequest, "editor", nil, request_dto.UpdateFormatJSONPatch)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS142).StatusCode, err.StatusCode)
+		assert.Contains(t, err.Message, "created_by")
+		assert.Contains(t, err.Message, "course_id")
+	})
+	t.Run("TestUpdateInventory_ShouldReturnInvalidPatch_WhenOperationFails", func(t *testing.T) {
+		var updateRequest interface{} = []interface{}{map[string]interface{}{"op": "remove", "path": "/missing"}}
+
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"id": "1"}, nil).Return(bson.M{"id": "1", "course_id": "C1", "version": int64(2)}, nil)
+		_, err := sut.UpdateInventory("1", inventoryName, &updateRequest, "editor", nil, request_dto.UpdateFormatJSONPatch)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS143).StatusCode, err.StatusCode)
+		assert.Contains(t, err.Message, "/missing does not exist")
+	})
+	t.Run("TestUpdateInventory_ShouldReturnBadRequest_WhenSetUpdateIsEmpty", func(t *testing.T) {
+		var updateRequest interface{} = map[string]interface{}{}
+
+		_, err := sut.UpdateInventory("1", inventoryName, &updateRequest, "editor", nil, request_dto.UpdateFormatSet)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS400).StatusCode, err.StatusCode)
+	})
+	t.Run("TestUpdateInventory_ShouldReturnProtectedFieldError_WhenSetUpdateChangesManagedFields", func(t *testing.T) {
+		var updateRequest interface{} = map[string]interface{}{"details.price": float64(10), "version": float64(9), "created_by.name": "x", "is_deleted": true}
+
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"id": "1"}, nil).Return(bson.M{"id": "1", "course_id": "C1", "version": int64(2), "is_deleted": false}, nil)
+		_, err := sut.UpdateInventory("1", inventoryName, &updateRequest, "editor", nil, request_dto.UpdateFormatSet)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS142).StatusCode, err.StatusCode)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS142).Message+" : created_by, version, is_deleted", err.Message)
+	})
+	t.Run("TestUpdateInventory_ShouldReturnBadRequest_WhenSetUpdateOnlyRepeatsManagedFields", func(t *testing.T) {
+		var updateRequest interface{} = map[string]interface{}{"id": "1"}
+
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"id": "1"}, nil).Return(bson.M{"id": "1", "course_id": "C1", "version": int64(2)}, nil)
+		_, err := sut.UpdateInventory("1", inventoryName, &updateRequest, "editor", nil, request_dto.UpdateFormatSet)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS400).StatusCode, err.StatusCode)
+	})
+}
+
+func TestRemoveItemFromInventory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryRepo = mockRepo.NewMockIInventoryRepository(mockController)
+	sut := serviceImpl.NewInventoryService(mockInventoryRepo, nil, nil)
+	inventoryName := "Course"
+
+	t.Run("TestRemoveItemFromInventory_ShouldRecordDeleteRevision", func(t *testing.T) {
+		var revisions []commonDto.ItemRevision
+		mockInventoryRepo.EXPECT().RemoveItemFromInventory(gomock.Any(), gomock.Any(), inventoryName, gomock.Any()).Return(bson.M{"id": "1", "name": "DSA", "version": int64(2), "is_deleted": false}, nil)
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).DoAndReturn(func(ctx context.Context, inventoryName string, itemRevisions []commonDto.ItemRevision) *dto.ErrorResponseDto {
+			revisions = itemRevisions
+			return nil
+		})
+		err := sut.RemoveItemFromInventory(context.Background(), &request_dto.RemoveInventoryItem{ItemId: "1"}, inventoryName, "editor")
+
+		assert.Nil(t, err)
+		assert.Equal(t, commonDto.ItemRevisionDelete, revisions[0].Operation)
+		assert.Equal(t, int64(3), revisions[0].Version)
+		assert.Equal(t, true, revisions[0].Item["is_deleted"])
+		assert.Contains(t, revisions[0].Changes, patch.Operation{Op: patch.OperationReplace, Path: "/is_deleted", Value: true})
+	})
+	t.Run("TestRemoveItemFromInventory_ShouldRecordDeletionMetadata", func(t *testing.T) {
+		var removeFields bson.M
+		mockInventoryRepo.EXPECT().RemoveItemFromInventory(gomock.Any(), gomock.Any(), inventoryName, gomock.Any()).DoAndReturn(func(ctx context.Context, removeItemModel *models.RemoveInventoryItem, inventoryName string, updateMetadata bson.M) (bson.M, *dto.ErrorResponseDto) {
+			removeFields = updateMetadata
+			return bson.M{"id": "1", "version": int64(2), "is_deleted": false}, nil
+		})
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).Return(nil)
+		err := sut.RemoveItemFromInventory(context.Background(), &request_dto.RemoveInventoryItem{ItemId: "1", Reason: "duplicate"}, inventoryName, "editor")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "editor", removeFields["deleted_by"])
+		assert.Equal(t, "duplicate", removeFields["deletion_reason"])
+		assert.Equal(t, removeFields["updated_at"], removeFields["deleted_at"])
+	})
+	t.Run("TestRemoveItemFromInventory_ShouldOmitReason_WhenNotGiven", func(t *testing.T) {
+		var removeFields bson.M
+		mockInventoryRepo.EXPECT().RemoveItemFromInventory(gomock.Any(), gomock.Any(), inventoryName, gomock.Any()).DoAndReturn(func(ctx context.Context, removeItemModel *models.RemoveInventoryItem, inventoryName string, updateMetadata bson.M) (bson.M, *dto.ErrorResponseDto) {
+			removeFields = updateMetadata
+			return nil, nil
+		})
+		err := sut.RemoveItemFromInventory(context.Background(), &request_dto.RemoveInventoryItem{ItemId: "1"}, inventoryName, "editor")
+
+		assert.Nil(t, err)
+		assert.NotContains(t, removeFields, "deletion_reason")
+	})
+	t.Run("TestRemoveItemFromInventory_ShouldSucceed_WhenRevisionCannotBeRecorded", func(t *testing.T) {
+		var historyErr dto.ErrorResponseDto
+		historyErr.SetError(status_code.IMS144)
+		mockInventoryRepo.EXPECT().RemoveItemFromInventory(gomock.Any(), gomock.Any(), inventoryName, gomock.Any()).Return(bson.M{"id": "1", "version": int64(2)}, nil)
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).Return(&historyErr)
+		err := sut.RemoveItemFromInventory(context.Background(), &request_dto.RemoveInventoryItem{ItemId: "1"}, inventoryName, "editor")
+
+		assert.Nil(t, err)
+	})
+}
+
+func TestActivateResourceById(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryRepo = mockRepo.NewMockIInventoryRepository(mockController)
+	mockInventoryConfigurationService = mockServices.NewMockIInventoryConfigurationService(mockController)
+
+	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService, nil)
+	inventoryName := "Course"
+	var serviceResponse = response_dto.InventoryConfigurationResponseDto{
+		InventoryName:        inventoryName,
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "course_id", IsUnique: true}, {Key: "name"}, {Key: "code", IsUnique: true}},
+	}
+	deletedItem := bson.M{"id": "1", "course_id": "C1", "name": "DSA", "version": int64(3), "is_deleted": true, "deleted_at": time.Now(), "deleted_by": "editor", "deletion_reason": "duplicate"}
+	var notFoundErr dto.ErrorResponseDto
+	notFoundErr.SetError(status_code.IMS404)
+
+	t.Run("TestActivateResourceById_ShouldRestoreAndRecordRevision", func(t *testing.T) {
+		var revisions []commonDto.ItemRevision
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventoryById(gomock.Any(), inventoryName, "1").Return(deletedItem, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"course_id": "C1"}, nil).Return(nil, &notFoundErr)
+		mockInventoryRepo.EXPECT().ActivateResourceById(gomock.Any(), inventoryName, "1", gomock.Any()).Return(deletedItem, nil)
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).DoAndReturn(func(ctx context.Context, inventoryName string, itemRevisions []commonDto.ItemRevision) *dto.ErrorResponseDto {
+			revisions = itemRevisions
+			return nil
+		})
+		err := sut.ActivateResourceById(context.Background(), inventoryName, "1", "admin")
+
+		assert.Nil(t, err)
+		assert.Equal(t, commonDto.ItemRevisionRestore, revisions[0].Operation)
+		assert.Equal(t, int64(4), revisions[0].Version)
+		assert.Equal(t, false, revisions[0].Item["is_deleted"])
+		assert.NotContains(t, revisions[0].Item, "deleted_by")
+		assert.Contains(t, revisions[0].Changes, patch.Operation{Op: patch.OperationRemove, Path: "/deletion_reason"})
+	})
+	t.Run("TestActivateResourceById_ShouldReturnNotFound_WhenIdIsUnknown", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventoryById(gomock.Any(), inventoryName, "2").Return(nil, &notFoundErr)
+		err := sut.ActivateResourceById(context.Background(), inventoryName, "2", "admin")
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS404).StatusCode, err.StatusCode)
+	})
+	t.Run("TestActivateResourceById_ShouldReturnError_WhenItemIsNotDeleted", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventoryById(gomock.Any(), inventoryName, "1").Return(bson.M{"id": "1", "course_id": "C1", "is_deleted": false}, nil)
+		err := sut.ActivateResourceById(context.Background(), inventoryName, "1", "admin")
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS147).StatusCode, err.StatusCode)
+	})
+	t.Run("TestActivateResourceById_ShouldReturnConflict_WhenLiveItemHoldsUniqueIdentifier", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventoryById(gomock.Any(), inventoryName, "1").Return(deletedItem, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"course_id": "C1"}, nil).Return(bson.M{"id": "5", "course_id": "C1"}, nil)
+		err := sut.ActivateResourceById(context.Background(), inventoryName, "1", "admin")
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS148).StatusCode, err.StatusCode)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS148).Message+" : course_id", err.Message)
+	})
+}
+
+func TestGetInventoryTrash(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryRepo = mockRepo.NewMockIInventoryRepository(mockController)
+	mockInventoryConfigurationService = mockServices.NewMockIInventoryConfigurationService(mockController)
+
+	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService, nil)
+	inventoryName := "Course"
+	var serviceResponse = response_dto.InventoryConfigurationResponseDto{InventoryName: inventoryName}
+
+	t.Run("TestGetInventoryTrash_ShouldPaginateByDeletionTime_WhenInventoryIsNotPaginated", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchDeletedInventoryList(gomock.Any(), inventoryName, commonDto.Pagination{Pagination: true, PageNumber: 1, PageSize: 5, SortField: "deleted_at", SortDirection: -1}).
+			Return([]bson.M{{"id": "1"}}, &commonDto.PaginationResponse{Count: 6, PageNumber: 1, PageSize: 5}, nil)
+		items, pagination, err := sut.GetInventoryTrash(context.Background(), inventoryName, "1", "5")
+
+		assert.Nil(t, err)
+		assert.Len(t, items, 1)
+		assert.Equal(t, int64(6), pagination.Count)
+	})
+	t.Run("TestGetInventoryTrash_ShouldReturnError_WhenPageIsInvalid", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		_, _, err := sut.GetInventoryTrash(context.Background(), inventoryName, "-1", "")
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS138).StatusCode, err.StatusCode)
+	})
+}
+
+func TestGetInventoryV2(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryRepo = mockRepo.NewMockIInventoryRepository(mockController)
+	mockInventoryConfigurationService = mockServices.NewMockIInventoryConfigurationService(mockController)
+
+	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService, nil)
+	inventoryName := "Course"
+	var serviceResponse = response_dto.InventoryConfigurationResponseDto{
+		InventoryName: inventoryName,
+		JsonSchema: map[string]interface{}{"$jsonSchema": map[string]interface{}{
+			"bsonType":   "object",
+			"properties": map[string]interface{}{"name": map[string]interface{}{"bsonType": "string"}, "price": map[string]interface{}{"bsonType": "double"}},
+		}},
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "name"}, {Key: "price"}},
+	}
+	ginContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ginContext.Request = httptest.NewRequest("POST", "/", nil)
+
+	t.Run("TestGetInventoryV2_ShouldFetchWithTypedFilter", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventoryList(gomock.Any(), "", "", inventoryName, bson.M{"name": bson.M{"$in": bson.A{"DSA"}}, "price": bson.M{"$gte": 10.0}}, gomock.Any(), nil).Return([]bson.M{{"id": "1"}}, nil, nil)
+		items, _, err := sut.GetInventoryV2(ginContext, "", "", inventoryName, map[string]interface{}{"name": []interface{}{"DSA"}, "price": map[string]interface{}{"gte": "10"}})
+
+		assert.Nil(t, err)
+		assert.Len(t, items, 1)
+	})
+	t.Run("TestGetInventoryV2_ShouldReturnStatus113_WhenFieldIsNotAnIdentifier", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		_, _, err := sut.GetInventoryV2(ginContext, "", "", inventoryName, map[string]interface{}{"or": []interface{}{map[string]interface{}{"code": "C1"}}})
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS113).StatusCode, err.StatusCode)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS113).Message+" : code", err.Message)
+	})
+	t.Run("TestGetInventoryV2_ShouldReturnStatus151_WhenValueDoesNotMatchSchema", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		_, _, err := sut.GetInventoryV2(ginContext, "", "", inventoryName, map[string]interface{}{"price": map[string]interface{}{"lt": "cheap"}})
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS151).StatusCode, err.StatusCode)
+		assert.Contains(t, err.Message, "price")
+	})
+	t.Run("TestGetInventoryV2_ShouldReadFirstCursorPage_WhenCursorIsEmpty", func(t *testing.T) {
+		cursorContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+		cursorContext.Request = httptest.NewRequest("POST", "/?cursor=&page_size=2", nil)
+		expectedPagination := commonDto.Pagination{Pagination: true, IsCursor: true, PageSize: 2, SortField: "created_at", SortDirection: -1}
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventoryList(gomock.Any(), "", "", inventoryName, bson.M{}, expectedPagination, nil).Return([]bson.M{{"id": "1"}}, &commonDto.PaginationResponse{PageSize: 2, NextCursor: "next"}, nil)
+		_, paginationData, err := sut.GetInventoryV2(cursorContext, "", "", inventoryName, map[string]interface{}{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "next", paginationData.NextCursor)
+	})
+	t.Run("TestGetInventoryV2_ShouldSortAndProject_KeepingCursorFields", func(t *testing.T) {
+		cursorContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+		cursorContext.Request = httptest.NewRequest("POST", "/?cursor=&sort=-price,name&exclude=resources", nil)
+		sortKeys := []cursor.SortKey{{Field: "price", Direction: -1}, {Field: "name", Direction: 1}}
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventoryList(gomock.Any(), "", "", inventoryName, bson.M{}, gomock.Any(), bson.M{"_id": 0, "resources": 0}).DoAndReturn(
+			func(ctx interface{}, from string, to string, name string, query bson.M, pagination commonDto.Pagination, itemProjection bson.M) ([]bson.M, *commonDto.PaginationResponse, *dto.ErrorResponseDto) {
+				assert.Equal(t, sortKeys, pagination.Order())
+				return []bson.M{}, &commonDto.PaginationResponse{}, nil
+			})
+		_, _, err := sut.GetInventoryV2(cursorContext, "", "", inventoryName, map[string]interface{}{})
+		assert.Nil(t, err)
+
+		excludeContext, _ := gin.CreateTestContext(httptest.NewRecorder())
+		excludeContext.Request = httptest.NewRequest("POST", "/?cursor=&sort=-price&exclude=price", nil)
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		_, _, err = sut.GetInventoryV2(excludeContext, "", "", inventoryName, map[string]interface{}{})
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS153).StatusCode, err.StatusCode)
+	})
+}
+
+func TestResolveCursor(t *testing.T) {
+	newPagination := func() *commonDto.Pagination {
+		return &commonDto.Pagination{Pagination: true, PageSize: 10, SortField: "name", SortDirection: 1}
+	}
+
+	t.Run("TestResolveCursor_ShouldPositionPaginationOnCursor", func(t *testing.T) {
+		position := cursor.Cursor{Sort: []cursor.SortKey{{Field: "name", Direction: 1}}, Values: []interface{}{"DSA"}, Id: "C2", IsBefore: true}
+		token, _ := cursor.Encode(position)
+		pagination := newPagination()
+
+		err := serviceImpl.ResolveCursor(pagination, token, "")
+		assert.Nil(t, err)
+		assert.True(t, pagination.IsCursor)
+		assert.Equal(t, &position, pagination.Cursor)
+	})
+	t.Run("TestResolveCursor_ShouldReturnStatus138_WhenCursorCannotContinueTheList", func(t *testing.T) {
+		otherOrder, _ := cursor.Encode(cursor.Cursor{Sort: []cursor.SortKey{{Field: "name", Direction: -1}}, Values: []interface{}{"DSA"}, Id: "C2"})
+		for _, request := range [][2]string{{"garbage", ""}, {otherOrder, ""}, {"", "1"}} {
+			err := serviceImpl.ResolveCursor(newPagination(), request[0], request[1])
+			assert.Equal(t, dto.GetStatusDetails(status_code.IMS138).StatusCode, err.StatusCode, request)
+		}
+	})
+}
+
+func TestResolveSort(t *testing.T) {
+	configuration := response_dto.InventoryConfigurationResponseDto{
+		InventoryName: "Course",
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{
+			{Key: "course_id", IsUnique: true},
+			{Key: "name_level", Keys: []string{"name", "level"}},
+			{Key: "description", IndexType: request_dto.IndexTypeText},
+		},
+	}
+
+	t.Run("TestResolveSort_ShouldParseDirections", func(t *testing.T) {
+		sortKeys, err := serviceImpl.ResolveSort(configuration, "name, -level,created_at")
+		assert.Nil(t, err)
+		assert.Equal(t, []cursor.SortKey{{Field: "name", Direction: 1}, {Field: "level", Direction: -1}, {Field: "created_at", Direction: 1}}, sortKeys)
+	})
+	t.Run("TestResolveSort_ShouldReturnStatus152_WhenSortIsInvalid", func(t *testing.T) {
+		for _, sort := range []string{"price", "description", "name,-name", "name,", "-"} {
+			sortKeys, err := serviceImpl.ResolveSort(configuration, sort)
+			assert.Nil(t, sortKeys)
+			assert.Equal(t, dto.GetStatusDetails(status_code.IMS152).StatusCode, err.StatusCode, sort)
+		}
+	})
+}
+
+func TestSearchInventory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryRepo = mockRepo.NewMockIInventoryRepository(mockController)
+	mockInventoryConfigurationService = mockServices.NewMockIInventoryConfigurationService(mockController)
+
+	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService, nil)
+	inventoryName := "topics"
+	serviceResponse := response_dto.InventoryConfigurationResponseDto{
+		InventoryName:    inventoryName,
+		SearchableFields: []string{"topic_name", "description", "tags"},
+	}
+
+	t.Run("TestSearchInventory_ShouldReturnScoredResultsWithHighlights", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().SearchInventory(gomock.Any(), inventoryName, "graph -tree", commonDto.Pagination{Pagination: true, PageNumber: 1, PageSize: 10, SortField: "created_at", SortDirection: -1}).Return([]bson.M{
+			{"id": "T1", "topic_name": "Graphs", "description": "Walking a graph", "tags": bson.A{"bfs", "graph search"}, "search_score": 1.5},
+		}, &commonDto.PaginationResponse{Count: 11, PageNumber: 1, PageSize: 10}, nil)
+		results, paginationData, err := sut.SearchInventory(context.Background(), inventoryName, " graph -tree ", "1", "")
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(11), paginationData.Count)
+		assert.Equal(t, []commonDto.SearchResult{{
+			Item:  bson.M{"id": "T1", "topic_name": "Graphs", "description": "Walking a graph", "tags": bson.A{"bfs", "graph search"}},
+			Score: 1.5,
+			Highlights: map[string]string{
+				"topic_name":  "<em>Graphs</em>",
+				"description": "Walking a <em>graph</em>",
+				"tags":        "<em>graph</em> search",
+			},
+		}}, results)
+	})
+	t.Run("TestSearchInventory_ShouldSearchTextIdentifier_WhenNoFieldsAreSearchable", func(t *testing.T) {
+		textIdentifierResponse := response_dto.InventoryConfigurationResponseDto{
+			InventoryName:        inventoryName,
+			InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "topic_id", IsUnique: true}, {Key: "topic_text", Keys: []string{"topic_name"}, IndexType: request_dto.IndexTypeText}},
+		}
+		assert.Equal(t, []string{"topic_name"}, serviceImpl.SearchableFields(textIdentifierResponse))
+	})
+	t.Run("TestSearchInventory_ShouldReturnStatus155_WhenInventoryIsNotSearchable", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&response_dto.InventoryConfigurationResponseDto{InventoryName: inventoryName}, nil)
+		_, _, err := sut.SearchInventory(context.Background(), inventoryName, "graph", "", "")
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS155).StatusCode, err.StatusCode)
+	})
+	t.Run("TestSearchInventory_ShouldReturnStatus156_WhenQueryHasNoWords", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		_, _, err := sut.SearchInventory(context.Background(), inventoryName, " -tree ", "", "")
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS156).StatusCode, err.StatusCode)
+	})
+}
+
+func TestAggregateInventory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryRepo = mockRepo.NewMockIInventoryRepository(mockController)
+	mockInventoryConfigurationService = mockServices.NewMockIInventoryConfigurationService(mockController)
+
+	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService, nil)
+	inventoryName := "courses"
+	serviceResponse := response_dto.InventoryConfigurationResponseDto{
+		InventoryName:        inventoryName,
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "category"}, {Key: "level"}},
+		JsonSchema: map[string]interface{}{"$jsonSchema": map[string]interface{}{
+			"bsonType": "object",
+			"properties": map[string]interface{}{
+				"category": map[string]interface{}{"bsonType": "string"},
+				"level":    map[string]interface{}{"bsonType": "int"},
+				"price":    map[string]interface{}{"bsonType": "double"},
+				"title":    map[string]interface{}{"bsonType": "string"},
+			},
+		}},
+	}
+
+	t.Run("TestAggregateInventory_ShouldCompileRequestIntoPipeline", func(t *testing.T) {
+		pipeline, err := serviceImpl.CompileAggregation(serviceResponse, request_dto.AggregateRequest{
+			GroupBy: []string{"category", "level"},
+			Metrics: []request_dto.AggregateMetric{{Operator: request_dto.MetricAvg, Field: "price"}, {Name: "cheapest", Operator: request_dto.MetricMin, Field: "price"}},
+			Filter:  map[string]interface{}{"level": map[string]interface{}{"gte": "2"}},
+			Sort:    "avg_price",
+			Limit:   10,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, bson.A{
+			bson.D{{Key: "$match", Value: bson.M{"$and": bson.A{bson.M{"is_deleted": false}, bson.M{"level": bson.M{"$gte": int64(2)}}}}}},
+			bson.D{{Key: "$group", Value: bson.D{
+				{Key: "_id", Value: bson.D{{Key: "g0", Value: "$category"}, {Key: "g1", Value: "$level"}}},
+				{Key: "count", Value: bson.M{"$sum": 1}},
+				{Key: "m0", Value: bson.M{"$avg": "$price"}},
+				{Key: "m1", Value: bson.M{"$min": "$price"}},
+			}}},
+			bson.D{{Key: "$sort", Value: bson.D{{Key: "m0", Value: 1}, {Key: "_id", Value: 1}}}},
+			bson.D{{Key: "$limit", Value: int64(11)}},
+		}, pipeline)
+	})
+	t.Run("TestAggregateInventory_ShouldSortByCountDescending_WhenNoSortIsGiven", func(t *testing.T) {
+		pipeline, err := serviceImpl.CompileAggregation(serviceResponse, request_dto.AggregateRequest{GroupBy: []string{"category"}})
+
+		assert.Nil(t, err)
+		assert.Equal(t, bson.D{{Key: "$match", Value: bson.M{"is_deleted": false}}}, pipeline[0])
+		assert.Equal(t, bson.D{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}}, pipeline[2])
+		assert.Equal(t, bson.D{{Key: "$limit", Value: int64(constants.DefaultAggregateLimit + 1)}}, pipeline[3])
+	})
+	t.Run("TestAggregateInventory_ShouldReturnStatus157_WhenRequestIsInvalid", func(t *testing.T) {
+		for _, aggregateRequest := range []request_dto.AggregateRequest{
+			{},
+			{GroupBy: []string{"title"}},
+			{GroupBy: []string{"category", "category"}},
+			{GroupBy: []string{"category"}, Metrics: []request_dto.AggregateMetric{{Operator: "median", Field: "price"}}},
+			{GroupBy: []string{"category"}, Metrics: []request_dto.AggregateMetric{{Operator: request_dto.MetricSum, Field: "title"}}},
+			{GroupBy: []string{"category"}, Metrics: []request_dto.AggregateMetric{{Name: "count", Operator: request_dto.MetricSum, Field: "price"}}},
+			{GroupBy: []string{"category"}, Metrics: []request_dto.AggregateMetric{{Name: "$total", Operator: request_dto.MetricSum, Field: "price"}}},
+			{GroupBy: []string{"category"}, Sort: "price"},
+			{GroupBy: []string{"category"}, Limit: constants.MaxAggregateLimit + 1},
+		} {
+			_, err := serviceImpl.CompileAggregation(serviceResponse, aggregateRequest)
+			assert.Equal(t, dto.GetStatusDetails(status_code.IMS157).StatusCode, err.StatusCode, aggregateRequest)
+		}
+	})
+	t.Run("TestAggregateInventory_ShouldReturnStatus113_WhenFilterIsNotOnIdentifiers", func(t *testing.T) {
+		_, err := serviceImpl.CompileAggregation(serviceResponse, request_dto.AggregateRequest{GroupBy: []string{"category"}, Filter: map[string]interface{}{"title": "Go"}})
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS113).StatusCode, err.StatusCode)
+	})
+	t.Run("TestAggregateInventory_ShouldMapGroupsAndTruncateToLimit", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().AggregateInventory(gomock.Any(), inventoryName, gomock.Any()).Return([]bson.M{
+			{"_id": bson.M{"g0": "dsa"}, "count": int32(7), "m0": 120.5},
+			{"_id": bson.M{"g0": "java"}, "count": int32(3), "m0": 80.0},
+		}, nil)
+		result, err := sut.AggregateInventory(context.Background(), inventoryName, request_dto.AggregateRequest{
+			GroupBy: []string{"category"},
+			Metrics: []request_dto.AggregateMetric{{Operator: request_dto.MetricSum, Field: "price"}},
+			Limit:   1,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, &commonDto.AggregateResult{
+			Groups:      []commonDto.AggregateGroup{{Key: map[string]interface{}{"category": "dsa"}, Count: 7, Metrics: map[string]interface{}{"sum_price": 120.5}}},
+			IsTruncated: true,
+		}, result)
+	})
+}
+
+func TestGetInventoryFacets(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryRepo = mockRepo.NewMockIInventoryRepository(mockController)
+	mockInventoryConfigurationService = mockServices.NewMockIInventoryConfigurationService(mockController)
+
+	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService, nil)
+	inventoryName := "courses"
+	serviceResponse := response_dto.InventoryConfigurationResponseDto{
+		InventoryName:        inventoryName,
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "category"}, {Key: "level"}, {Key: "language"}},
+		JsonSchema: map[string]interface{}{"$jsonSchema": map[string]interface{}{
+			"bsonType": "object",
+			"properties": map[string]interface{}{
+				"category": map[string]interface{}{"bsonType": "string"},
+				"level":    map[string]interface{}{"bsonType": "int"},
+				"language": map[string]interface{}{"bsonType": "string"},
+			},
+		}},
+	}
+	facetPipeline := func(field string, limit int64) bson.A {
+		return bson.A{
+			bson.D{{Key: "$unwind", Value: "$" + field}},
+			bson.D{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$" + field}, {Key: "count", Value: bson.M{"$sum": 1}}}}},
+			bson.D{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+			bson.D{{Key: "$limit", Value: limit}},
+		}
+	}
+
+	t.Run("TestGetInventoryFacets_ShouldLeaveSelectionsOutOfTheirOwnFacet", func(t *testing.T) {
+		pipeline, err := serviceImpl.CompileFacets(serviceResponse, request_dto.FacetRequest{
+			Facets: []string{"category", "level"},
+			Filter: map[string]interface{}{"category": []interface{}{"dsa"}, "level": "2", "language": "go"},
+			Limit:  5,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, bson.A{
+			bson.D{{Key: "$match", Value: bson.M{"$and": bson.A{bson.M{"is_deleted": false}, bson.M{"language": "go"}}}}},
+			bson.D{{Key: "$facet", Value: bson.D{
+				{Key: "f0", Value: append(bson.A{bson.D{{Key: "$match", Value: bson.M{"level": int64(2)}}}}, facetPipeline("category", 6)...)},
+				{Key: "f1", Value: append(bson.A{bson.D{{Key: "$match", Value: bson.M{"category": bson.M{"$in": bson.A{"dsa"}}}}}}, facetPipeline("level", 6)...)},
+			}}},
+		}, pipeline)
+	})
+	t.Run("TestGetInventoryFacets_ShouldReturnStatus159_WhenRequestIsInvalid", func(t *testing.T) {
+		for _, facetRequest := range []request_dto.FacetRequest{
+			{},
+			{Facets: []string{"title"}},
+			{Facets: []string{"level", "level"}},
+			{Facets: []string{"level"}, Limit: constants.MaxFacetLimit + 1},
+		} {
+			_, err := serviceImpl.CompileFacets(serviceResponse, facetRequest)
+			assert.Equal(t, dto.GetStatusDetails(status_code.IMS159).StatusCode, err.StatusCode, facetRequest)
+		}
+	})
+	t.Run("TestGetInventoryFacets_ShouldReturnStatus159_WhenFacetFieldIsFilteredInsideAndOr", func(t *testing.T) {
+		for _, facetRequest := range []request_dto.FacetRequest{
+			{Facets: []string{"level"}, Filter: map[string]interface{}{"or": []interface{}{map[string]interface{}{"level": "2"}, map[string]interface{}{"language": "go"}}}},
+			{Facets: []string{"category", "level"}, Filter: map[string]interface{}{"and": []interface{}{map[string]interface{}{"or": []interface{}{map[string]interface{}{"category": "dsa"}}}}}},
+		} {
+			_, err := serviceImpl.CompileFacets(serviceResponse, facetRequest)
+			assert.Equal(t, dto.GetStatusDetails(status_code.IMS159).StatusCode, err.StatusCode, facetRequest)
+		}
+	})
+	t.Run("TestGetInventoryFacets_ShouldShareCombinedConditions_WhenTheyDoNotNameAFacet", func(t *testing.T) {
+		pipeline, err := serviceImpl.CompileFacets(serviceResponse, request_dto.FacetRequest{
+			Facets: []string{"category"},
+			Filter: map[string]interface{}{"or": []interface{}{map[string]interface{}{"level": "2"}, map[string]interface{}{"language": "go"}}},
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, bson.D{{Key: "$match", Value: bson.M{"$and": bson.A{
+			bson.M{"is_deleted": false},
+			bson.M{"$or": bson.A{bson.M{"level": int64(2)}, bson.M{"language": "go"}}},
+		}}}}, pipeline[0])
+	})
+	t.Run("TestGetInventoryFacets_ShouldReturnValuesWithCounts", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().AggregateInventory(gomock.Any(), inventoryName, gomock.Any()).Return([]bson.M{{
+			"f0": bson.A{bson.M{"_id": "dsa", "count": int32(4)}, bson.M{"_id": "java", "count": int32(2)}},
+			"f1": bson.A{},
+		}}, nil)
+		facets, err := sut.GetInventoryFacets(context.Background(), inventoryName, request_dto.FacetRequest{Facets: []string{"category", "level"}, Limit: 1})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []commonDto.Facet{
+			{Field: "category", Values: []commonDto.FacetValue{{Value: "dsa", Count: 4}}, IsTruncated: true},
+			{Field: "level", Values: []commonDto.FacetValue{}},
+		}, facets)
+	})
+}
+
+func TestGetInventoryFilter(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryRepo = mockRepo.NewMockIInventoryRepository(mockController)
+	mockInventoryConfigurationService = mockServices.NewMockIInventoryConfigurationService(mockController)
+
+	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService, nil)
+	inventoryName := "courses"
+	serviceResponse := response_dto.InventoryConfigurationResponseDto{
+		InventoryName:        inventoryName,
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "category"}, {Key: "level"}},
+	}
+
+	t.Run("TestGetInventoryFilter_ShouldReturnDistinctValues_WhenNotPaged", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().GetInventoryFilter(gomock.Any(), inventoryName, "category", bson.M{"level": bson.M{"$in": []string{"2"}}}).Return([]interface{}{"dsa", "java"}, nil)
+		values, paginationData, err := sut.GetInventoryFilter(context.Background(), inventoryName, "category", map[string][]string{"level": {"2"}}, "", "", "")
+
+		assert.Nil(t, err)
+		assert.Nil(t, paginationData)
+		assert.Equal(t, []interface{}{"dsa", "java"}, values)
+	})
+	t.Run("TestGetInventoryFilter_ShouldReturnPageOfValues_WhenPrefixIsGiven", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().AggregateInventory(gomock.Any(), inventoryName, bson.A{
+			bson.D{{Key: "$match", Value: bson.M{"$and": bson.A{bson.M{"is_deleted": false}, bson.M{}}}}},
+			bson.D{{Key: "$unwind", Value: "$category"}},
+			bson.D{{Key: "$match", Value: bson.M{"category": bson.M{"$regex": "^d\\.s", "$options": "i"}}}},
+			bson.D{{Key: "$group", Value: bson.M{"_id": "$category"}}},
+			bson.D{{Key: "$sort", Value: bson.M{"_id": 1}}},
+			bson.D{{Key: "$facet", Value: bson.D{
+				{Key: "values", Value: bson.A{bson.D{{Key: "$skip", Value: int64(5)}}, bson.D{{Key: "$limit", Value: int64(5)}}}},
+				{Key: "total", Value: bson.A{bson.D{{Key: "$count", Value: "count"}}}},
+			}}},
+		}).Return([]bson.M{{"values": bson.A{bson.M{"_id": "d.s. algorithms"}}, "total": bson.A{bson.M{"count": int32(6)}}}}, nil)
+		values, paginationData, err := sut.GetInventoryFilter(context.Background(), inventoryName, "category", map[string][]string{}, "d.s", "1", "5")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{"d.s. algorithms"}, values)
+		assert.Equal(t, &commonDto.PaginationResponse{Count: 6, PageNumber: 1, PageSize: 5}, paginationData)
+	})
+	t.Run("TestGetInventoryFilter_ShouldReturnStatus113_WhenFilterIsNotAnIdentifier", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		_, _, err := sut.GetInventoryFilter(context.Background(), inventoryName, "title", map[string][]string{}, "go", "", "")
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS113).StatusCode, err.StatusCode)
+	})
+}
+
+func TestExportInventory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryRepo = mockRepo.NewMockIInventoryRepository(mockController)
+	mockInventoryConfigurationService = mockServices.NewMockIInventoryConfigurationService(mockController)
+
+	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService, nil)
+	inventoryName := "courses"
+	serviceResponse := response_dto.InventoryConfigurationResponseDto{
+		InventoryName:        inventoryName,
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "level"}},
+		JsonSchema: map[string]interface{}{"$jsonSchema": map[string]interface{}{
+			"bsonType": "object",
+			"properties": map[string]interface{}{
+				"title": map[string]interface{}{"bsonType": "string"},
+				"level": map[string]interface{}{"bsonType": "int"},
+			},
+		}},
+	}
+
+	t.Run("TestExportInventory_ShouldStreamItemsAsCSV", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().ExportInventory(gomock.Any(), "", "", inventoryName, bson.M{"level": int64(2)}, []cursor.SortKey{{Field: "level", Direction: -1}}, bson.M{"_id": 0, "id": 1, "title": 1, "level": 1}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ string, _ string, _ bson.M, _ []cursor.SortKey, _ bson.M, write func(map[string]interface{}) error) *dto.ErrorResponseDto {
+				assert.Nil(t, write(bson.M{"id": "C1", "title": "Graphs", "level": int32(2)}))
+				assert.Nil(t, write(bson.M{"id": "C2", "level": int32(2)}))
+				return nil
+			})
+		var output bytes.Buffer
+		err := sut.ExportInventory(context.Background(), inventoryName, request_dto.ExportRequest{
+			Format: "csv",
+			Filter: map[string]interface{}{"level": "2"},
+			Sort:   "-level",
+			Fields: "title,level",
+		}, &output)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "id,level,title\nC1,2,Graphs\nC2,2,\n", output.String())
+	})
+	t.Run("TestExportInventory_ShouldReturnStatus160_WhenFormatIsUnknown", func(t *testing.T) {
+		var output bytes.Buffer
+		err := sut.ExportInventory(context.Background(), inventoryName, request_dto.ExportRequest{Format: "xml"}, &output)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS160).StatusCode, err.StatusCode)
+		assert.Equal(t, 0, output.Len())
+	})
+	t.Run("TestExportInventory_ShouldWriteNothing_WhenFilterIsInvalid", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		var output bytes.Buffer
+		err := sut.ExportInventory(context.Background(), inventoryName, request_dto.ExportRequest{Format: "json", Filter: map[string]interface{}{"title": "Go"}}, &output)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS113).StatusCode, err.StatusCode)
+		assert.Equal(t, 0, output.Len())
+	})
+}
+
+func TestImportInventory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryRepo = mockRepo.NewMockIInventoryRepository(mockController)
+	mockInventoryConfigurationService = mockServices.NewMockIInventoryConfigurationService(mockController)
+
+	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService, nil)
+	inventoryName := "courses"
+	serviceResponse := response_dto.InventoryConfigurationResponseDto{
+		InventoryName:        inventoryName,
+		InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "code", IsUnique: true}, {Key: "level"}},
+		JsonSchema: map[string]interface{}{"$jsonSchema": map[string]interface{}{
+			"bsonType": "object",
+			"required": []interface{}{"code"},
+			"properties": map[string]interface{}{
+				"code":  map[string]interface{}{"bsonType": "string"},
+				"level": map[string]interface{}{"bsonType": "long"},
+			},
+		}},
+	}
+	isRejection := func(rejection commonDto.ImportRejection, row int, statusCode dto.StatusCode) bool {
+		return rejection.Row == row && rejection.StatusCode == dto.GetStatusDetails(statusCode).StatusCode
+	}
+
+	t.Run("TestImportInventory_ShouldValidateRowsWithoutWriting_WhenDryRun", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		report, err := sut.ImportInventory(context.Background(), inventoryName, request_dto.ImportRequest{Format: "csv", DryRun: true},
+			strings.NewReader("code,level\nC1,2\nC2,two\n,3\n"), "importer")
+
+		assert.Nil(t, err)
+		assert.Equal(t, request_dto.ImportModeInsert, report.Mode)
+		assert.True(t, report.IsDryRun)
+		assert.False(t, report.IsApplied)
+		assert.Equal(t, 3, report.TotalRows)
+		assert.Equal(t, 1, report.ValidCount)
+		assert.Equal(t, 0, report.InsertedCount)
+		assert.Equal(t, 2, report.RejectedCount)
+		assert.True(t, isRejection(report.Rejected[0], 3, status_code.IMS109))
+		assert.Equal(t, `Document Validation failed : level: "two" is not a valid long`, report.Rejected[0].Reason)
+		assert.True(t, isRejection(report.Rejected[1], 4, status_code.IMS109))
+	})
+	t.Run("TestImportInventory_ShouldReplaceExistingItemsAndInsertOthers_WhenUpsert", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		existingItem := bson.M{"id": "I1", "code": "C1", "level": int64(1), "created_by": "seed", "version": int64(2), "is_deleted": false}
+		mockInventoryRepo.EXPECT().FetchInventoryList(gomock.Any(), "", "", inventoryName, bson.M{"code": bson.M{"$in": bson.A{"C1", "C2"}}}, commonDto.Pagination{}, nil).
+			Return([]bson.M{existingItem}, nil, nil)
+		mockInventoryRepo.EXPECT().BulkWriteInventory(gomock.Any(), inventoryName, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, writes []commonDto.ItemWrite) (map[int]*dto.ErrorResponseDto, *dto.ErrorResponseDto) {
+				assert.Len(t, writes, 2)
+				assert.Equal(t, bson.M{"id": "I1"}, writes[0].Filter)
+				assert.Equal(t, int64(3), writes[0].Item["level"])
+				assert.NotContains(t, writes[0].Item, "id")
+				assert.Nil(t, writes[1].Filter)
+				assert.NotEmpty(t, writes[1].Item["id"])
+				assert.Equal(t, "importer", writes[1].Item["created_by"])
+				return map[int]*dto.ErrorResponseDto{}, nil
+			})
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).DoAndReturn(func(_ context.Context, _ string, itemRevisions []commonDto.ItemRevision) *dto.ErrorResponseDto {
+			assert.Equal(t, commonDto.ItemRevisionReplace, itemRevisions[0].Operation)
+			assert.Equal(t, int64(3), itemRevisions[0].Version)
+			assert.Equal(t, commonDto.ItemRevisionCreate, itemRevisions[1].Operation)
+			return nil
+		})
+		report, err := sut.ImportInventory(context.Background(), inventoryName, request_dto.ImportRequest{Format: "ndjson", Mode: "upsert", Key: "code"},
+			strings.NewReader(`{"code":"C1","level":3}`+"\n"+`{"code":"C2"}`+"\n"+`{"code":"C1","level":4}`+"\n"+`{"level":5}`+"\n"), "importer")
+
+		assert.Nil(t, err)
+		assert.True(t, report.IsApplied)
+		assert.Equal(t, 4, report.TotalRows)
+		assert.Equal(t, 1, report.ReplacedCount)
+		assert.Equal(t, 1, report.InsertedCount)
+		assert.Equal(t, 2, report.RejectedCount)
+		assert.True(t, isRejection(report.Rejected[0], 3, status_code.IMS108))
+		assert.Equal(t, "Inventory already exists with given identifier : code C1 is already imported by row 1", report.Rejected[0].Reason)
+		assert.True(t, isRejection(report.Rejected[1], 4, status_code.IMS400))
+	})
+	t.Run("TestImportInventory_ShouldReportFailedWrites", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		var duplicateErr dto.ErrorResponseDto
+		duplicateErr.SetError(status_code.IMS108)
+		mockInventoryRepo.EXPECT().BulkWriteInventory(gomock.Any(), inventoryName, gomock.Any()).Return(map[int]*dto.ErrorResponseDto{0: &duplicateErr}, nil)
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Len(1)).Return(nil)
+		report, err := sut.ImportInventory(context.Background(), inventoryName, request_dto.ImportRequest{Format: "csv"}, strings.NewReader("code\nC1\nC2\n"), "importer")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, report.InsertedCount)
+		assert.Equal(t, 1, report.RejectedCount)
+		assert.True(t, isRejection(report.Rejected[0], 2, status_code.IMS108))
+	})
+	t.Run("TestImportInventory_ShouldWriteNothing_WhenReplaceAllHasRejectedRows", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventoryList(gomock.Any(), "", "", inventoryName, bson.M{"code": bson.M{"$in": bson.A{"C1"}}}, commonDto.Pagination{}, nil).Return(nil, nil, nil)
+		report, err := sut.ImportInventory(context.Background(), inventoryName, request_dto.ImportRequest{Format: "csv", Mode: "replace_all"}, strings.NewReader("code,level\nC1,1\nC2,x\n"), "importer")
+
+		assert.Nil(t, err)
+		assert.False(t, report.IsDryRun)
+		assert.False(t, report.IsApplied)
+		assert.Equal(t, 1, report.RejectedCount)
+	})
+	t.Run("TestImportInventory_ShouldReplaceMatchingItemsAndRemoveTheRest_WhenReplaceAll", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		existingItem := bson.M{"id": "I1", "code": "C1", "version": int64(1), "is_deleted": false}
+		mockInventoryRepo.EXPECT().FetchInventoryList(gomock.Any(), "", "", inventoryName, bson.M{"code": bson.M{"$in": bson.A{"C1", "C3"}}}, commonDto.Pagination{}, nil).
+			Return([]bson.M{existingItem}, nil, nil).Times(2)
+		writeCall := mockInventoryRepo.EXPECT().BulkWriteInventory(gomock.Any(), inventoryName, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, writes []commonDto.ItemWrite) (map[int]*dto.ErrorResponseDto, *dto.ErrorResponseDto) {
+				assert.Len(t, writes, 2)
+				assert.Equal(t, bson.M{"id": "I1"}, writes[0].Filter)
+				assert.Nil(t, writes[1].Filter)
+				return map[int]*dto.ErrorResponseDto{}, nil
+			})
+		revisionCall := mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Len(2)).Return(nil).After(writeCall)
+		removeCall := mockInventoryRepo.EXPECT().RemoveItemsUpdatedBefore(gomock.Any(), inventoryName, gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, updatedBefore time.Time, updateMetadata bson.M, removed func(items []bson.M)) (int64, *dto.ErrorResponseDto) {
+				assert.False(t, updatedBefore.IsZero())
+				assert.Equal(t, "replaced by import", updateMetadata[constants.ItemDeletionReasonField])
+				assert.Equal(t, "importer", updateMetadata[constants.ItemDeletedByField])
+				removed([]bson.M{{"id": "I2", "code": "C2", "version": int64(1), "is_deleted": false}})
+				return 1, nil
+			}).After(revisionCall)
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).DoAndReturn(func(_ context.Context, _ string, itemRevisions []commonDto.ItemRevision) *dto.ErrorResponseDto {
+			assert.Equal(t, commonDto.ItemRevisionDelete, itemRevisions[0].Operation)
+			assert.Equal(t, true, itemRevisions[0].Item[constants.ItemIsDeletedField])
+			return nil
+		}).After(removeCall)
+		report, err := sut.ImportInventory(context.Background(), inventoryName, request_dto.ImportRequest{Format: "csv", Mode: "replace_all"}, strings.NewReader("code\nC1\nC3\n"), "importer")
+
+		assert.Nil(t, err)
+		assert.True(t, report.IsApplied)
+		assert.Equal(t, 1, report.ReplacedCount)
+		assert.Equal(t, 1, report.InsertedCount)
+		assert.Equal(t, int64(1), report.RemovedCount)
+	})
+	t.Run("TestImportInventory_ShouldKeepPreviousItems_WhenReplaceAllWriteIsRejected", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventoryList(gomock.Any(), "", "", inventoryName, gomock.Any(), commonDto.Pagination{}, nil).Return(nil, nil, nil).Times(2)
+		var duplicateErr dto.ErrorResponseDto
+		duplicateErr.SetError(status_code.IMS108)
+		mockInventoryRepo.EXPECT().BulkWriteInventory(gomock.Any(), inventoryName, gomock.Len(2)).Return(map[int]*dto.ErrorResponseDto{1: &duplicateErr}, nil)
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Len(1)).Return(nil)
+		report, err := sut.ImportInventory(context.Background(), inventoryName, request_dto.ImportRequest{Format: "csv", Mode: "replace_all"}, strings.NewReader("code\nC1\nC3\n"), "importer")
+
+		assert.Nil(t, err)
+		assert.True(t, report.IsApplied)
+		assert.Equal(t, int64(0), report.RemovedCount)
+		assert.True(t, isRejection(report.Rejected[0], 3, status_code.IMS108))
+	})
+	t.Run("TestImportInventory_ShouldRejectRowMatchingTwoItems_WhenReplaceAll", func(t *testing.T) {
+		twoKeyResponse := serviceResponse
+		twoKeyResponse.InventoryIdentifiers = []request_dto.InventoryIdentifier{{Key: "code", IsUnique: true}, {Key: "sku", IsUnique: true}}
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&twoKeyResponse, nil)
+		mockInventoryRepo.EXPECT().FetchInventoryList(gomock.Any(), "", "", inventoryName, bson.M{"$or": bson.A{bson.M{"code": bson.M{"$in": bson.A{"C1"}}}, bson.M{"sku": bson.M{"$in": bson.A{"S2"}}}}}, commonDto.Pagination{}, nil).
+			Return([]bson.M{{"id": "I1", "code": "C1", "sku": "S1"}, {"id": "I2", "code": "C2", "sku": "S2"}}, nil, nil)
+		report, err := sut.ImportInventory(context.Background(), inventoryName, request_dto.ImportRequest{Format: "csv", Mode: "replace_all"}, strings.NewReader("code,sku\nC1,S2\n"), "importer")
+
+		assert.Nil(t, err)
+		assert.False(t, report.IsApplied)
+		assert.True(t, isRejection(report.Rejected[0], 2, status_code.IMS108))
+	})
+	t.Run("TestImportInventory_ShouldReturnStatus162_WhenRequestIsInvalid", func(t *testing.T) {
+		for _, importRequest := range []request_dto.ImportRequest{
+			{Format: "xlsx"},
+			{Format: "csv", Mode: "merge"},
+			{Format: "csv", Key: "code"},
+			{Format: "csv", Mode: "upsert"},
+		} {
+			_, err := sut.ImportInventory(context.Background(), inventoryName, importRequest, strings.NewReader("code\nC1\n"), "importer")
+			assert.Equal(t, dto.GetStatusDetails(status_code.IMS162).StatusCode, err.StatusCode)
+		}
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		_, err := sut.ImportInventory(context.Background(), inventoryName, request_dto.ImportRequest{Format: "csv"}, strings.NewReader("code,code\n"), "importer")
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS162).StatusCode, err.StatusCode)
+	})
+	t.Run("TestImportInventory_ShouldReturnStatus140_WhenKeyIsNotUnique", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		_, err := sut.ImportInventory(context.Background(), inventoryName, request_dto.ImportRequest{Format: "csv", Mode: "upsert", Key: "level"}, strings.NewReader("level\n1\n"), "importer")
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS140).StatusCode, err.StatusCode)
+	})
+}
+
+func TestRetentionPolicies(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryRepo = mockRepo.NewMockIInventoryRepository(mockController)
+	mockInventoryConfigurationService = mockServices.NewMockIInventoryConfigurationService(mockController)
+
+	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService, nil)
+	courses := response_dto.InventoryConfigurationResponseDto{InventoryName: "Course", RetentionPolicy: &request_dto.RetentionPolicy{PurgeDeletedAfterDays: 30, MaxRevisions: 50}}
+	topics := response_dto.InventoryConfigurationResponseDto{InventoryName: "topics", RetentionPolicy: &request_dto.RetentionPolicy{MaxRevisions: 10}}
+	lessons := response_dto.InventoryConfigurationResponseDto{InventoryName: "lessons"}
+	var purgeErr dto.ErrorResponseDto
+	purgeErr.SetError(status_code.IMS150)
+
+	t.Run("TestEnforceRetentionPolicies_ShouldPurgeEveryInventoryWithPolicy", func(t *testing.T) {
+		var deletedBefore time.Time
+		mockInventoryConfigurationService.EXPECT().GetAllInventoryConfiguration(gomock.Any(), false).Return([]response_dto.InventoryConfigurationResponseDto{courses, topics, lessons}, nil)
+		mockInventoryRepo.EXPECT().PurgeDeletedItems(gomock.Any(), "Course", gomock.Any(), false).DoAndReturn(func(ctx context.Context, inventoryName string, before time.Time, dryRun bool) (int64, *dto.ErrorResponseDto) {
+			deletedBefore = before
+			return 4, nil
+		})
+		mockInventoryRepo.EXPECT().PurgeItemRevisions(gomock.Any(), "Course", int64(50), false).Return(int64(7), nil)
+		mockInventoryRepo.EXPECT().PurgeItemRevisions(gomock.Any(), "topics", int64(10), false).Return(int64(2), &purgeErr)
+		report, err := sut.EnforceRetentionPolicies(context.Background(), false)
+
+		assert.Nil(t, err)
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), deletedBefore, time.Minute)
+		assert.Equal(t, []commonDto.InventoryRetention{
+			{InventoryName: "Course", PurgedItems: 4, PurgedRevisions: 7},
+			{InventoryName: "topics", PurgedRevisions: 2},
+		}, report.Inventories)
+		assert.Equal(t, int64(4), report.PurgedItems)
+		assert.Equal(t, int64(9), report.PurgedRevisions)
+		assert.Equal(t, []string{"topics: " + purgeErr.Message}, report.Failures)
+	})
+	t.Run("TestPurgeInventory_ShouldOnlyCount_WhenDryRun", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), "Course").Return(&courses, nil)
+		mockInventoryRepo.EXPECT().PurgeDeletedItems(gomock.Any(), "Course", gomock.Any(), true).Return(int64(3), nil)
+		mockInventoryRepo.EXPECT().PurgeItemRevisions(gomock.Any(), "Course", int64(50), true).Return(int64(12), nil)
+		retention, err := sut.PurgeInventory(context.Background(), "Course", true)
+
+		assert.Nil(t, err)
+		assert.Equal(t, commonDto.InventoryRetention{InventoryName: "Course", IsDryRun: true, PurgedItems: 3, PurgedRevisions: 12}, *retention)
+	})
+	t.Run("TestPurgeInventory_ShouldReturnError_WhenNoRetentionPolicy", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), "lessons").Return(&lessons, nil)
+		_, err := sut.PurgeInventory(context.Background(), "lessons", false)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS149).StatusCode, err.StatusCode)
+	})
+}
+
+func TestItemHistory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+
+	mockInventoryRepo = mockRepo.NewMockIInventoryRepository(mockController)
+	mockInventoryConfigurationService = mockServices.NewMockIInventoryConfigurationService(mockController)
+
+	sut := serviceImpl.NewInventoryService(mockInventoryRepo, mockInventoryConfigurationService, nil)
+	inventoryName := "topics"
+	var serviceResponse = response_dto.InventoryConfigurationResponseDto{
+		InventoryName: "topics",
+		JsonSchema: map[string]interface{}{"$jsonSchema": map[string]interface{}{
+			"bsonType": "object",
+			"required": []interface{}{"description"},
+		}},
+	}
+	firstRevision := commonDto.ItemRevision{ItemId: "T1", Version: 1, Item: bson.M{"id": "T1", "version": int64(1), "description": "Arrays", "resources": bson.A{"video"}, "created_by": "author", "is_deleted": false}}
+	secondRevision := commonDto.ItemRevision{ItemId: "T1", Version: 2, Item: bson.M{"id": "T1", "version": int64(2), "description": "Overwritten", "created_by": "author", "is_deleted": false}}
+	var notFoundErr dto.ErrorResponseDto
+	notFoundErr.SetError(status_code.IMS145)
+
+	t.Run("TestDiffItemRevisions_ShouldReturnPatchBetweenVersions", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchItemRevision(gomock.Any(), inventoryName, "T1", bson.M{"version": int64(1)}).Return(&firstRevision, nil)
+		mockInventoryRepo.EXPECT().FetchItemRevision(gomock.Any(), inventoryName, "T1", bson.M{"version": int64(2)}).Return(&secondRevision, nil)
+		changes, err := sut.DiffItemRevisions(context.Background(), inventoryName, "T1", 1, 2)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []patch.Operation{
+			{Op: patch.OperationRemove, Path: "/resources"},
+			{Op: patch.OperationReplace, Path: "/description", Value: "Overwritten"},
+			{Op: patch.OperationReplace, Path: "/version", Value: int64(2)},
+		}, changes)
+	})
+	t.Run("TestDiffItemRevisions_ShouldNameMissingVersion", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchItemRevision(gomock.Any(), inventoryName, "T1", bson.M{"version": int64(7)}).Return(nil, &notFoundErr)
+		_, err := sut.DiffItemRevisions(context.Background(), inventoryName, "T1", 7, 2)
+
+		assert.Equal(t, notFoundErr.Message+" : version 7", err.Message)
+	})
+	t.Run("TestListItemRevisions_ShouldReturnNotFound_WhenItemHasNoHistory", func(t *testing.T) {
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchItemRevisions(gomock.Any(), inventoryName, "T9").Return([]commonDto.ItemRevision{}, nil)
+		_, err := sut.ListItemRevisions(context.Background(), inventoryName, "T9")
+
+		assert.Equal(t, notFoundErr.StatusCode, err.StatusCode)
+	})
+	t.Run("TestRevertItem_ShouldRestoreContentAndKeepManagedFields", func(t *testing.T) {
+		var replacedItem map[string]interface{}
+		var revisions []commonDto.ItemRevision
+		currentItem := bson.M{"id": "T1", "version": int64(2), "description": "Overwritten", "created_by": "author", "created_at": createdTime, "is_deleted": false}
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchItemRevision(gomock.Any(), inventoryName, "T1", bson.M{"version": int64(1)}).Return(&firstRevision, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"id": "T1"}, nil).Return(currentItem, nil)
+		mockInventoryRepo.EXPECT().ReplaceInventory("T1", inventoryName, gomock.Any(), int64(2)).DoAndReturn(func(Id string, InventoryName string, item map[string]interface{}, currentVersion interface{}) *dto.ErrorResponseDto {
+			replacedItem = item
+			return nil
+		})
+		mockInventoryRepo.EXPECT().CreateItemRevisions(gomock.Any(), inventoryName, gomock.Any()).DoAndReturn(func(ctx context.Context, inventoryName string, itemRevisions []commonDto.ItemRevision) *dto.ErrorResponseDto {
+			revisions = itemRevisions
+			return nil
+		})
+		revertedItem, err := sut.RevertItem(context.Background(), inventoryName, "T1", 1, "editor", nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, bson.M(replacedItem), revertedItem)
+		assert.Equal(t, "Arrays", revertedItem["description"])
+		assert.Equal(t, []interface{}{"video"}, revertedItem["resources"])
+		assert.Equal(t, int64(3), revertedItem["version"])
+		assert.Equal(t, "author", revertedItem["created_by"])
+		assert.Equal(t, createdTime, revertedItem["created_at"])
+		assert.Equal(t, "editor", revertedItem["updated_by"])
+		assert.Equal(t, commonDto.ItemRevisionRevert, revisions[0].Operation)
+		assert.Equal(t, int64(1), revisions[0].RevertedTo)
+		assert.Equal(t, int64(3), revisions[0].Version)
+	})
+	t.Run("TestRevertItem_ShouldReturnConflict_WhenIfMatchVersionDiffers", func(t *testing.T) {
+		expectedVersion := int64(1)
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchItemRevision(gomock.Any(), inventoryName, "T1", bson.M{"version": int64(1)}).Return(&firstRevision, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"id": "T1"}, nil).Return(bson.M{"id": "T1", "version": int64(2)}, nil)
+		_, err := sut.RevertItem(context.Background(), inventoryName, "T1", 1, "editor", &expectedVersion)
+
+		assert.Equal(t, serviceImpl.NewVersionConflictError(1, 2), err)
+	})
+	t.Run("TestRevertItem_ShouldReturnFieldErrors_WhenRevisionFailsCurrentSchema", func(t *testing.T) {
+		invalidRevision := commonDto.ItemRevision{ItemId: "T1", Version: 1, Item: bson.M{"id": "T1", "version": int64(1), "topic_name": "Arrays"}}
+		mockInventoryConfigurationService.EXPECT().GetInventoryConfiguration(gomock.Any(), inventoryName).Return(&serviceResponse, nil)
+		mockInventoryRepo.EXPECT().FetchItemRevision(gomock.Any(), inventoryName, "T1", bson.M{"version": int64(1)}).Return(&invalidRevision, nil)
+		mockInventoryRepo.EXPECT().FetchInventory(gomock.Any(), inventoryName, bson.M{"id": "T1"}, nil).Return(bson.M{"id": "T1", "version": int64(2), "description": "x"}, nil)
+		_, err := sut.RevertItem(context.Background(), inventoryName, "T1", 1, "editor", nil)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS109).StatusCode, err.StatusCode)
+	})
+}
+
+func TestResolvePagination(t *testing.T) {
+	paginatedConfiguration := response_dto.InventoryConfigurationResponseDto{
+		InventoryName:      "Course",
+		Pagination:         true,
+		PaginationSettings: &request_dto.PaginationSettings{DefaultPageSize: 5, MaxPageSize: 20, DefaultSortField: "name", DefaultSortDirection: request_dto.SortDirectionAscending},
+	}
+
+	t.Run("TestResolvePagination_ShouldApplyDefaults_WhenNoPageIsRequested", func(t *testing.T) {
+		pagination, err := serviceImpl.ResolvePagination(response_dto.InventoryConfigurationResponseDto{Pagination: true}, "", "")
+		assert.Nil(t, err)
+		assert.Equal(t, &commonDto.Pagination{Pagination: true, PageSize: 10, SortField: "created_at", SortDirection: -1}, pagination)
+	})
+	t.Run("TestResolvePagination_ShouldUseConfiguredSettings", func(t *testing.T) {
+		pagination, err := serviceImpl.ResolvePagination(paginatedConfiguration, "2", "")
+		assert.Nil(t, err)
+		assert.Equal(t, &commonDto.Pagination{Pagination: true, PageNumber: 2, PageSize: 5, SortField: "name", SortDirection: 1}, pagination)
+	})
+	t.Run("TestResolvePagination_ShouldIgnorePage_WhenPaginationIsDisabled", func(t *testing.T) {
+		pagination, err := serviceImpl.ResolvePagination(response_dto.InventoryConfigurationResponseDto{}, "3", "500")
+		assert.Nil(t, err)
+		assert.False(t, pagination.Pagination)
+		assert.Equal(t, int64(0), pagination.PageSize)
+	})
+	t.Run("TestResolvePagination_ShouldReturnError_WhenPageSizeExceedsMaximum", func(t *testing.T) {
+		var expectedErr dto.ErrorResponseDto
+		expectedErr.SetError(status_code.IMS138)
+
+		pagination, err := serviceImpl.ResolvePagination(paginatedConfiguration, "0", "21")
+		assert.Nil(t, pagination)
+		assert.Equal(t, expectedErr.Message+" : page_size exceeds the maximum of 20", err.Message)
+	})
+	t.Run("TestResolvePagination_ShouldReturnError_WhenPageIsInvalid", func(t *testing.T) {
+		for _, page := range [][2]string{{"-1", ""}, {"one", ""}, {"", "0"}} {
+			pagination, err := serviceImpl.ResolvePagination(paginatedConfiguration, page[0], page[1])
+			assert.Nil(t, pagination)
+			assert.Equal(t, dto.GetStatusDetails(status_code.IMS138).StatusCode, err.StatusCode)
+		}
+	})
+}
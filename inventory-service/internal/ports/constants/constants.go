@@ -8,7 +8,24 @@ const (
 	HEALTH_CHECK_PATH = "inventory-service/api/health"
 )
 
+// server subcommands
+const (
+	RECONCILE_COMMAND = "reconcile"
+)
+
+// content types selecting the format of an item update
+const (
+	MIMEJSONPatch  = "application/json-patch+json"
+	MIMEMergePatch = "application/merge-patch+json"
+)
+
 // server port
 const (
 	SERVER_PORT = "server.port"
 )
+
+// schema forms returned by the get configuration api
+const (
+	SchemaFormatMongo  = "mongo"
+	SchemaFormatSource = "source"
+)
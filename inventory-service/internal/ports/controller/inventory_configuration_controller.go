@@ -6,10 +6,13 @@ import (
 	"inventory-system/common/pkg/dto"
 	"inventory-system/common/pkg/logger"
 	ConfigurationServiceDto "inventory-system/inventory-service/internal/common/dto/request_dto"
+	"inventory-system/inventory-service/internal/common/dto/response_dto"
 	"inventory-system/inventory-service/internal/common/status_code"
 	"inventory-system/inventory-service/internal/domain/service"
+	portConstants "inventory-system/inventory-service/internal/ports/constants"
 	"inventory-system/inventory-service/internal/ports/utils"
 	"net/http"
+	"strconv"
 )
 
 type InventoryConfigurationController struct {
@@ -65,7 +68,7 @@ func (bc InventoryConfigurationController) CreateNewConfiguration() gin.HandlerF
 			return
 		}
 
-		errorDto = bc.InventoryConfigurationService.CreateNewConfiguration(ctx, createNewConfigurationRequestBody)
+		unenforcedKeywords, errorDto := bc.InventoryConfigurationService.CreateNewConfiguration(ctx, createNewConfigurationRequestBody)
 		if errorDto != nil {
 			log.Error("Inside "+methodName+" error while creating new configuration: ", createNewConfigurationRequestBody.InventoryName)
 			c.JSON(http.StatusOK, dto.ResponseDto{
@@ -77,6 +80,7 @@ func (bc InventoryConfigurationController) CreateNewConfiguration() gin.HandlerF
 		c.JSON(http.StatusOK, dto.ResponseDto{
 			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
 			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+			Data:       response_dto.CreateConfigurationResponseDto{UnenforcedKeywords: unenforcedKeywords},
 		})
 	}
 	return fn
@@ -89,6 +93,7 @@ func (bc InventoryConfigurationController) CreateNewConfiguration() gin.HandlerF
 // @Produce  json
 // @Success 200 {object} dto.ResponseDto
 // @Param inventoryName path string true "Inventory Key"
+// @Param schema_format query string false "mongo (default) for the enforced $jsonSchema or source for the schema as submitted"
 // @Router /inventory-service/api/v1/inventory/configurations/{inventoryName} [GET]
 // GetConfiguration : This function will fetch inventory Configuration
 func (bc InventoryConfigurationController) GetConfiguration() gin.HandlerFunc {
@@ -96,7 +101,19 @@ func (bc InventoryConfigurationController) GetConfiguration() gin.HandlerFunc {
 		methodName := "GetInventory"
 		log := logger.GetLogger()
 		ctx := context.Background()
+		var portErr dto.ErrorResponseDto
 		configurationName := c.Param("inventoryName")
+		schemaFormat := c.DefaultQuery("schema_format", portConstants.SchemaFormatMongo)
+
+		if schemaFormat != portConstants.SchemaFormatMongo && schemaFormat != portConstants.SchemaFormatSource {
+			log.Error("Inside "+methodName+" invalid schema format: ", schemaFormat)
+			portErr.SetError(status_code.IMS400)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: portErr.StatusCode,
+				Message:    portErr.Message,
+			})
+			return
+		}
 
 		inventoryConfiguration, errDto := bc.InventoryConfigurationService.GetInventoryConfiguration(ctx, configurationName)
 		if errDto != nil {
@@ -107,6 +124,13 @@ func (bc InventoryConfigurationController) GetConfiguration() gin.HandlerFunc {
 			})
 			return
 		}
+
+		//Only one form of the schema is returned, mongo dialect configurations have no separate source
+		if schemaFormat == portConstants.SchemaFormatSource && inventoryConfiguration.SourceJsonSchema != nil {
+			inventoryConfiguration.JsonSchema = inventoryConfiguration.SourceJsonSchema
+		}
+		inventoryConfiguration.SourceJsonSchema = nil
+
 		c.JSON(http.StatusOK, dto.ResponseDto{
 			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
 			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
@@ -121,6 +145,7 @@ func (bc InventoryConfigurationController) GetConfiguration() gin.HandlerFunc {
 // @Description Fetch all Inventory configurations
 // @Tags InventoryConfiguration
 // @Produce  json
+// @Param include_deleted query bool false "Include soft deleted configurations"
 // @Success 200 {object} dto.ResponseDto
 // @Router /inventory-service/api/v1/inventory/configurations [GET]
 // GetAllConfiguration : This function will fetch all inventory Configuration
@@ -130,7 +155,9 @@ func (bc InventoryConfigurationController) GetAllConfiguration() gin.HandlerFunc
 		log := logger.GetLogger()
 		ctx := context.Background()
 
-		inventoryConfigurations, errDto := bc.InventoryConfigurationService.GetAllInventoryConfiguration(ctx)
+		includeDeleted, _ := strconv.ParseBool(c.Query("include_deleted"))
+
+		inventoryConfigurations, errDto := bc.InventoryConfigurationService.GetAllInventoryConfiguration(ctx, includeDeleted)
 		if errDto != nil {
 			log.Info("Inside " + methodName + " unable to fetch all base configuration")
 			c.JSON(http.StatusOK, dto.ResponseDto{
@@ -180,3 +207,232 @@ func (bc InventoryConfigurationController) DeleteConfiguration() gin.HandlerFunc
 	}
 	return fn
 }
+
+// UpdateConfiguration  godoc
+// @Summary Update inventory configuration
+// @Description Update schema and identifiers of an inventory configuration and migrate its collection
+// @Tags InventoryConfiguration
+// @Accept  json
+// @Produce  json
+// @Param inventoryName path string true "Inventory Key"
+// @Param requestBody body request_dto.UpdateConfigurationRequestBody true "Update Inventory Configuration Request"
+// @Success 200 {object} dto.ResponseDto
+// @Router /inventory-service/api/v1/inventory/configurations/{inventoryName} [PUT]
+// UpdateConfiguration : This function will update inventory Configuration
+func (bc InventoryConfigurationController) UpdateConfiguration() gin.HandlerFunc {
+	fn := func(c *gin.Context) {
+		methodName := "UpdateConfiguration"
+		log := logger.GetLogger()
+		ctx := context.Background()
+		inventoryName := c.Param("inventoryName")
+		var portErr dto.ErrorResponseDto
+
+		var updateConfigurationRequestBody ConfigurationServiceDto.UpdateConfigurationRequestBody
+
+		err := c.ShouldBindJSON(&updateConfigurationRequestBody)
+		if err != nil {
+			log.Error("Inside "+methodName+" error while binding json Error: ", err.Error())
+			portErr.SetError(status_code.IMS400)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: portErr.StatusCode,
+				Message:    portErr.Message,
+			})
+			return
+		}
+
+		errorDto := bc.RequestValidator.ValidateStruct(&updateConfigurationRequestBody)
+		if errorDto == nil {
+			errorDto = bc.RequestValidator.ValidateInventoryIdentifiers(updateConfigurationRequestBody.InventoryIdentifiers)
+		}
+		if errorDto != nil {
+			log.Error("Inside " + methodName + " error while validating request for Update Configuration")
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errorDto.StatusCode,
+				Message:    errorDto.Message,
+			})
+			return
+		}
+
+		inventoryConfiguration, errorDto := bc.InventoryConfigurationService.UpdateInventoryConfiguration(ctx, inventoryName, updateConfigurationRequestBody)
+		if errorDto != nil {
+			log.Error("Inside "+methodName+" error while updating configuration: ", inventoryName)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errorDto.StatusCode,
+				Message:    errorDto.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+			Data:       inventoryConfiguration,
+		})
+	}
+	return fn
+}
+
+// ValidateConfiguration  godoc
+// @Summary Validate existing inventory items against a candidate schema
+// @Description Report the documents failing a candidate schema and the unique identifiers holding duplicates, without applying the change
+// @Tags InventoryConfiguration
+// @Accept  json
+// @Produce  json
+// @Param inventoryName path string true "Inventory Key"
+// @Param requestBody body request_dto.ValidateConfigurationRequestBody true "Validate Inventory Configuration Request"
+// @Success 200 {object} dto.ResponseDto
+// @Router /inventory-service/api/v1/inventory/configurations/{inventoryName}/validate [POST]
+// ValidateConfiguration : This function will report the impact of a schema change on an inventory
+func (bc InventoryConfigurationController) ValidateConfiguration() gin.HandlerFunc {
+	fn := func(c *gin.Context) {
+		methodName := "ValidateConfiguration"
+		log := logger.GetLogger()
+		ctx := context.Background()
+		inventoryName := c.Param("inventoryName")
+		var portErr dto.ErrorResponseDto
+
+		var validateConfigurationRequestBody ConfigurationServiceDto.ValidateConfigurationRequestBody
+
+		err := c.ShouldBindJSON(&validateConfigurationRequestBody)
+		if err != nil {
+			log.Error("Inside "+methodName+" error while binding json Error: ", err.Error())
+			portErr.SetError(status_code.IMS400)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: portErr.StatusCode,
+				Message:    portErr.Message,
+			})
+			return
+		}
+
+		errorDto := bc.RequestValidator.ValidateStruct(&validateConfigurationRequestBody)
+		if errorDto == nil {
+			errorDto = bc.RequestValidator.ValidateInventoryIdentifiers(validateConfigurationRequestBody.InventoryIdentifiers)
+		}
+		if errorDto != nil {
+			log.Error("Inside " + methodName + " error while validating request for Validate Configuration")
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errorDto.StatusCode,
+				Message:    errorDto.Message,
+			})
+			return
+		}
+
+		report, errorDto := bc.InventoryConfigurationService.ValidateInventoryConfiguration(ctx, inventoryName, validateConfigurationRequestBody)
+		if errorDto != nil {
+			log.Error("Inside "+methodName+" error while validating configuration: ", inventoryName)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errorDto.StatusCode,
+				Message:    errorDto.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+			Data:       report,
+		})
+	}
+	return fn
+}
+
+// RestoreConfiguration  godoc
+// @Summary Restore deleted inventory configuration
+// @Description Restore a soft deleted inventory configuration
+// @Tags InventoryConfiguration
+// @Produce  json
+// @Success 200 {object} dto.ResponseDto
+// @Param inventoryName path string true "Inventory Key"
+// @Router /inventory-service/api/v1/inventory/configurations/{inventoryName}/restore [POST]
+// RestoreConfiguration : This function will restore a deleted inventory Configuration
+func (bc InventoryConfigurationController) RestoreConfiguration() gin.HandlerFunc {
+	fn := func(c *gin.Context) {
+		methodName := "RestoreConfiguration"
+		log := logger.GetLogger()
+		ctx := context.Background()
+		inventoryName := c.Param("inventoryName")
+
+		errDto := bc.InventoryConfigurationService.RestoreInventoryConfiguration(ctx, inventoryName)
+		if errDto != nil {
+			log.Info("Inside "+methodName+" unable to restore inventory configuration for inventoryName :", inventoryName)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errDto.StatusCode,
+				Message:    errDto.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+		})
+	}
+	return fn
+}
+
+// PurgeConfiguration  godoc
+// @Summary Purge deleted inventory configuration
+// @Description Drop the collection of a soft deleted inventory configuration and remove the configuration permanently
+// @Tags InventoryConfiguration
+// @Produce  json
+// @Success 200 {object} dto.ResponseDto
+// @Param inventoryName path string true "Inventory Key"
+// @Param confirmation_token query string true "Must repeat the inventory name"
+// @Router /inventory-service/api/v1/inventory/configurations/{inventoryName}/purge [DELETE]
+// PurgeConfiguration : This function will permanently remove a deleted inventory Configuration
+func (bc InventoryConfigurationController) PurgeConfiguration() gin.HandlerFunc {
+	fn := func(c *gin.Context) {
+		methodName := "PurgeConfiguration"
+		log := logger.GetLogger()
+		ctx := context.Background()
+		inventoryName := c.Param("inventoryName")
+		confirmationToken := c.Query("confirmation_token")
+
+		errDto := bc.InventoryConfigurationService.PurgeInventoryConfiguration(ctx, inventoryName, confirmationToken)
+		if errDto != nil {
+			log.Info("Inside "+methodName+" unable to purge inventory configuration for inventoryName :", inventoryName)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errDto.StatusCode,
+				Message:    errDto.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+		})
+	}
+	return fn
+}
+
+// ReconcileConfigurations  godoc
+// @Summary Reconcile inventory configurations with collections
+// @Description Report drift between inventory configurations and their collections, optionally repairing it
+// @Tags InventoryConfiguration
+// @Produce  json
+// @Param apply query bool false "Repair the reported drift"
+// @Success 200 {object} dto.ResponseDto
+// @Router /inventory-service/api/v1/admin/inventory/reconcile [POST]
+// ReconcileConfigurations : This function will report and optionally repair drift between configurations and collections
+func (bc InventoryConfigurationController) ReconcileConfigurations() gin.HandlerFunc {
+	fn := func(c *gin.Context) {
+		methodName := "ReconcileConfigurations"
+		log := logger.GetLogger()
+		ctx := context.Background()
+
+		apply, _ := strconv.ParseBool(c.Query("apply"))
+
+		report, errDto := bc.InventoryConfigurationService.ReconcileInventoryConfigurations(ctx, apply)
+		if errDto != nil {
+			log.Info("Inside " + methodName + " unable to reconcile inventory configurations")
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errDto.StatusCode,
+				Message:    errDto.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+			Data:       report,
+		})
+	}
+	return fn
+}
@@ -2,18 +2,28 @@ package controller
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"go.mongodb.org/mongo-driver/bson"
+	"inventory-system/common/pkg/constants"
 	"inventory-system/common/pkg/dto"
 	"inventory-system/common/pkg/logger"
+	commonDto "inventory-system/inventory-service/internal/common/dto"
 	"inventory-system/inventory-service/internal/common/dto/request_dto"
+	"inventory-system/inventory-service/internal/common/export"
+	"inventory-system/inventory-service/internal/common/importer"
 	"inventory-system/inventory-service/internal/common/status_code"
 	"inventory-system/inventory-service/internal/domain/service"
+	"inventory-system/inventory-service/internal/ports/utils"
 	"io"
 	"net/http"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 type InventoryController struct {
@@ -35,6 +45,7 @@ func NewInventoryController(inventoryService service.IInventoryService) *Invento
 // @Success 200 {object} dto.ResponseDto
 // @Param requestBody body bson.M true "Add item to inventory request"
 // @Param inventoryName path string true "Inventory Key"
+// @Param X-User-Id header string false "Caller stamped as created_by and updated_by"
 // @Router /inventory-service/api/v1/inventory/configurations/{inventoryName} [POST]
 // AddNewInventory : This function will an item from inventory
 func (cc InventoryController) AddNewInventory() gin.HandlerFunc {
@@ -56,7 +67,7 @@ func (cc InventoryController) AddNewInventory() gin.HandlerFunc {
 			})
 			return
 		}
-		errorDto := cc.InventoryService.CreateNewInventory(ctx, requestBody, inventoryName)
+		item, errorDto := cc.InventoryService.CreateNewInventory(ctx, requestBody, inventoryName, utils.GetCaller(c))
 		if errorDto != nil {
 			log.Error("Inside "+methodName+" error while adding inventory: ", requestBody)
 			c.JSON(http.StatusOK, dto.ResponseDto{
@@ -65,9 +76,138 @@ func (cc InventoryController) AddNewInventory() gin.HandlerFunc {
 			})
 			return
 		}
+		utils.SetItemETag(c, item)
 		c.JSON(http.StatusOK, dto.ResponseDto{
 			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
 			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+			Data:       item,
+		})
+	}
+	return fn
+}
+
+// BulkAddNewInventory  godoc
+// @Summary Add items to inventory in bulk
+// @Description Add a JSON array or an NDJSON stream of items to inventory, the response holds the result of every item
+// @Tags Inventory
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.ResponseDto
+// @Param requestBody body []bson.M true "Items to add to inventory"
+// @Param inventoryName path string true "Inventory Key"
+// @Param ordered query bool false "Stop at the first failing item"
+// @Param X-User-Id header string false "Caller stamped as created_by and updated_by"
+// @Router /inventory-service/api/v1/inventory/{inventoryName}/bulk [POST]
+// BulkAddNewInventory : This function will add many items to inventory
+func (cc InventoryController) BulkAddNewInventory() gin.HandlerFunc {
+	fn := func(c *gin.Context) {
+		methodName := "BulkAddNewInventory"
+		log := logger.GetLogger()
+		ctx := context.Background()
+		inventoryName := c.Param("inventoryName")
+		var portErr dto.ErrorResponseDto
+
+		ordered := false
+		if c.Query("ordered") != "" {
+			var err error
+			ordered, err = strconv.ParseBool(c.Query("ordered"))
+			if err != nil {
+				log.Info("Inside "+methodName+" invalid ordered flag: ", c.Query("ordered"))
+				portErr.SetError(status_code.IMS400)
+				c.JSON(http.StatusOK, dto.ResponseDto{
+					StatusCode: portErr.StatusCode,
+					Message:    portErr.Message,
+				})
+				return
+			}
+		}
+		items, err := utils.DecodeBulkItems(http.MaxBytesReader(c.Writer, c.Request.Body, constants.MaxBulkInsertBodySize))
+		var tooLargeErr *http.MaxBytesError
+		if errors.As(err, &tooLargeErr) {
+			log.Info("Inside "+methodName+" bulk request body too large for: ", inventoryName)
+			portErr.SetError(status_code.IMS139)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: portErr.StatusCode,
+				Message:    portErr.Message + " : request body must not be larger than " + strconv.Itoa(constants.MaxBulkInsertBodySize>>20) + " MB",
+			})
+			return
+		}
+		if err != nil {
+			log.Info("Inside "+methodName+" unable to decode items: ", err.Error())
+			portErr.SetError(status_code.IMS400)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: portErr.StatusCode,
+				Message:    portErr.Message + " : " + err.Error(),
+			})
+			return
+		}
+		report, errorDto := cc.InventoryService.BulkCreateInventory(ctx, items, inventoryName, utils.GetCaller(c), ordered)
+		if errorDto != nil {
+			log.Error("Inside "+methodName+" error while bulk adding inventory into: ", inventoryName)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errorDto.StatusCode,
+				Message:    errorDto.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+			Data:       report,
+		})
+	}
+	return fn
+}
+
+// UpsertInventory  godoc
+// @Summary Create or replace an item by a unique identifier
+// @Description Replace the item whose unique identifier key has the value given in the body, or create it when there is none
+// @Tags Inventory
+// @Produce  json
+// @Success 200 {object} dto.ResponseDto
+// @Param requestBody body bson.M true "Item to create or replace"
+// @Param inventoryName path string true "Inventory Key"
+// @Param key query string true "Unique inventory identifier to match on"
+// @Param X-User-Id header string false "Caller stamped as created_by and updated_by"
+// @Router /inventory-service/api/v1/inventory/{inventoryName} [PUT]
+// UpsertInventory : This function will create or replace an item of inventory
+func (cc InventoryController) UpsertInventory() gin.HandlerFunc {
+	fn := func(c *gin.Context) {
+		methodName := "UpsertInventory"
+		log := logger.GetLogger()
+		ctx := context.Background()
+		inventoryName := c.Param("inventoryName")
+		key := c.Query("key")
+		var requestBody interface{}
+		var portErr dto.ErrorResponseDto
+
+		err := c.ShouldBindJSON(&requestBody)
+		if err != nil || key == "" {
+			log.Info("Inside "+methodName+" invalid upsert request for: ", inventoryName)
+			portErr.SetError(status_code.IMS400)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: portErr.StatusCode,
+				Message:    portErr.Message,
+			})
+			return
+		}
+		item, isCreated, errorDto := cc.InventoryService.UpsertInventory(ctx, requestBody, inventoryName, key, utils.GetCaller(c))
+		if errorDto != nil {
+			log.Error("Inside "+methodName+" error while upserting inventory: ", requestBody)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errorDto.StatusCode,
+				Message:    errorDto.Message,
+			})
+			return
+		}
+		utils.SetItemETag(c, item)
+		c.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+			Data: bson.M{
+				"created": isCreated,
+				"item":    item,
+			},
 		})
 	}
 	return fn
@@ -81,6 +221,8 @@ func (cc InventoryController) AddNewInventory() gin.HandlerFunc {
 // @Success 200 {object} dto.ResponseDto
 // @Param inventoryName path string true "Inventory Key"
 // @Param {inventory_identifier_key} query string true "inventory Identifier Value"
+// @Param fields query string false "Comma separated fields to return, id and version are always returned"
+// @Param exclude query string false "Comma separated fields to leave out, cannot be combined with fields"
 // @Router /inventory-service/api/v1/{inventoryName}/inventory [GET]
 // GetInventory : This function will fetch an item from inventory
 func (cc InventoryController) GetInventory() gin.HandlerFunc {
@@ -89,10 +231,13 @@ func (cc InventoryController) GetInventory() gin.HandlerFunc {
 		log := logger.GetLogger()
 		ctx := context.Background()
 		filterMap := c.Request.URL.Query()
+		fields, exclude := filterMap.Get("fields"), filterMap.Get("exclude")
+		filterMap.Del("fields")
+		filterMap.Del("exclude")
 
 		inventoryConfigurationName := c.Param("inventoryName")
 
-		inventory, errDto := cc.InventoryService.GetInventory(ctx, inventoryConfigurationName, filterMap)
+		inventory, errDto := cc.InventoryService.GetInventory(ctx, inventoryConfigurationName, filterMap, fields, exclude)
 		if errDto != nil {
 			log.Info("Inside "+methodName+" unable to fetch inventory for inventoryConfigurationName :", inventoryConfigurationName, " filterMap: ", filterMap)
 			c.JSON(http.StatusOK, dto.ResponseDto{
@@ -101,6 +246,7 @@ func (cc InventoryController) GetInventory() gin.HandlerFunc {
 			})
 			return
 		}
+		utils.SetItemETag(c, inventory)
 		c.JSON(http.StatusOK, dto.ResponseDto{
 			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
 			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
@@ -112,12 +258,18 @@ func (cc InventoryController) GetInventory() gin.HandlerFunc {
 
 // GetInventoryV2  godoc
 // @Summary Get an item from inventory
-// @Description Get Inventory
+// @Description Get Inventory items matching a filter document. A field maps to a value, a list of values or operators eq, ne, gt, gte, lt, lte, in, nin, exists, prefix, regex and elemMatch; and/or combine lists of filters. regex takes a mongo PCRE pattern of at most 256 characters
 // @Tags Inventory
 // @Produce  json
 // @Success 200 {object} dto.ResponseDto
 // @Param inventoryName path string true "Inventory Key"
-// @Param {inventory_identifier_key} query string true "inventory Identifier Value"
+// @Param filter body object true "Filter document on inventory identifier keys"
+// @Param page query int false "Page number, cannot be combined with cursor"
+// @Param page_size query int false "Page size"
+// @Param cursor query string false "next_cursor or prev_cursor of a previous page, empty for the first page"
+// @Param sort query string false "Comma separated indexed identifiers to sort on, descending when prefixed with -"
+// @Param fields query string false "Comma separated fields to return, id is always returned"
+// @Param exclude query string false "Comma separated fields to leave out, cannot be combined with fields"
 // @Router /inventory-service/api/v1/{inventoryName}/inventory [GET]
 // GetInventory : This function will fetch an item from inventory
 func (cc InventoryController) GetInventoryV2() gin.HandlerFunc {
@@ -129,9 +281,9 @@ func (cc InventoryController) GetInventoryV2() gin.HandlerFunc {
 		to := c.Query("to")
 
 		inventoryConfigurationName := c.Param("inventoryName")
-		var filterMap map[string][]string
+		var filterDocument map[string]interface{}
 		var portErr dto.ErrorResponseDto
-		err := c.ShouldBindJSON(&filterMap)
+		err := c.ShouldBindJSON(&filterDocument)
 		if err != nil {
 
 			log.Info("Filter Err")
@@ -142,9 +294,9 @@ func (cc InventoryController) GetInventoryV2() gin.HandlerFunc {
 			})
 			return
 		}
-		inventory, pagination, errDto := cc.InventoryService.GetInventoryV2(c, from, to, inventoryConfigurationName, filterMap)
+		inventory, pagination, errDto := cc.InventoryService.GetInventoryV2(c, from, to, inventoryConfigurationName, filterDocument)
 		if errDto != nil {
-			log.Info("Inside "+methodName+" unable to fetch inventory for inventoryConfigurationName :", inventoryConfigurationName, " filterMap: ", filterMap)
+			log.Info("Inside "+methodName+" unable to fetch inventory for inventoryConfigurationName :", inventoryConfigurationName, " filter: ", filterDocument)
 			c.JSON(http.StatusOK, dto.ResponseDto{
 				StatusCode: errDto.StatusCode,
 				Message:    errDto.Message,
@@ -159,14 +311,28 @@ func (cc InventoryController) GetInventoryV2() gin.HandlerFunc {
 
 		log.Info("Pagination", pagination)
 
+		if _, isCursor := c.GetQuery("cursor"); isCursor && pagination != nil {
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+				Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+				Data: bson.M{
+					"page_size":   pagination.PageSize,
+					"next_cursor": pagination.NextCursor,
+					"prev_cursor": pagination.PrevCursor,
+					"items":       data,
+				},
+			})
+			return
+		}
 		if pagination != nil {
 			c.JSON(http.StatusOK, dto.ResponseDto{
 				StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
 				Message:    dto.GetStatusDetails(status_code.IMS200).Message,
 				Data: bson.M{
-					"count": pagination.Count,
-					"page":  pagination.PageNumber,
-					"items": data,
+					"count":     pagination.Count,
+					"page":      pagination.PageNumber,
+					"page_size": pagination.PageSize,
+					"items":     data,
 				},
 			})
 			return
@@ -186,7 +352,7 @@ func (cc InventoryController) RemoveItemFromInventory() gin.HandlerFunc {
 		methodName := "RemoveItemFromInventory"
 		log := logger.GetLogger()
 		log.Info("Inside " + methodName)
-		var errorDto *dto.ErrorResponseDto
+		var errorDto dto.ErrorResponseDto
 		var RemoveItemRequest *request_dto.RemoveInventoryItem
 		InventoryName := ctx.Param("inventoryName")
 
@@ -202,7 +368,7 @@ func (cc InventoryController) RemoveItemFromInventory() gin.HandlerFunc {
 
 		log.Info("RemoveItemRequest", RemoveItemRequest)
 
-		errDto := cc.InventoryService.RemoveItemFromInventory(ctx, RemoveItemRequest, InventoryName)
+		errDto := cc.InventoryService.RemoveItemFromInventory(ctx, RemoveItemRequest, InventoryName, utils.GetCaller(ctx))
 		if errDto != nil {
 			log.Info("Inside " + methodName + " unable to fetch inventory for inventoryConfigurationName :")
 			ctx.JSON(http.StatusOK, dto.ResponseDto{
@@ -229,7 +395,17 @@ func (cc InventoryController) UpdateInventory() gin.HandlerFunc {
 		InventoryName := ctx.Param("inventoryName")
 		Id := ctx.Param("id")
 
-		bindErr := ctx.ShouldBind(&UpdateRequest)
+		updateFormat, formatErr := utils.GetUpdateFormat(ctx)
+		if formatErr != nil {
+			log.Info("Unsupported update content type", formatErr)
+			ctx.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: dto.GetStatusDetails(status_code.IMS400).StatusCode,
+				Message:    dto.GetStatusDetails(status_code.IMS400).Message + " : " + formatErr.Error(),
+			})
+			return
+		}
+
+		bindErr := ctx.ShouldBindWith(&UpdateRequest, binding.JSON)
 		if bindErr != nil {
 			log.Info("Invalid Request Body", bindErr)
 			ctx.JSON(http.StatusOK, dto.ResponseDto{
@@ -239,13 +415,24 @@ func (cc InventoryController) UpdateInventory() gin.HandlerFunc {
 			return
 		}
 
-		errorDto := cc.InventoryService.UpdateInventory(Id, InventoryName, UpdateRequest)
+		expectedVersion, ifMatchErr := utils.GetIfMatchVersion(ctx)
+		if ifMatchErr != nil {
+			log.Info("Invalid If-Match header", ifMatchErr)
+			ctx.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: dto.GetStatusDetails(status_code.IMS400).StatusCode,
+				Message:    dto.GetStatusDetails(status_code.IMS400).Message + " : " + ifMatchErr.Error(),
+			})
+			return
+		}
+
+		version, errorDto := cc.InventoryService.UpdateInventory(Id, InventoryName, UpdateRequest, utils.GetCaller(ctx), expectedVersion, updateFormat)
 		if errorDto != nil {
 			log.Info("There is an issue while updating Inventory", errorDto)
 			ctx.JSON(http.StatusOK, errorDto)
 			return
 		}
 
+		ctx.Header("ETag", utils.ItemETag(version))
 		ctx.JSON(http.StatusOK, dto.ResponseDto{
 			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
 			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
@@ -254,6 +441,19 @@ func (cc InventoryController) UpdateInventory() gin.HandlerFunc {
 	}
 }
 
+// GetInventoryFilter  godoc
+// @Summary Distinct values of an inventory identifier
+// @Description Distinct values of an identifier among the items matching the other query parameters. Giving page, page_size or prefix pages the values in ascending order
+// @Tags Inventory
+// @Produce  json
+// @Success 200 {object} dto.ResponseDto
+// @Param inventoryName path string true "Inventory Key"
+// @Param filterName path string true "Identifier to list the values of"
+// @Param prefix query string false "Only values starting with the prefix, ignoring case"
+// @Param page query int false "Page number starting at 0"
+// @Param page_size query int false "Values per page"
+// @Router /inventory-service/api/v2/inventory/filter/{inventoryName}/{filterName} [GET]
+// GetInventoryFilter : This function will list the distinct values of an inventory identifier
 func (cc InventoryController) GetInventoryFilter() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		methodName := "UpdateInventory"
@@ -262,14 +462,31 @@ func (cc InventoryController) GetInventoryFilter() gin.HandlerFunc {
 		InventoryName := ctx.Param("inventoryName")
 		FilterName := ctx.Param("filterName")
 		filters := ctx.Request.URL.Query()
+		prefix, page, pageSize := filters.Get("prefix"), filters.Get("page"), filters.Get("page_size")
+		filters.Del("prefix")
+		filters.Del("page")
+		filters.Del("page_size")
 
-		data, errorDto := cc.InventoryService.GetInventoryFilter(ctx, InventoryName, FilterName, filters)
+		data, pagination, errorDto := cc.InventoryService.GetInventoryFilter(ctx, InventoryName, FilterName, filters, prefix, page, pageSize)
 		if errorDto != nil {
 			log.Info("There is an issue while get Inventory", errorDto)
 			ctx.JSON(http.StatusOK, errorDto)
 			return
 		}
 
+		if pagination != nil {
+			ctx.JSON(http.StatusOK, dto.ResponseDto{
+				Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+				StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+				Data: bson.M{
+					"count":     pagination.Count,
+					"page":      pagination.PageNumber,
+					"page_size": pagination.PageSize,
+					"values":    data,
+				},
+			})
+			return
+		}
 		ctx.JSON(http.StatusOK, dto.ResponseDto{
 			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
 			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
@@ -278,6 +495,53 @@ func (cc InventoryController) GetInventoryFilter() gin.HandlerFunc {
 	}
 }
 
+// GetInventoryFacets  godoc
+// @Summary Facet values of an inventory with item counts
+// @Description Values of each facet identifier with the count of live items matching the filter, most frequent first. The filter condition on a facet identifier does not narrow that facet, so a facet identifier can only be filtered at the top level of the filter and not inside and/or
+// @Tags Inventory
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.ResponseDto
+// @Param inventoryName path string true "Inventory Key"
+// @Param requestBody body request_dto.FacetRequest true "Facet identifiers, active filter and values per facet"
+// @Router /inventory-service/api/v2/inventory/{inventoryName}/facets [POST]
+// GetInventoryFacets : This function will count the items of an inventory per value of each facet
+func (cc InventoryController) GetInventoryFacets() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		methodName := "GetInventoryFacets"
+		log := logger.GetLogger()
+		log.Info("Inside " + methodName)
+		inventoryName := ctx.Param("inventoryName")
+		var facetRequest request_dto.FacetRequest
+		var portErr dto.ErrorResponseDto
+
+		if err := ctx.ShouldBindJSON(&facetRequest); err != nil {
+			log.Info("Inside "+methodName+" invalid facet request for: ", inventoryName, " : ", err.Error())
+			portErr.SetError(status_code.IMS400)
+			ctx.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: portErr.StatusCode,
+				Message:    portErr.Message,
+			})
+			return
+		}
+		facets, errDto := cc.InventoryService.GetInventoryFacets(ctx, inventoryName, facetRequest)
+		if errDto != nil {
+			log.Info("Inside "+methodName+" unable to compute facets for inventoryName :", inventoryName)
+			ctx.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errDto.StatusCode,
+				Message:    errDto.Message,
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+			Data:       bson.M{"facets": facets},
+		})
+	}
+}
+
 func (cc InventoryController) RemoveSubjectTopicsByLessonNameAndSubjectId() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		methodName := "RemoveSubjectTopicsByLessonNameAndSubjectId"
@@ -344,7 +608,7 @@ func (cc InventoryController) UpdateInventoryTopic() gin.HandlerFunc {
 			return
 		}
 
-		errDto := cc.InventoryService.UpdateInventoryTopic(ctx, InventoryTopicUpdate, TopicId)
+		errDto := cc.InventoryService.UpdateInventoryTopic(ctx, InventoryTopicUpdate, TopicId, utils.GetCaller(ctx))
 		if errDto != nil {
 			log.Info("Inside " + methodName + " unable to update Inventory Topic")
 			ctx.JSON(http.StatusOK, dto.ResponseDto{
@@ -369,7 +633,7 @@ func (cc InventoryController) ActivateResourceById() gin.HandlerFunc {
 		InventoryName := ctx.Param("inventoryName")
 		Id := ctx.Query("id")
 
-		errDto := cc.InventoryService.ActivateResourceById(ctx, InventoryName, Id)
+		errDto := cc.InventoryService.ActivateResourceById(ctx, InventoryName, Id, utils.GetCaller(ctx))
 		if errDto != nil {
 			log.Info("Inside " + methodName + " unable to update Inventory Topic")
 			ctx.JSON(http.StatusOK, dto.ErrorResponseDto{
@@ -386,6 +650,382 @@ func (cc InventoryController) ActivateResourceById() gin.HandlerFunc {
 	}
 }
 
+// GetInventoryTrash  godoc
+// @Summary List the deleted items of an inventory
+// @Description List the soft deleted items of an inventory with their deletion metadata, most recently deleted first
+// @Tags Inventory
+// @Produce  json
+// @Success 200 {object} dto.ResponseDto
+// @Param inventoryName path string true "Inventory Key"
+// @Param page query int false "Page number starting at 0"
+// @Param page_size query int false "Items per page"
+// @Router /inventory-service/api/v1/inventory/{inventoryName}/trash [GET]
+// GetInventoryTrash : This function will list the deleted items of an inventory
+func (cc InventoryController) GetInventoryTrash() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		methodName := "GetInventoryTrash"
+		log := logger.GetLogger()
+		log.Info("Inside " + methodName)
+		inventoryName := ctx.Param("inventoryName")
+
+		items, pagination, errDto := cc.InventoryService.GetInventoryTrash(ctx, inventoryName, ctx.Query("page"), ctx.Query("page_size"))
+		if errDto != nil {
+			log.Info("Inside "+methodName+" unable to fetch deleted items for inventoryName :", inventoryName)
+			ctx.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errDto.StatusCode,
+				Message:    errDto.Message,
+				Data:       []bson.M{},
+			})
+			return
+		}
+		data := []bson.M{}
+		if len(items) > 0 {
+			data = items
+		}
+
+		ctx.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+			Data: bson.M{
+				"count":     pagination.Count,
+				"page":      pagination.PageNumber,
+				"page_size": pagination.PageSize,
+				"items":     data,
+			},
+		})
+	}
+}
+
+// SearchInventory  godoc
+// @Summary Search the items of an inventory
+// @Description Full text search over the searchable fields of an inventory, most relevant items first with highlighted snippets of the fields that matched
+// @Tags Inventory
+// @Produce  json
+// @Success 200 {object} dto.ResponseDto
+// @Param inventoryName path string true "Inventory Key"
+// @Param q query string true "Words to search for, quoted phrases and -negated words are supported"
+// @Param page query int false "Page number starting at 0"
+// @Param page_size query int false "Items per page"
+// @Router /inventory-service/api/v1/inventory/{inventoryName}/search [GET]
+// SearchInventory : This function will search the items of an inventory
+func (cc InventoryController) SearchInventory() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		methodName := "SearchInventory"
+		log := logger.GetLogger()
+		log.Info("Inside " + methodName)
+		inventoryName := ctx.Param("inventoryName")
+
+		results, pagination, errDto := cc.InventoryService.SearchInventory(ctx, inventoryName, ctx.Query("q"), ctx.Query("page"), ctx.Query("page_size"))
+		if errDto != nil {
+			log.Info("Inside "+methodName+" unable to search items for inventoryName :", inventoryName)
+			ctx.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errDto.StatusCode,
+				Message:    errDto.Message,
+				Data:       []bson.M{},
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+			Data: bson.M{
+				"count":     pagination.Count,
+				"page":      pagination.PageNumber,
+				"page_size": pagination.PageSize,
+				"items":     results,
+			},
+		})
+	}
+}
+
+// AggregateInventory  godoc
+// @Summary Aggregate the items of an inventory
+// @Description Group the live items matching the filter by identifiers with the count of each group and sum, avg, min or max metrics over numeric fields
+// @Tags Inventory
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.ResponseDto
+// @Param inventoryName path string true "Inventory Key"
+// @Param requestBody body request_dto.AggregateRequest true "Group fields, metrics, filter, sort and limit of the aggregation"
+// @Router /inventory-service/api/v1/inventory/{inventoryName}/aggregate [POST]
+// AggregateInventory : This function will compute grouped counts and metrics over the items of an inventory
+func (cc InventoryController) AggregateInventory() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		methodName := "AggregateInventory"
+		log := logger.GetLogger()
+		log.Info("Inside " + methodName)
+		inventoryName := ctx.Param("inventoryName")
+		var aggregateRequest request_dto.AggregateRequest
+		var portErr dto.ErrorResponseDto
+
+		if err := ctx.ShouldBindJSON(&aggregateRequest); err != nil {
+			log.Info("Inside "+methodName+" invalid aggregation request for: ", inventoryName, " : ", err.Error())
+			portErr.SetError(status_code.IMS400)
+			ctx.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: portErr.StatusCode,
+				Message:    portErr.Message,
+			})
+			return
+		}
+		result, errDto := cc.InventoryService.AggregateInventory(ctx, inventoryName, aggregateRequest)
+		if errDto != nil {
+			log.Info("Inside "+methodName+" unable to aggregate items for inventoryName :", inventoryName)
+			ctx.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errDto.StatusCode,
+				Message:    errDto.Message,
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+			Data:       result,
+		})
+	}
+}
+
+// ExportInventory  godoc
+// @Summary Export the items of an inventory
+// @Description Stream the live items matching the filter as ndjson, csv or json. Csv columns are the id, the schema properties flattened into dot paths and the item metadata
+// @Tags Inventory
+// @Produce  json,text/csv,application/x-ndjson
+// @Success 200 {object} dto.ResponseDto
+// @Param inventoryName path string true "Inventory Key"
+// @Param format query string false "ndjson, csv or json, ndjson by default"
+// @Param filter query string false "Filter document on inventory identifier keys, json encoded"
+// @Param from query string false "Only items created from this date"
+// @Param to query string false "Only items created up to this date"
+// @Param sort query string false "Comma separated indexed identifiers to sort on, descending when prefixed with -"
+// @Param fields query string false "Comma separated fields to return, id is always returned"
+// @Param exclude query string false "Comma separated fields to leave out, cannot be combined with fields"
+// @Router /inventory-service/api/v1/inventory/{inventoryName}/export [GET]
+// ExportInventory : This function will stream the items of an inventory as a file
+func (cc InventoryController) ExportInventory() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		methodName := "ExportInventory"
+		log := logger.GetLogger()
+		log.Info("Inside " + methodName)
+		inventoryName := ctx.Param("inventoryName")
+		exportRequest := request_dto.ExportRequest{
+			Format:  ctx.DefaultQuery("format", export.FormatNDJSON),
+			From:    ctx.Query("from"),
+			To:      ctx.Query("to"),
+			Sort:    ctx.Query("sort"),
+			Fields:  ctx.Query("fields"),
+			Exclude: ctx.Query("exclude"),
+		}
+		var portErr dto.ErrorResponseDto
+
+		if filterDocument := ctx.Query("filter"); filterDocument != "" {
+			if err := json.Unmarshal([]byte(filterDocument), &exportRequest.Filter); err != nil {
+				log.Info("Inside "+methodName+" invalid filter for: ", inventoryName, " : ", err.Error())
+				portErr.SetError(status_code.IMS151)
+				portErr.Message = portErr.Message + " : filter must be a json object"
+				ctx.JSON(http.StatusOK, dto.ResponseDto{
+					StatusCode: portErr.StatusCode,
+					Message:    portErr.Message,
+				})
+				return
+			}
+		}
+
+		writer := &exportWriter{ctx: ctx, contentType: export.ContentType(exportRequest.Format), fileName: inventoryName + "." + exportRequest.Format}
+		errDto := cc.InventoryService.ExportInventory(ctx, inventoryName, exportRequest, writer)
+		if errDto == nil {
+			return
+		}
+		if ctx.Writer.Written() {
+			//The export already started streaming with a 200, the connection is cut so the client sees an incomplete
+			//transfer instead of a file which looks complete
+			log.Error("Inside "+methodName+" export of "+inventoryName+" aborted : ", errDto.Message)
+			panic(http.ErrAbortHandler)
+		}
+		log.Info("Inside "+methodName+" unable to export items for inventoryName :", inventoryName)
+		ctx.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: errDto.StatusCode,
+			Message:    errDto.Message,
+		})
+	}
+}
+
+// exportWriter : response writer of an export, the file headers are set on the first write so errors found before
+// streaming starts are still returned in a json response
+type exportWriter struct {
+	ctx         *gin.Context
+	contentType string
+	fileName    string
+}
+
+func (w *exportWriter) Write(data []byte) (int, error) {
+	if !w.ctx.Writer.Written() {
+		w.ctx.Header("Content-Type", w.contentType)
+		w.ctx.Header("Content-Disposition", "attachment; filename=\""+w.fileName+"\"")
+		w.ctx.Status(http.StatusOK)
+	}
+	return w.ctx.Writer.Write(data)
+}
+
+// ImportInventory  godoc
+// @Summary Import items into an inventory from a file
+// @Description Import the rows of a csv or ndjson file, coerced to the inventory schema and validated. Csv files start with a header of dot paths. Insert creates an item per row, upsert replaces the live item having the key value of the row and replace_all replaces the live items only when every row is valid. The report lists the rejected rows and their reason, as a csv file when report is csv
+// @Tags Inventory
+// @Accept  mpfd
+// @Produce  json,text/csv
+// @Success 200 {object} dto.ResponseDto
+// @Param inventoryName path string true "Inventory Key"
+// @Param file formData file true "Csv or ndjson file of items"
+// @Param format query string false "csv or ndjson, by default the extension of the file"
+// @Param mode query string false "insert, upsert or replace_all, insert by default"
+// @Param key query string false "Unique inventory identifier matching rows to items in upsert mode"
+// @Param dry_run query bool false "Validate the rows without writing them"
+// @Param report query string false "csv to download the rejected rows as a csv file"
+// @Param X-User-Id header string false "Caller stamped as created_by and updated_by"
+// @Router /inventory-service/api/v1/inventory/{inventoryName}/import [POST]
+// ImportInventory : This function will import the items of a file into an inventory
+func (cc InventoryController) ImportInventory() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		methodName := "ImportInventory"
+		log := logger.GetLogger()
+		log.Info("Inside " + methodName)
+		inventoryName := ctx.Param("inventoryName")
+		var portErr dto.ErrorResponseDto
+		invalid := func(detail string) {
+			portErr.SetError(status_code.IMS162)
+			ctx.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: portErr.StatusCode,
+				Message:    portErr.Message + " : " + detail,
+			})
+		}
+
+		importRequest := request_dto.ImportRequest{
+			Format: ctx.Query("format"),
+			Mode:   ctx.Query("mode"),
+			Key:    ctx.Query("key"),
+		}
+		if ctx.Query("dry_run") != "" {
+			var err error
+			importRequest.DryRun, err = strconv.ParseBool(ctx.Query("dry_run"))
+			if err != nil {
+				invalid("dry_run must be a boolean")
+				return
+			}
+		}
+		reportFormat := ctx.Query("report")
+		if reportFormat != "" && reportFormat != "json" && reportFormat != importer.FormatCSV {
+			invalid("report must be json or csv")
+			return
+		}
+
+		//The form is parsed from a bounded body so an oversized upload is not read in full before its size is checked
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, constants.MaxImportRequestSize)
+		fileHeader, err := ctx.FormFile("file")
+		var tooLargeErr *http.MaxBytesError
+		if errors.As(err, &tooLargeErr) || (err == nil && fileHeader.Size > constants.MaxImportFileSize) {
+			log.Info("Inside "+methodName+" import file too large for: ", inventoryName)
+			invalid("file must not be larger than " + strconv.Itoa(constants.MaxImportFileSize>>20) + " MB")
+			return
+		}
+		if err != nil {
+			log.Info("Inside "+methodName+" no import file for: ", inventoryName, " : ", err.Error())
+			invalid("file is required")
+			return
+		}
+		if importRequest.Format == "" {
+			importRequest.Format = importer.FormatOf(fileHeader.Filename)
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			log.Error("Inside "+methodName+" unable to open import file for: ", inventoryName, " : ", err.Error())
+			invalid("file cannot be read")
+			return
+		}
+		defer file.Close()
+
+		report, errDto := cc.InventoryService.ImportInventory(ctx, inventoryName, importRequest, file, utils.GetCaller(ctx))
+		if errDto != nil {
+			log.Info("Inside "+methodName+" unable to import items for inventoryName :", inventoryName)
+			ctx.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errDto.StatusCode,
+				Message:    errDto.Message,
+			})
+			return
+		}
+		if reportFormat == importer.FormatCSV {
+			writeImportRejections(ctx, inventoryName, report.Rejected)
+			return
+		}
+		ctx.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+			Data:       report,
+		})
+	}
+}
+
+// writeImportRejections : downloads the rejected rows of an import as a csv file with their row, status code and reason
+func writeImportRejections(ctx *gin.Context, inventoryName string, rejected []commonDto.ImportRejection) {
+	ctx.Header("Content-Type", export.ContentType(export.FormatCSV))
+	ctx.Header("Content-Disposition", "attachment; filename=\""+inventoryName+"-import-rejections.csv\"")
+	ctx.Status(http.StatusOK)
+	writer := csv.NewWriter(ctx.Writer)
+	_ = writer.Write([]string{"row", "status_code", "reason"})
+	for _, rejection := range rejected {
+		_ = writer.Write([]string{strconv.Itoa(rejection.Row), string(rejection.StatusCode), rejection.Reason})
+	}
+	writer.Flush()
+}
+
+// PurgeInventory  godoc
+// @Summary Purge an inventory by its retention policy
+// @Description Hard delete the soft deleted items and old revisions the retention policy of the inventory no longer keeps. A dry run only reports the counts
+// @Tags Inventory
+// @Produce  json
+// @Success 200 {object} dto.ResponseDto
+// @Param inventoryName path string true "Inventory Key"
+// @Param dry_run query bool false "Report what would be removed without removing it"
+// @Router /inventory-service/api/v1/inventory/{inventoryName}/purge [POST]
+// PurgeInventory : This function will apply the retention policy of an inventory
+func (cc InventoryController) PurgeInventory() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		methodName := "PurgeInventory"
+		log := logger.GetLogger()
+		log.Info("Inside " + methodName)
+		inventoryName := ctx.Param("inventoryName")
+		var portErr dto.ErrorResponseDto
+
+		dryRun := false
+		if ctx.Query("dry_run") != "" {
+			var err error
+			dryRun, err = strconv.ParseBool(ctx.Query("dry_run"))
+			if err != nil {
+				portErr.SetError(status_code.IMS400)
+				ctx.JSON(http.StatusOK, dto.ResponseDto{
+					StatusCode: portErr.StatusCode,
+					Message:    portErr.Message + " : dry_run must be a boolean",
+				})
+				return
+			}
+		}
+
+		retention, errDto := cc.InventoryService.PurgeInventory(ctx, inventoryName, dryRun)
+		if errDto != nil {
+			log.Info("Inside "+methodName+" unable to purge inventory for inventoryName :", inventoryName)
+			ctx.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errDto.StatusCode,
+				Message:    errDto.Message,
+			})
+			return
+		}
+		ctx.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+			Data:       retention,
+		})
+	}
+}
+
 func (cc InventoryController) CreateResource() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		methodName := "CreateResource"
@@ -404,6 +1044,9 @@ func (cc InventoryController) CreateResource() gin.HandlerFunc {
 		topicId := ctx.PostForm("topic_id")
 		fileType := ctx.PostForm("file_type")
 		createdBy := ctx.PostForm("created_by")
+		if createdBy == "" {
+			createdBy = utils.GetCaller(ctx)
+		}
 		topicName := ctx.PostForm("topic_name")
 		file_request_id := ctx.PostForm("file_request_id")
 
@@ -464,3 +1107,228 @@ func (cc InventoryController) CreateResource() gin.HandlerFunc {
 		})
 	}
 }
+
+// ListItemRevisions  godoc
+// @Summary List the revisions of an item
+// @Description List every recorded mutation of an item oldest first, with its actor, timestamp and JSON Patch diff
+// @Tags Inventory
+// @Produce  json
+// @Success 200 {object} dto.ResponseDto
+// @Param inventoryName path string true "Inventory Key"
+// @Param id path string true "Item id"
+// @Router /inventory-service/api/v1/inventory/{inventoryName}/items/{id}/revisions [GET]
+// ListItemRevisions : This function will list the revisions of an item
+func (cc InventoryController) ListItemRevisions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		methodName := "ListItemRevisions"
+		log := logger.GetLogger()
+		inventoryName := c.Param("inventoryName")
+		id := c.Param("id")
+
+		revisions, errorDto := cc.InventoryService.ListItemRevisions(c, inventoryName, id)
+		if errorDto != nil {
+			log.Error("Inside "+methodName+" error while listing revisions of item : "+id+" in ", inventoryName)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errorDto.StatusCode,
+				Message:    errorDto.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+			Data:       revisions,
+		})
+	}
+}
+
+// GetItemAsOf  godoc
+// @Summary Get an item as of a version or timestamp
+// @Description Get the revision of an item at a version, or the latest revision recorded at or before a timestamp, with the item as it was stored then
+// @Tags Inventory
+// @Produce  json
+// @Success 200 {object} dto.ResponseDto
+// @Param inventoryName path string true "Inventory Key"
+// @Param id path string true "Item id"
+// @Param version query int false "Version of the item"
+// @Param timestamp query string false "RFC3339 timestamp"
+// @Router /inventory-service/api/v1/inventory/{inventoryName}/items/{id}/as-of [GET]
+// GetItemAsOf : This function will get an item as it was at a version or timestamp
+func (cc InventoryController) GetItemAsOf() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		methodName := "GetItemAsOf"
+		log := logger.GetLogger()
+		inventoryName := c.Param("inventoryName")
+		id := c.Param("id")
+		var portErr dto.ErrorResponseDto
+
+		if (c.Query("version") == "") == (c.Query("timestamp") == "") {
+			log.Info("Inside " + methodName + " expected exactly one of version and timestamp")
+			portErr.SetError(status_code.IMS146)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: portErr.StatusCode,
+				Message:    portErr.Message + " : expected exactly one of version and timestamp",
+			})
+			return
+		}
+
+		var revision *commonDto.ItemRevision
+		var errorDto *dto.ErrorResponseDto
+		if c.Query("version") != "" {
+			version, err := utils.ParseRevisionVersion("version", c.Query("version"))
+			if err != nil {
+				log.Info("Inside "+methodName+" invalid version: ", c.Query("version"))
+				portErr.SetError(status_code.IMS146)
+				c.JSON(http.StatusOK, dto.ResponseDto{
+					StatusCode: portErr.StatusCode,
+					Message:    portErr.Message + " : " + err.Error(),
+				})
+				return
+			}
+			revision, errorDto = cc.InventoryService.GetItemRevision(c, inventoryName, id, version)
+		} else {
+			asOf, err := utils.ParseRevisionTimestamp(c.Query("timestamp"))
+			if err != nil {
+				log.Info("Inside "+methodName+" invalid timestamp: ", c.Query("timestamp"))
+				portErr.SetError(status_code.IMS146)
+				c.JSON(http.StatusOK, dto.ResponseDto{
+					StatusCode: portErr.StatusCode,
+					Message:    portErr.Message + " : " + err.Error(),
+				})
+				return
+			}
+			revision, errorDto = cc.InventoryService.GetItemAsOf(c, inventoryName, id, asOf)
+		}
+		if errorDto != nil {
+			log.Error("Inside "+methodName+" error while reading item : "+id+" in ", inventoryName)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errorDto.StatusCode,
+				Message:    errorDto.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+			Data:       revision,
+		})
+	}
+}
+
+// DiffItemRevisions  godoc
+// @Summary Diff two revisions of an item
+// @Description Get the JSON Patch turning the item at one version into the item at another
+// @Tags Inventory
+// @Produce  json
+// @Success 200 {object} dto.ResponseDto
+// @Param inventoryName path string true "Inventory Key"
+// @Param id path string true "Item id"
+// @Param from query int true "Version diffed from"
+// @Param to query int true "Version diffed to"
+// @Router /inventory-service/api/v1/inventory/{inventoryName}/items/{id}/diff [GET]
+// DiffItemRevisions : This function will diff two revisions of an item
+func (cc InventoryController) DiffItemRevisions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		methodName := "DiffItemRevisions"
+		log := logger.GetLogger()
+		inventoryName := c.Param("inventoryName")
+		id := c.Param("id")
+		var portErr dto.ErrorResponseDto
+
+		fromVersion, err := utils.ParseRevisionVersion("from", c.Query("from"))
+		if err != nil {
+			log.Info("Inside "+methodName+" invalid from version: ", c.Query("from"))
+			portErr.SetError(status_code.IMS146)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: portErr.StatusCode,
+				Message:    portErr.Message + " : " + err.Error(),
+			})
+			return
+		}
+		toVersion, err := utils.ParseRevisionVersion("to", c.Query("to"))
+		if err != nil {
+			log.Info("Inside "+methodName+" invalid to version: ", c.Query("to"))
+			portErr.SetError(status_code.IMS146)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: portErr.StatusCode,
+				Message:    portErr.Message + " : " + err.Error(),
+			})
+			return
+		}
+
+		changes, errorDto := cc.InventoryService.DiffItemRevisions(c, inventoryName, id, fromVersion, toVersion)
+		if errorDto != nil {
+			log.Error("Inside "+methodName+" error while diffing revisions of item : "+id+" in ", inventoryName)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errorDto.StatusCode,
+				Message:    errorDto.Message,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+			Data:       changes,
+		})
+	}
+}
+
+// RevertItem  godoc
+// @Summary Revert an item to a revision
+// @Description Replace the item with its content at a version, the revert is recorded as a new revision
+// @Tags Inventory
+// @Produce  json
+// @Success 200 {object} dto.ResponseDto
+// @Param inventoryName path string true "Inventory Key"
+// @Param id path string true "Item id"
+// @Param version query int true "Version to revert to"
+// @Param If-Match header string false "Only revert while the item is at this version"
+// @Param X-User-Id header string false "Caller stamped as updated_by"
+// @Router /inventory-service/api/v1/inventory/{inventoryName}/items/{id}/revert [POST]
+// RevertItem : This function will revert an item to a revision
+func (cc InventoryController) RevertItem() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		methodName := "RevertItem"
+		log := logger.GetLogger()
+		inventoryName := c.Param("inventoryName")
+		id := c.Param("id")
+		var portErr dto.ErrorResponseDto
+
+		version, err := utils.ParseRevisionVersion("version", c.Query("version"))
+		if err != nil {
+			log.Info("Inside "+methodName+" invalid version: ", c.Query("version"))
+			portErr.SetError(status_code.IMS146)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: portErr.StatusCode,
+				Message:    portErr.Message + " : " + err.Error(),
+			})
+			return
+		}
+		expectedVersion, err := utils.GetIfMatchVersion(c)
+		if err != nil {
+			log.Info("Inside "+methodName+" invalid If-Match header: ", err.Error())
+			portErr.SetError(status_code.IMS400)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: portErr.StatusCode,
+				Message:    portErr.Message + " : " + err.Error(),
+			})
+			return
+		}
+
+		item, errorDto := cc.InventoryService.RevertItem(c, inventoryName, id, version, utils.GetCaller(c), expectedVersion)
+		if errorDto != nil {
+			log.Error("Inside "+methodName+" error while reverting item : "+id+" in ", inventoryName)
+			c.JSON(http.StatusOK, dto.ResponseDto{
+				StatusCode: errorDto.StatusCode,
+				Message:    errorDto.Message,
+			})
+			return
+		}
+		utils.SetItemETag(c, item)
+		c.JSON(http.StatusOK, dto.ResponseDto{
+			StatusCode: dto.GetStatusDetails(status_code.IMS200).StatusCode,
+			Message:    dto.GetStatusDetails(status_code.IMS200).Message,
+			Data:       item,
+		})
+	}
+}
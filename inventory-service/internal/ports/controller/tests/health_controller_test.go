@@ -29,7 +29,7 @@ func TestHealthCheck(t *testing.T) {
 	router := healthSetupRouter()
 
 	t.Run("TestGetInventory_ShouldReturnStatus200_WhenNoErrorOccurs", func(t *testing.T) {
-		url := "/inventory-service/health"
+		url := "/inventory-service/api/health"
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, url, nil)
 		router.ServeHTTP(w, req)
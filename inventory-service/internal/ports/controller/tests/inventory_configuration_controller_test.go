@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"inventory-system/common/pkg/dto"
 	"inventory-system/common/pkg/logger"
+	commonDto "inventory-system/inventory-service/internal/common/dto"
 	"inventory-system/inventory-service/internal/common/dto/request_dto"
 	"inventory-system/inventory-service/internal/common/dto/response_dto"
 	"inventory-system/inventory-service/internal/common/status_code"
@@ -28,7 +29,7 @@ var inventoryConfigurationServiceMock *mockServices.MockIInventoryConfigurationS
 
 var getInventoryConfigurationResponseDto = response_dto.InventoryConfigurationResponseDto{
 	InventoryName:        "Course",
-	InventoryIdentifiers: []string{"name", "course_id"},
+	InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "name"}, {Key: "course_id"}},
 	CreatedBy:            "admin",
 	CreatedOn:            time.Now(),
 	JsonSchema:           map[string]interface{}{},
@@ -38,7 +39,13 @@ var createNewInventoryConfigurationRequestDto = request_dto.CreateNewConfigurati
 	InventoryName:        "Course",
 	CreatedBy:            "admin",
 	JsonSchema:           map[string]interface{}{},
-	InventoryIdentifiers: []string{"name", "course_id"},
+	InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "name"}, {Key: "course_id"}},
+}
+
+var updateInventoryConfigurationRequestDto = request_dto.UpdateConfigurationRequestBody{
+	UpdatedBy:            "admin",
+	JsonSchema:           map[string]interface{}{},
+	InventoryIdentifiers: []request_dto.InventoryIdentifier{{Key: "name", IsUnique: true}},
 }
 
 func SetupInventoryConfigurationRouter(mockController *gomock.Controller) *gin.Engine {
@@ -53,7 +60,9 @@ func SetupInventoryConfigurationRouter(mockController *gomock.Controller) *gin.E
 		inventoryConfigurations.POST("", inventoryConfigurationController.CreateNewConfiguration())
 		inventoryConfigurations.GET("", inventoryConfigurationController.GetAllConfiguration())
 		inventoryConfigurations.DELETE("/:inventoryName", inventoryConfigurationController.DeleteConfiguration())
+		inventoryConfigurations.PUT("/:inventoryName", inventoryConfigurationController.UpdateConfiguration())
 	}
+	InventoryServiceRouter.POST("/admin/inventory/reconcile", inventoryConfigurationController.ReconcileConfigurations())
 	return router
 }
 
@@ -103,7 +112,7 @@ func TestCreateNewInventoryConfiguration(t *testing.T) {
 	url := "/inventory-service/api/v1/inventory/configurations"
 
 	t.Run("TestCreateNewInventoryConfiguration_ShouldReturnStatus200_WhenNoErrorOccurs", func(t *testing.T) {
-		inventoryConfigurationServiceMock.EXPECT().CreateNewConfiguration(gomock.Any(), createNewInventoryConfigurationRequestDto).Return(nil)
+		inventoryConfigurationServiceMock.EXPECT().CreateNewConfiguration(gomock.Any(), createNewInventoryConfigurationRequestDto).Return(nil, nil)
 
 		body, _ := json.Marshal(createNewInventoryConfigurationRequestDto)
 		reqBody := string(body)
@@ -121,7 +130,7 @@ func TestCreateNewInventoryConfiguration(t *testing.T) {
 		var errDto dto.ErrorResponseDto
 		errDto.SetError(status_code.IMS500)
 
-		inventoryConfigurationServiceMock.EXPECT().CreateNewConfiguration(gomock.Any(), createNewInventoryConfigurationRequestDto).Return(&errDto)
+		inventoryConfigurationServiceMock.EXPECT().CreateNewConfiguration(gomock.Any(), createNewInventoryConfigurationRequestDto).Return(nil, &errDto)
 		body, _ := json.Marshal(createNewInventoryConfigurationRequestDto)
 		reqBody := string(body)
 		req, _ := http.NewRequest("POST", url, strings.NewReader(reqBody))
@@ -215,7 +224,7 @@ func TestGetAllInventoryConfiguration(t *testing.T) {
 	t.Run("TestTestGetAllInventoryConfiguration_ShouldReturnStatus200_WhenNoErrorOccurs", func(t *testing.T) {
 		var inventoryConfigurations []response_dto.InventoryConfigurationResponseDto
 
-		inventoryConfigurationServiceMock.EXPECT().GetAllInventoryConfiguration(gomock.Any()).Return(inventoryConfigurations, nil)
+		inventoryConfigurationServiceMock.EXPECT().GetAllInventoryConfiguration(gomock.Any(), false).Return(inventoryConfigurations, nil)
 		req, _ := http.NewRequest("GET", url, nil)
 		recordedResponse := httptest.NewRecorder()
 		router.ServeHTTP(recordedResponse, req)
@@ -230,7 +239,7 @@ func TestGetAllInventoryConfiguration(t *testing.T) {
 		var errorDto dto.ErrorResponseDto
 		errorDto.SetError(status_code.IMS500)
 
-		inventoryConfigurationServiceMock.EXPECT().GetAllInventoryConfiguration(gomock.Any()).Return(nil, &errorDto)
+		inventoryConfigurationServiceMock.EXPECT().GetAllInventoryConfiguration(gomock.Any(), false).Return(nil, &errorDto)
 		req, _ := http.NewRequest("GET", url, nil)
 		recordedResponse := httptest.NewRecorder()
 		router.ServeHTTP(recordedResponse, req)
@@ -243,3 +252,96 @@ func TestGetAllInventoryConfiguration(t *testing.T) {
 		assert.Equal(t, nil, responseValue.Data)
 	})
 }
+
+func TestUpdateInventoryConfiguration(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+	router := SetupInventoryConfigurationRouter(mockController)
+
+	inventoryName := "Course"
+	url := "/inventory-service/api/v1/inventory/configurations/:inventoryName"
+	url = strings.Replace(url, ":inventoryName", inventoryName, -1)
+
+	t.Run("TestUpdateInventoryConfiguration_ShouldReturnStatus200_WhenNoErrorOccurs", func(t *testing.T) {
+		inventoryConfigurationServiceMock.EXPECT().UpdateInventoryConfiguration(gomock.Any(), inventoryName, updateInventoryConfigurationRequestDto).Return(&getInventoryConfigurationResponseDto, nil)
+
+		body, _ := json.Marshal(updateInventoryConfigurationRequestDto)
+		req, _ := http.NewRequest("PUT", url, strings.NewReader(string(body)))
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, http.StatusOK, recordedResponse.Code)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).Message, responseValue.Message)
+	})
+	t.Run("TestUpdateInventoryConfiguration_ShouldReturnError_WhenInventoryServiceReturnsError", func(t *testing.T) {
+		var errDto dto.ErrorResponseDto
+		errDto.SetError(status_code.IMS123)
+
+		inventoryConfigurationServiceMock.EXPECT().UpdateInventoryConfiguration(gomock.Any(), inventoryName, updateInventoryConfigurationRequestDto).Return(nil, &errDto)
+		body, _ := json.Marshal(updateInventoryConfigurationRequestDto)
+		req, _ := http.NewRequest("PUT", url, strings.NewReader(string(body)))
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, http.StatusOK, recordedResponse.Code)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS123).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS123).Message, responseValue.Message)
+	})
+	t.Run("TestUpdateInventoryConfiguration_ShouldReturnStatus400_WhenValidationLevelInvalid", func(t *testing.T) {
+		requestDto := updateInventoryConfigurationRequestDto
+		requestDto.ValidationLevel = "lenient"
+
+		body, _ := json.Marshal(requestDto)
+		req, _ := http.NewRequest("PUT", url, strings.NewReader(string(body)))
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, http.StatusOK, recordedResponse.Code)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS400).StatusCode, responseValue.StatusCode)
+	})
+}
+
+func TestReconcileConfigurations(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+	router := SetupInventoryConfigurationRouter(mockController)
+
+	url := "/inventory-service/api/v1/admin/inventory/reconcile"
+
+	t.Run("TestReconcileConfigurations_ShouldReturnDriftReport_WhenApplyIsTrue", func(t *testing.T) {
+		report := commonDto.DriftReport{OrphanCollections: []string{"Orphan"}, IsApplied: true}
+
+		inventoryConfigurationServiceMock.EXPECT().ReconcileInventoryConfigurations(gomock.Any(), true).Return(&report, nil)
+		req, _ := http.NewRequest("POST", url+"?apply=true", nil)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, http.StatusOK, recordedResponse.Code)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, true, responseValue.Data.(map[string]interface{})["is_applied"])
+	})
+	t.Run("TestReconcileConfigurations_ShouldReturnError_WhenServiceReturnsError", func(t *testing.T) {
+		var errorDto dto.ErrorResponseDto
+		errorDto.SetError(status_code.IMS134)
+
+		inventoryConfigurationServiceMock.EXPECT().ReconcileInventoryConfigurations(gomock.Any(), false).Return(nil, &errorDto)
+		req, _ := http.NewRequest("POST", url, nil)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, http.StatusOK, recordedResponse.Code)
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS134).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, nil, responseValue.Data)
+	})
+}
@@ -1,20 +1,28 @@
 package tests
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson"
+	"inventory-system/common/pkg/constants"
 	"inventory-system/common/pkg/dto"
 	"inventory-system/common/pkg/logger"
+	commonDto "inventory-system/inventory-service/internal/common/dto"
+	"inventory-system/inventory-service/internal/common/dto/request_dto"
 	"inventory-system/inventory-service/internal/common/status_code"
 	mockServices "inventory-system/inventory-service/internal/domain/service/mocks"
 	"inventory-system/inventory-service/internal/ports/controller"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func init() {
@@ -31,8 +39,25 @@ func SetupInventoryRouter(mockController *gomock.Controller) *gin.Engine {
 	inventory := InventoryServiceRouter.Group("/inventory")
 	{
 		inventory.POST("/:inventoryName", inventoryController.AddNewInventory())
+		inventory.POST("/:inventoryName/bulk", inventoryController.BulkAddNewInventory())
+		inventory.PUT("/:inventoryName", inventoryController.UpsertInventory())
+		inventory.PATCH("/update/:inventoryName/:id", inventoryController.UpdateInventory())
 		inventory.GET("/:inventoryName", inventoryController.GetInventory())
+		inventory.GET("/:inventoryName/trash", inventoryController.GetInventoryTrash())
+		inventory.GET("/:inventoryName/search", inventoryController.SearchInventory())
+		inventory.POST("/:inventoryName/aggregate", inventoryController.AggregateInventory())
+		inventory.GET("/:inventoryName/export", inventoryController.ExportInventory())
+		inventory.POST("/:inventoryName/import", inventoryController.ImportInventory())
+		inventory.POST("/:inventoryName/purge", inventoryController.PurgeInventory())
+		inventory.GET("/:inventoryName/items/:id/revisions", inventoryController.ListItemRevisions())
+		inventory.GET("/:inventoryName/items/:id/as-of", inventoryController.GetItemAsOf())
+		inventory.GET("/:inventoryName/items/:id/diff", inventoryController.DiffItemRevisions())
+		inventory.POST("/:inventoryName/items/:id/revert", inventoryController.RevertItem())
 	}
+	v2 := router.Group("inventory-service/api/v2")
+	v2.POST("/inventory/:inventoryName", inventoryController.GetInventoryV2())
+	v2.POST("/inventory/:inventoryName/facets", inventoryController.GetInventoryFacets())
+	v2.GET("/inventory/filter/:inventoryName/:filterName", inventoryController.GetInventoryFilter())
 	return router
 }
 
@@ -48,7 +73,7 @@ func TestGetInventory(t *testing.T) {
 	t.Run("TestGetInventory_ShouldReturnStatus200_WhenNoErrorOccurs", func(t *testing.T) {
 		var item bson.M
 
-		inventoryServiceMock.EXPECT().GetInventory(gomock.Any(), inventoryName, gomock.Any()).Return(item, nil)
+		inventoryServiceMock.EXPECT().GetInventory(gomock.Any(), inventoryName, gomock.Any(), "", "").Return(item, nil)
 		req, _ := http.NewRequest("GET", url, nil)
 		recordedResponse := httptest.NewRecorder()
 		router.ServeHTTP(recordedResponse, req)
@@ -63,7 +88,7 @@ func TestGetInventory(t *testing.T) {
 		var errorDto dto.ErrorResponseDto
 		errorDto.SetError(status_code.IMS500)
 
-		inventoryServiceMock.EXPECT().GetInventory(gomock.Any(), inventoryName, gomock.Any()).Return(nil, &errorDto)
+		inventoryServiceMock.EXPECT().GetInventory(gomock.Any(), inventoryName, gomock.Any(), "", "").Return(nil, &errorDto)
 		req, _ := http.NewRequest("GET", url, nil)
 		recordedResponse := httptest.NewRecorder()
 		router.ServeHTTP(recordedResponse, req)
@@ -76,6 +101,23 @@ func TestGetInventory(t *testing.T) {
 	})
 }
 
+func TestGetInventoryProjection(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+	router := SetupInventoryRouter(mockController)
+
+	t.Run("TestGetInventory_ShouldPassProjectionApartFromFilter", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().GetInventory(gomock.Any(), "Course", map[string][]string{"name": {"DSA"}}, "name,price", "").Return(bson.M{"id": "1", "name": "DSA"}, nil)
+		req, _ := http.NewRequest("GET", "/inventory-service/api/v1/inventory/Course?name=DSA&fields=name,price", nil)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+	})
+}
+
 func TestCreateNewInventory(t *testing.T) {
 	var mockController = gomock.NewController(t)
 	defer mockController.Finish()
@@ -86,9 +128,10 @@ func TestCreateNewInventory(t *testing.T) {
 	url = strings.Replace(url, ":inventoryName", inventoryName, -1)
 
 	t.Run("TestCreateNewInventory_ShouldReturnStatus200_WhenNoErrorOccurs", func(t *testing.T) {
-		inventoryServiceMock.EXPECT().CreateNewInventory(gomock.Any(), gomock.Any(), inventoryName).Return(nil)
+		inventoryServiceMock.EXPECT().CreateNewInventory(gomock.Any(), gomock.Any(), inventoryName, "admin").Return(bson.M{"id": "1"}, nil)
 		reqBody := `{"course_name":"DSA", "course_id" : "123"}`
 		req, _ := http.NewRequest("POST", url, strings.NewReader(reqBody))
+		req.Header.Set("X-User-Id", "admin")
 		recordedResponse := httptest.NewRecorder()
 		router.ServeHTTP(recordedResponse, req)
 		var responseValue dto.ResponseDto
@@ -97,12 +140,13 @@ func TestCreateNewInventory(t *testing.T) {
 		assert.Equal(t, http.StatusOK, recordedResponse.Code)
 		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
 		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).Message, responseValue.Message)
+		assert.Equal(t, map[string]interface{}{"id": "1"}, responseValue.Data)
 	})
 	t.Run("TestCreateNewInventory_ShouldReturnsError_WhenInventoryServiceReturnsError", func(t *testing.T) {
 		var errDto dto.ErrorResponseDto
 		errDto.SetError(status_code.IMS500)
 
-		inventoryServiceMock.EXPECT().CreateNewInventory(gomock.Any(), gomock.Any(), inventoryName).Return(&errDto)
+		inventoryServiceMock.EXPECT().CreateNewInventory(gomock.Any(), gomock.Any(), inventoryName, "anonymous").Return(nil, &errDto)
 		reqBody := `{"course_name":"DSA", "course_id" : "123"}`
 		req, _ := http.NewRequest("POST", url, strings.NewReader(reqBody))
 		recordedResponse := httptest.NewRecorder()
@@ -129,3 +173,583 @@ func TestCreateNewInventory(t *testing.T) {
 	})
 
 }
+
+func TestBulkAddNewInventory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+	router := SetupInventoryRouter(mockController)
+
+	inventoryName := "Course"
+	url := "/inventory-service/api/v1/inventory/" + inventoryName + "/bulk"
+	expectedItems := []interface{}{map[string]interface{}{"name": "DSA"}, map[string]interface{}{"name": "OS"}}
+
+	t.Run("TestBulkAddNewInventory_ShouldDecodeJsonArray", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().BulkCreateInventory(gomock.Any(), expectedItems, inventoryName, "anonymous", false).Return(&commonDto.BulkInsertReport{InsertedCount: 2}, nil)
+		req, _ := http.NewRequest("POST", url, strings.NewReader(`[{"name":"DSA"},{"name":"OS"}]`))
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, float64(2), responseValue.Data.(map[string]interface{})["inserted_count"])
+	})
+	t.Run("TestBulkAddNewInventory_ShouldDecodeNdjsonStream_WhenOrdered", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().BulkCreateInventory(gomock.Any(), expectedItems, inventoryName, "admin", true).Return(&commonDto.BulkInsertReport{Ordered: true}, nil)
+		req, _ := http.NewRequest("POST", url+"?ordered=true", strings.NewReader("{\"name\":\"DSA\"}\n\n{\"name\":\"OS\"}\n"))
+		req.Header.Set("X-User-Id", "admin")
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+	})
+	t.Run("TestBulkAddNewInventory_ShouldReturnStatus139_WhenBodyTooLarge", func(t *testing.T) {
+		body := `[{"name":"` + strings.Repeat("a", constants.MaxBulkInsertBodySize) + `"}]`
+		req, _ := http.NewRequest("POST", url, strings.NewReader(body))
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS139).StatusCode, responseValue.StatusCode)
+		assert.Contains(t, responseValue.Message, "request body must not be larger than 32 MB")
+	})
+	t.Run("TestBulkAddNewInventory_ShouldReturnStatus400_WhenBodyOrOrderedInvalid", func(t *testing.T) {
+		for _, request := range [][2]string{{url, `[{"name":"DSA"}`}, {url, ""}, {url + "?ordered=maybe", `[]`}} {
+			req, _ := http.NewRequest("POST", request[0], strings.NewReader(request[1]))
+			recordedResponse := httptest.NewRecorder()
+			router.ServeHTTP(recordedResponse, req)
+			var responseValue dto.ResponseDto
+			_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+			assert.Equal(t, dto.GetStatusDetails(status_code.IMS400).StatusCode, responseValue.StatusCode)
+		}
+	})
+}
+
+func TestUpsertInventory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+	router := SetupInventoryRouter(mockController)
+
+	inventoryName := "Course"
+	url := "/inventory-service/api/v1/inventory/" + inventoryName
+
+	t.Run("TestUpsertInventory_ShouldReturnCreatedFlag_WhenNoErrorOccurs", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().UpsertInventory(gomock.Any(), map[string]interface{}{"course_id": "C1"}, inventoryName, "course_id", "admin").Return(bson.M{"id": "1", "course_id": "C1"}, true, nil)
+		req, _ := http.NewRequest("PUT", url+"?key=course_id", strings.NewReader(`{"course_id":"C1"}`))
+		req.Header.Set("X-User-Id", "admin")
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, true, responseValue.Data.(map[string]interface{})["created"])
+	})
+	t.Run("TestUpsertInventory_ShouldReturnStatus400_WhenKeyMissing", func(t *testing.T) {
+		req, _ := http.NewRequest("PUT", url, strings.NewReader(`{"course_id":"C1"}`))
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS400).StatusCode, responseValue.StatusCode)
+	})
+}
+
+func TestUpdateInventory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+	router := SetupInventoryRouter(mockController)
+
+	inventoryName := "Course"
+	url := "/inventory-service/api/v1/inventory/update/" + inventoryName + "/1"
+
+	t.Run("TestUpdateInventory_ShouldPassIfMatchVersionAndReturnETag", func(t *testing.T) {
+		expectedVersion := int64(3)
+		inventoryServiceMock.EXPECT().UpdateInventory("1", inventoryName, gomock.Any(), "anonymous", &expectedVersion, request_dto.UpdateFormatSet).Return(int64(4), nil)
+		req, _ := http.NewRequest("PATCH", url, strings.NewReader(`{"name":"DSA"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"3"`)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, `"4"`, recordedResponse.Header().Get("ETag"))
+	})
+	t.Run("TestUpdateInventory_ShouldReturnStatus400_WhenIfMatchInvalid", func(t *testing.T) {
+		req, _ := http.NewRequest("PATCH", url, strings.NewReader(`{"name":"DSA"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"abc"`)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS400).StatusCode, responseValue.StatusCode)
+	})
+	t.Run("TestUpdateInventory_ShouldSelectFormatFromContentType", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().UpdateInventory("1", inventoryName, gomock.Any(), "anonymous", nil, request_dto.UpdateFormatJSONPatch).Return(int64(2), nil)
+		req, _ := http.NewRequest("PATCH", url, strings.NewReader(`[{"op":"remove","path":"/name"}]`))
+		req.Header.Set("Content-Type", "application/json-patch+json")
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+	})
+	t.Run("TestUpdateInventory_ShouldReturnStatus400_WhenContentTypeUnsupported", func(t *testing.T) {
+		req, _ := http.NewRequest("PATCH", url, strings.NewReader(`name=DSA`))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS400).StatusCode, responseValue.StatusCode)
+	})
+}
+
+func TestGetInventoryTrash(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+	router := SetupInventoryRouter(mockController)
+
+	inventoryName := "topics"
+	url := "/inventory-service/api/v1/inventory/" + inventoryName + "/trash"
+
+	t.Run("TestGetInventoryTrash_ShouldReturnPageOfDeletedItems", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().GetInventoryTrash(gomock.Any(), inventoryName, "1", "10").Return([]bson.M{{"id": "T1", "is_deleted": true}}, &commonDto.PaginationResponse{Count: 11, PageNumber: 1, PageSize: 10}, nil)
+		req, _ := http.NewRequest("GET", url+"?page=1&page_size=10", nil)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		data := responseValue.Data.(map[string]interface{})
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, float64(11), data["count"])
+		assert.Len(t, data["items"], 1)
+	})
+	t.Run("TestGetInventoryTrash_ShouldReturnEmptyItems_WhenTrashIsEmpty", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().GetInventoryTrash(gomock.Any(), inventoryName, "", "").Return([]bson.M{}, &commonDto.PaginationResponse{PageSize: 10}, nil)
+		req, _ := http.NewRequest("GET", url, nil)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, []interface{}{}, responseValue.Data.(map[string]interface{})["items"])
+	})
+}
+
+func TestGetInventoryV2(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+	router := SetupInventoryRouter(mockController)
+
+	inventoryName := "Course"
+	url := "/inventory-service/api/v2/inventory/" + inventoryName
+
+	t.Run("TestGetInventoryV2_ShouldReturnCursors_WhenCursorRequested", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().GetInventoryV2(gomock.Any(), "", "", inventoryName, map[string]interface{}{"name": "DSA"}).Return([]bson.M{{"id": "C1"}}, &commonDto.PaginationResponse{PageSize: 1, NextCursor: "next", PrevCursor: "prev"}, nil)
+		req, _ := http.NewRequest("POST", url+"?cursor=abc&page_size=1", strings.NewReader(`{"name":"DSA"}`))
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		data := responseValue.Data.(map[string]interface{})
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, "next", data["next_cursor"])
+		assert.Equal(t, "prev", data["prev_cursor"])
+		assert.NotContains(t, data, "count")
+	})
+	t.Run("TestGetInventoryV2_ShouldReturnPageNumbers_WhenNoCursorRequested", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().GetInventoryV2(gomock.Any(), "", "", inventoryName, map[string]interface{}{}).Return([]bson.M{{"id": "C1"}}, &commonDto.PaginationResponse{Count: 3, PageNumber: 1, PageSize: 1}, nil)
+		req, _ := http.NewRequest("POST", url+"?page=1&page_size=1", strings.NewReader(`{}`))
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		data := responseValue.Data.(map[string]interface{})
+		assert.Equal(t, float64(3), data["count"])
+		assert.NotContains(t, data, "next_cursor")
+	})
+}
+
+func TestSearchInventory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+	router := SetupInventoryRouter(mockController)
+
+	url := "/inventory-service/api/v1/inventory/topics/search"
+
+	t.Run("TestSearchInventory_ShouldReturnPageOfResults", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().SearchInventory(gomock.Any(), "topics", "shortest path", "", "5").Return([]commonDto.SearchResult{
+			{Item: bson.M{"id": "T1"}, Score: 2, Highlights: map[string]string{"topic_name": "<em>Shortest</em> <em>path</em>"}},
+		}, &commonDto.PaginationResponse{Count: 1, PageSize: 5}, nil)
+		req, _ := http.NewRequest("GET", url+"?q=shortest+path&page_size=5", nil)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		data := responseValue.Data.(map[string]interface{})
+		items := data["items"].([]interface{})
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, float64(1), data["count"])
+		assert.Equal(t, float64(2), items[0].(map[string]interface{})["score"])
+	})
+	t.Run("TestSearchInventory_ShouldReturnServiceError", func(t *testing.T) {
+		var errorDto dto.ErrorResponseDto
+		errorDto.SetError(status_code.IMS155)
+		inventoryServiceMock.EXPECT().SearchInventory(gomock.Any(), "topics", "graph", "", "").Return(nil, nil, &errorDto)
+		req, _ := http.NewRequest("GET", url+"?q=graph", nil)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, errorDto.StatusCode, responseValue.StatusCode)
+	})
+}
+
+func TestAggregateInventory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+	router := SetupInventoryRouter(mockController)
+
+	url := "/inventory-service/api/v1/inventory/courses/aggregate"
+
+	t.Run("TestAggregateInventory_ShouldReturnGroups", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().AggregateInventory(gomock.Any(), "courses", request_dto.AggregateRequest{
+			GroupBy: []string{"category"},
+			Metrics: []request_dto.AggregateMetric{{Operator: "avg", Field: "price"}},
+		}).Return(&commonDto.AggregateResult{
+			Groups: []commonDto.AggregateGroup{{Key: map[string]interface{}{"category": "dsa"}, Count: 7, Metrics: map[string]interface{}{"avg_price": 120.5}}},
+		}, nil)
+		req, _ := http.NewRequest("POST", url, strings.NewReader(`{"group_by":["category"],"metrics":[{"op":"avg","field":"price"}]}`))
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		groups := responseValue.Data.(map[string]interface{})["groups"].([]interface{})
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, float64(7), groups[0].(map[string]interface{})["count"])
+	})
+	t.Run("TestAggregateInventory_ShouldReturnStatus400_WhenBodyIsInvalid", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", url, strings.NewReader(`{"group_by":"category"}`))
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS400).StatusCode, responseValue.StatusCode)
+	})
+}
+
+func TestGetInventoryFilter(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+	router := SetupInventoryRouter(mockController)
+
+	url := "/inventory-service/api/v2/inventory/filter/courses/category"
+
+	t.Run("TestGetInventoryFilter_ShouldReturnValues_WhenNotPaged", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().GetInventoryFilter(gomock.Any(), "courses", "category", map[string][]string{"level": {"2"}}, "", "", "").Return([]interface{}{"dsa", "java"}, nil, nil)
+		req, _ := http.NewRequest("GET", url+"?level=2", nil)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, []interface{}{"dsa", "java"}, responseValue.Data)
+	})
+	t.Run("TestGetInventoryFilter_ShouldReturnPageOfValues_WhenPrefixIsGiven", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().GetInventoryFilter(gomock.Any(), "courses", "category", map[string][]string{}, "da", "1", "").Return([]interface{}{"data science"}, &commonDto.PaginationResponse{Count: 11, PageNumber: 1, PageSize: 10}, nil)
+		req, _ := http.NewRequest("GET", url+"?prefix=da&page=1", nil)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		data := responseValue.Data.(map[string]interface{})
+		assert.Equal(t, float64(11), data["count"])
+		assert.Equal(t, []interface{}{"data science"}, data["values"])
+	})
+}
+
+func TestGetInventoryFacets(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+	router := SetupInventoryRouter(mockController)
+
+	url := "/inventory-service/api/v2/inventory/courses/facets"
+
+	t.Run("TestGetInventoryFacets_ShouldReturnFacets", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().GetInventoryFacets(gomock.Any(), "courses", request_dto.FacetRequest{
+			Facets: []string{"category"},
+			Filter: map[string]interface{}{"level": float64(2)},
+		}).Return([]commonDto.Facet{{Field: "category", Values: []commonDto.FacetValue{{Value: "dsa", Count: 4}}}}, nil)
+		req, _ := http.NewRequest("POST", url, strings.NewReader(`{"facets":["category"],"filter":{"level":2}}`))
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		facets := responseValue.Data.(map[string]interface{})["facets"].([]interface{})
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, "category", facets[0].(map[string]interface{})["field"])
+	})
+	t.Run("TestGetInventoryFacets_ShouldReturnServiceError", func(t *testing.T) {
+		var errorDto dto.ErrorResponseDto
+		errorDto.SetError(status_code.IMS159)
+		inventoryServiceMock.EXPECT().GetInventoryFacets(gomock.Any(), "courses", gomock.Any()).Return(nil, &errorDto)
+		req, _ := http.NewRequest("POST", url, strings.NewReader(`{"facets":[]}`))
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, errorDto.StatusCode, responseValue.StatusCode)
+	})
+}
+
+func TestExportInventory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+	router := SetupInventoryRouter(mockController)
+
+	url := "/inventory-service/api/v1/inventory/courses/export"
+
+	t.Run("TestExportInventory_ShouldStreamFile", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().ExportInventory(gomock.Any(), "courses", request_dto.ExportRequest{Format: "csv", Filter: map[string]interface{}{"level": float64(2)}}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ request_dto.ExportRequest, writer io.Writer) *dto.ErrorResponseDto {
+				_, _ = writer.Write([]byte("id\nC1\n"))
+				return nil
+			})
+		req, _ := http.NewRequest("GET", url+"?format=csv&filter=%7B%22level%22%3A2%7D", nil)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+
+		assert.Equal(t, http.StatusOK, recordedResponse.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", recordedResponse.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="courses.csv"`, recordedResponse.Header().Get("Content-Disposition"))
+		assert.Equal(t, "id\nC1\n", recordedResponse.Body.String())
+	})
+	t.Run("TestExportInventory_ShouldReturnServiceError_WhenNothingWasStreamed", func(t *testing.T) {
+		var errorDto dto.ErrorResponseDto
+		errorDto.SetError(status_code.IMS160)
+		inventoryServiceMock.EXPECT().ExportInventory(gomock.Any(), "courses", request_dto.ExportRequest{Format: "xml"}, gomock.Any()).Return(&errorDto)
+		req, _ := http.NewRequest("GET", url+"?format=xml", nil)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, errorDto.StatusCode, responseValue.StatusCode)
+		assert.Empty(t, recordedResponse.Header().Get("Content-Disposition"))
+	})
+	t.Run("TestExportInventory_ShouldAbortResponse_WhenStreamFailsAfterStarting", func(t *testing.T) {
+		var errorDto dto.ErrorResponseDto
+		errorDto.SetError(status_code.IMS161)
+		inventoryServiceMock.EXPECT().ExportInventory(gomock.Any(), "courses", request_dto.ExportRequest{Format: "ndjson"}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ request_dto.ExportRequest, writer io.Writer) *dto.ErrorResponseDto {
+				_, _ = writer.Write([]byte("{\"id\":\"C1\"}\n"))
+				return &errorDto
+			})
+		req, _ := http.NewRequest("GET", url, nil)
+		recordedResponse := httptest.NewRecorder()
+
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() { router.ServeHTTP(recordedResponse, req) })
+		assert.Equal(t, "{\"id\":\"C1\"}\n", recordedResponse.Body.String())
+	})
+	t.Run("TestExportInventory_ShouldReturnStatus151_WhenFilterIsNotJson", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", url+"?filter=level", nil)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS151).StatusCode, responseValue.StatusCode)
+	})
+}
+
+func TestImportInventory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+	router := SetupInventoryRouter(mockController)
+
+	url := "/inventory-service/api/v1/inventory/courses/import"
+	newImportRequest := func(query string, fileName string, content string) *http.Request {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		if fileName != "" {
+			part, _ := writer.CreateFormFile("file", fileName)
+			_, _ = part.Write([]byte(content))
+		}
+		_ = writer.Close()
+		req, _ := http.NewRequest("POST", url+query, body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return req
+	}
+	report := &commonDto.ImportReport{
+		Mode:          request_dto.ImportModeUpsert,
+		IsApplied:     true,
+		TotalRows:     2,
+		ValidCount:    1,
+		ReplacedCount: 1,
+		RejectedCount: 1,
+		Rejected:      []commonDto.ImportRejection{{Row: 3, StatusCode: dto.GetStatusDetails(status_code.IMS109).StatusCode, Reason: "Document Validation failed : level, expected integer"}},
+	}
+
+	t.Run("TestImportInventory_ShouldReturnReport", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().ImportInventory(gomock.Any(), "courses", request_dto.ImportRequest{Format: "csv", Mode: "upsert", Key: "code"}, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ request_dto.ImportRequest, file io.ReadSeeker, _ string) (*commonDto.ImportReport, *dto.ErrorResponseDto) {
+				content, _ := io.ReadAll(file)
+				assert.Equal(t, "code,level\nC1,2\nC2,x\n", string(content))
+				return report, nil
+			})
+		req := newImportRequest("?mode=upsert&key=code", "courses.csv", "code,level\nC1,2\nC2,x\n")
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, float64(1), responseValue.Data.(map[string]interface{})["replaced_count"])
+	})
+	t.Run("TestImportInventory_ShouldDownloadRejections_WhenReportIsCsv", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().ImportInventory(gomock.Any(), "courses", request_dto.ImportRequest{Format: "ndjson", DryRun: true}, gomock.Any(), gomock.Any()).Return(report, nil)
+		req := newImportRequest("?format=ndjson&dry_run=true&report=csv", "courses.txt", `{"code":"C1"}`)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+
+		assert.Equal(t, "text/csv; charset=utf-8", recordedResponse.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="courses-import-rejections.csv"`, recordedResponse.Header().Get("Content-Disposition"))
+		assert.Equal(t, "row,status_code,reason\n3,IMS109,\"Document Validation failed : level, expected integer\"\n", recordedResponse.Body.String())
+	})
+	t.Run("TestImportInventory_ShouldReturnStatus162_WhenFileMissing", func(t *testing.T) {
+		req := newImportRequest("", "", "")
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS162).StatusCode, responseValue.StatusCode)
+	})
+	t.Run("TestImportInventory_ShouldReturnStatus162_WhenFileTooLarge", func(t *testing.T) {
+		req := newImportRequest("", "courses.csv", strings.Repeat("C", constants.MaxImportRequestSize))
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS162).StatusCode, responseValue.StatusCode)
+		assert.Contains(t, responseValue.Message, "file must not be larger than 32 MB")
+	})
+	t.Run("TestImportInventory_ShouldReturnStatus162_WhenDryRunInvalid", func(t *testing.T) {
+		req := newImportRequest("?dry_run=maybe", "courses.csv", "code\nC1\n")
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS162).StatusCode, responseValue.StatusCode)
+	})
+}
+
+func TestPurgeInventory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+	router := SetupInventoryRouter(mockController)
+
+	inventoryName := "topics"
+	url := "/inventory-service/api/v1/inventory/" + inventoryName + "/purge"
+
+	t.Run("TestPurgeInventory_ShouldReportCounts_WhenDryRun", func(t *testing.T) {
+		inventoryServiceMock.EXPECT().PurgeInventory(gomock.Any(), inventoryName, true).Return(&commonDto.InventoryRetention{InventoryName: inventoryName, IsDryRun: true, PurgedItems: 2}, nil)
+		req, _ := http.NewRequest("POST", url+"?dry_run=true", nil)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, float64(2), responseValue.Data.(map[string]interface{})["purged_items"])
+	})
+	t.Run("TestPurgeInventory_ShouldReturnStatus400_WhenDryRunInvalid", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", url+"?dry_run=maybe", nil)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS400).StatusCode, responseValue.StatusCode)
+	})
+}
+
+func TestItemHistory(t *testing.T) {
+	var mockController = gomock.NewController(t)
+	defer mockController.Finish()
+	router := SetupInventoryRouter(mockController)
+
+	inventoryName := "topics"
+	url := "/inventory-service/api/v1/inventory/" + inventoryName + "/items/T1"
+
+	t.Run("TestGetItemAsOf_ShouldReadLatestRevisionBeforeTimestamp", func(t *testing.T) {
+		asOf, _ := time.Parse(time.RFC3339, "2026-01-02T15:04:05Z")
+		inventoryServiceMock.EXPECT().GetItemAsOf(gomock.Any(), inventoryName, "T1", asOf).Return(&commonDto.ItemRevision{ItemId: "T1", Version: 2}, nil)
+		req, _ := http.NewRequest("GET", url+"/as-of?timestamp=2026-01-02T15:04:05Z", nil)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, float64(2), responseValue.Data.(map[string]interface{})["version"])
+	})
+	t.Run("TestGetItemAsOf_ShouldReturnStatus146_WhenVersionAndTimestampGiven", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", url+"/as-of?version=1&timestamp=2026-01-02T15:04:05Z", nil)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS146).StatusCode, responseValue.StatusCode)
+	})
+	t.Run("TestDiffItemRevisions_ShouldReturnStatus146_WhenVersionInvalid", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", url+"/diff?from=1&to=0", nil)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS146).StatusCode, responseValue.StatusCode)
+		assert.Contains(t, responseValue.Message, "to must be a positive integer")
+	})
+	t.Run("TestRevertItem_ShouldPassIfMatchVersionAndReturnETag", func(t *testing.T) {
+		expectedVersion := int64(2)
+		inventoryServiceMock.EXPECT().RevertItem(gomock.Any(), inventoryName, "T1", int64(1), "anonymous", &expectedVersion).Return(bson.M{"id": "T1", "version": int64(3)}, nil)
+		req, _ := http.NewRequest("POST", url+"/revert?version=1", nil)
+		req.Header.Set("If-Match", `"2"`)
+		recordedResponse := httptest.NewRecorder()
+		router.ServeHTTP(recordedResponse, req)
+		var responseValue dto.ResponseDto
+		_ = json.Unmarshal(recordedResponse.Body.Bytes(), &responseValue)
+
+		assert.Equal(t, dto.GetStatusDetails(status_code.IMS200).StatusCode, responseValue.StatusCode)
+		assert.Equal(t, `"3"`, recordedResponse.Header().Get("ETag"))
+	})
+}
@@ -7,6 +7,7 @@ import (
 	portConstants "inventory-system/inventory-service/internal/ports/constants"
 	"inventory-system/inventory-service/internal/ports/docs"
 	"inventory-system/inventory-service/internal/ports/factory"
+	"net/http"
 	"strings"
 
 	swaggerFiles "github.com/swaggo/files"
@@ -31,6 +32,10 @@ func NewRouter() *gin.Engine {
 	router.Use(func(c *gin.Context) {
 	defer func() {
 	if r := recover(); r != nil {
+	//an aborted response is left to net/http which closes the connection
+	if r == http.ErrAbortHandler {
+	panic(r)
+	}
 	c.JSON(500, dto.ErrorResponseDto{
 	StatusCode: dto.GetStatusDetails(status_code.IMS500).StatusCode,
 	Message:    dto.GetStatusDetails(status_code.IMS500).Message,
@@ -80,16 +85,35 @@ func NewRouter() *gin.Engine {
 				v1.GET("/inventory/configurations", controllerFacade.InventoryConfigurationController.GetAllConfiguration())
 				v1.DELETE("/inventory/configurations/:inventoryName", controllerFacade.InventoryConfigurationController.DeleteConfiguration())
 				v1.POST("/inventory/configurations", controllerFacade.InventoryConfigurationController.CreateNewConfiguration())
+				v1.PUT("/inventory/configurations/:inventoryName", controllerFacade.InventoryConfigurationController.UpdateConfiguration())
+				v1.POST("/inventory/configurations/:inventoryName/validate", controllerFacade.InventoryConfigurationController.ValidateConfiguration())
+				v1.POST("/inventory/configurations/:inventoryName/restore", controllerFacade.InventoryConfigurationController.RestoreConfiguration())
+				v1.DELETE("/inventory/configurations/:inventoryName/purge", controllerFacade.InventoryConfigurationController.PurgeConfiguration())
+				v1.POST("/admin/inventory/reconcile", controllerFacade.InventoryConfigurationController.ReconcileConfigurations())
 				//Inventory Controller
 				v1.PATCH("/inventory/:inventoryName", controllerFacade.InventoryController.ActivateResourceById())
 				v1.GET("/inventory/:inventoryName", controllerFacade.InventoryController.GetInventory())
 				v1.POST("/inventory/:inventoryName", controllerFacade.InventoryController.AddNewInventory())
+				v1.POST("/inventory/:inventoryName/bulk", controllerFacade.InventoryController.BulkAddNewInventory())
+				v1.PUT("/inventory/:inventoryName", controllerFacade.InventoryController.UpsertInventory())
+				v1.GET("/inventory/:inventoryName/trash", controllerFacade.InventoryController.GetInventoryTrash())
+				v1.GET("/inventory/:inventoryName/search", controllerFacade.InventoryController.SearchInventory())
+				v1.POST("/inventory/:inventoryName/aggregate", controllerFacade.InventoryController.AggregateInventory())
+				v1.GET("/inventory/:inventoryName/export", controllerFacade.InventoryController.ExportInventory())
+				v1.POST("/inventory/:inventoryName/import", controllerFacade.InventoryController.ImportInventory())
+				v1.POST("/inventory/:inventoryName/purge", controllerFacade.InventoryController.PurgeInventory())
+				//Item history
+				v1.GET("/inventory/:inventoryName/items/:id/revisions", controllerFacade.InventoryController.ListItemRevisions())
+				v1.GET("/inventory/:inventoryName/items/:id/as-of", controllerFacade.InventoryController.GetItemAsOf())
+				v1.GET("/inventory/:inventoryName/items/:id/diff", controllerFacade.InventoryController.DiffItemRevisions())
+				v1.POST("/inventory/:inventoryName/items/:id/revert", controllerFacade.InventoryController.RevertItem())
 			}
 			v2 := api.Group(portConstants.VERSION_V2)
 			{
 				v2.PATCH("/inventory/update/:inventoryName/:id", controllerFacade.InventoryController.UpdateInventory())
 				v2.GET("/inventory/filter/:inventoryName/:filterName", controllerFacade.InventoryController.GetInventoryFilter())
 				v2.POST("/inventory/:inventoryName", controllerFacade.InventoryController.GetInventoryV2())
+				v2.POST("/inventory/:inventoryName/facets", controllerFacade.InventoryController.GetInventoryFacets())
 				v2.DELETE("/inventory/:inventoryName", controllerFacade.InventoryController.RemoveItemFromInventory())
 				v2.DELETE("/inventory/subject/remove", controllerFacade.InventoryController.RemoveSubjectTopicsByLessonNameAndSubjectId())
 
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// DecodeBulkItems : reads the items of a bulk request, the body is either a JSON array or a stream of JSON documents such as NDJSON.
+// Every item is held in memory so the caller bounds the body, its read error is returned as is
+func DecodeBulkItems(body io.Reader) ([]interface{}, error) {
+	reader := bufio.NewReader(body)
+	firstByte, err := peekNonSpace(reader)
+	if err != nil {
+		return nil, errors.New("request body is empty")
+	}
+
+	decoder := json.NewDecoder(reader)
+	if firstByte == '[' {
+		var items []interface{}
+		if err := decoder.Decode(&items); err != nil {
+			return nil, err
+		}
+		if decoder.More() {
+			return nil, errors.New("unexpected data after the item array")
+		}
+		return items, nil
+	}
+
+	var items []interface{}
+	for {
+		var item interface{}
+		err := decoder.Decode(&item)
+		if err == io.EOF {
+			return items, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+}
+
+func peekNonSpace(reader *bufio.Reader) (byte, error) {
+	for {
+		nextBytes, err := reader.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+		if !bytes.ContainsAny(nextBytes, " \t\r\n") {
+			return nextBytes[0], nil
+		}
+		_, _ = reader.ReadByte()
+	}
+}
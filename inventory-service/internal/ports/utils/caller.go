@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"inventory-system/common/pkg/constants"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetCaller : id of the user making the request from the X-User-Id header, anonymous when it is not sent
+func GetCaller(c *gin.Context) string {
+	caller := strings.TrimSpace(c.GetHeader(constants.CallerIdHeader))
+	if caller == "" {
+		return constants.AnonymousCaller
+	}
+	return caller
+}
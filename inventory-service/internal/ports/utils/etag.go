@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"errors"
+	"inventory-system/common/pkg/constants"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ItemETag : entity tag of an item version
+func ItemETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// SetItemETag : sets the ETag header from the version of the item, nothing is set for items without a version
+func SetItemETag(c *gin.Context, item bson.M) {
+	switch version := item[constants.ItemVersionField].(type) {
+	case int64:
+		c.Header("ETag", ItemETag(version))
+	case int32:
+		c.Header("ETag", ItemETag(int64(version)))
+	}
+}
+
+// GetIfMatchVersion : version required by the If-Match header, nil when the header is absent or *
+func GetIfMatchVersion(c *gin.Context) (*int64, error) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return nil, nil
+	}
+	version, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`), 10, 64)
+	if err != nil {
+		return nil, errors.New("If-Match must be the ETag of an item version")
+	}
+	return &version, nil
+}
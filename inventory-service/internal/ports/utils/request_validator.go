@@ -15,6 +15,7 @@ type IRequestValidator interface {
 	ValidateCreateConfigurationRequest(requestBody ConfigurationServiceDto.CreateNewConfigurationRequestBody) (*dto.ErrorResponseDto, bson.M)
 	ValidationErrors(err error) *dto.ErrorResponseDto
 	ValidateStruct(interfaceData interface{}) *dto.ErrorResponseDto
+	ValidateInventoryIdentifiers(inventoryIdentifiers []ConfigurationServiceDto.InventoryIdentifier) *dto.ErrorResponseDto
 }
 type RequestValidator struct {
 	Validator *validator.Validate
@@ -40,7 +41,51 @@ func (rv RequestValidator) ValidateCreateConfigurationRequest(requestBody Config
 	if err != nil {
 		return rv.ValidationErrors(err), nil
 	}
-	return nil, nil
+	return rv.ValidateInventoryIdentifiers(requestBody.InventoryIdentifiers), nil
+}
+
+// ValidateInventoryIdentifiers : checks the combinations of index options mongo cannot build
+func (rv RequestValidator) ValidateInventoryIdentifiers(inventoryIdentifiers []ConfigurationServiceDto.InventoryIdentifier) *dto.ErrorResponseDto {
+	var errorList []string
+	identifierKeys := make(map[string]bool)
+	textIndexCount := 0
+
+	for _, inventoryIdentifier := range inventoryIdentifiers {
+		if identifierKeys[inventoryIdentifier.Key] {
+			errorList = append(errorList, inventoryIdentifier.Key+" : Duplicate identifier key")
+		}
+		identifierKeys[inventoryIdentifier.Key] = true
+
+		fields := make(map[string]bool)
+		for _, field := range inventoryIdentifier.Fields() {
+			if field == "" || fields[field] {
+				errorList = append(errorList, inventoryIdentifier.Key+" : Keys must be distinct and non empty")
+				break
+			}
+			fields[field] = true
+		}
+
+		if inventoryIdentifier.IsText() {
+			textIndexCount++
+			if inventoryIdentifier.IsUnique {
+				errorList = append(errorList, inventoryIdentifier.Key+" : Text index cannot be unique")
+			}
+		}
+		if inventoryIdentifier.ExpireAfterSeconds != nil && (inventoryIdentifier.IsText() || len(inventoryIdentifier.Fields()) > 1) {
+			errorList = append(errorList, inventoryIdentifier.Key+" : TTL index must be a single field ascending or descending index")
+		}
+	}
+	if textIndexCount > 1 {
+		errorList = append(errorList, "inventory_identifiers : Only one text index allowed")
+	}
+
+	if len(errorList) > 0 {
+		var errDto dto.ErrorResponseDto
+		errDto.SetError(status_code.IMS400)
+		errDto.Message = strings.Join(errorList, ", ")
+		return &errDto
+	}
+	return nil
 }
 
 func (rv RequestValidator) ValidationErrors(err error) *dto.ErrorResponseDto {
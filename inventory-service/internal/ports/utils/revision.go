@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ParseRevisionVersion : version named by a query parameter, versions start at 1
+func ParseRevisionVersion(name string, value string) (int64, error) {
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || version < 1 {
+		return 0, errors.New(name + " must be a positive integer")
+	}
+	return version, nil
+}
+
+// ParseRevisionTimestamp : RFC3339 timestamp of an as-of read
+func ParseRevisionTimestamp(value string) (time.Time, error) {
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.New("timestamp must be an RFC3339 date time")
+	}
+	return timestamp, nil
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"errors"
+	"inventory-system/inventory-service/internal/common/dto/request_dto"
+	portConstants "inventory-system/inventory-service/internal/ports/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetUpdateFormat : update format selected by the Content-Type of the request, plain JSON bodies are set updates
+func GetUpdateFormat(c *gin.Context) (string, error) {
+	switch contentType := c.ContentType(); contentType {
+	case "", gin.MIMEJSON:
+		return request_dto.UpdateFormatSet, nil
+	case portConstants.MIMEJSONPatch:
+		return request_dto.UpdateFormatJSONPatch, nil
+	case portConstants.MIMEMergePatch:
+		return request_dto.UpdateFormatMergePatch, nil
+	default:
+		return "", errors.New("unsupported content type " + contentType + ", expected " + gin.MIMEJSON + ", " +
+			portConstants.MIMEJSONPatch + " or " + portConstants.MIMEMergePatch)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"bitbucket.org/kodnest/go-common-libraries/config"
 	"bitbucket.org/kodnest/go-common-libraries/gracefulshutdown"
 	"bitbucket.org/kodnest/go-common-libraries/logger"
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"github.com/spf13/viper"
@@ -11,12 +13,14 @@ import (
 	"inventory-system/common/pkg/utils"
 	configs "inventory-system/inventory-service/config"
 	"inventory-system/inventory-service/internal/adapters/db"
+	"inventory-system/inventory-service/internal/domain/factory"
 	portConstants "inventory-system/inventory-service/internal/ports/constants"
 	"inventory-system/inventory-service/pkg"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 )
 
 func init() {
@@ -78,6 +82,10 @@ func main() {
 	gracefulShutDownManager := gracefulshutdown.NewManager(log, exitChannel)
 	utils.NewServiceUtils().SetDefaultProperties(configs.PropertiesMap)
 	db.Init()
+	if len(os.Args) > 1 && os.Args[1] == portConstants.RECONCILE_COMMAND {
+		runReconcileCommand(os.Args[2:])
+		return
+	}
 	startRestApiService(gracefulShutDownManager, exitChannel)
 
 }
@@ -110,9 +118,64 @@ func startRestApiService(gracefulShutDownManager *gracefulshutdown.Manager, exit
 		}
 	}, viper.GetInt(commonConstants.MAX_STARTUP_ATTEMPT))
 
+	startRetentionJob()
+
 	// Init Shutdown Signals & Actions
 	gracefulShutDownManager.Shutdown(httpServer)
 	// Blocking until the shutdown to complete then inform the main goroutine.
 	<-exitChannel
 	log.Info("main goroutine shutdown completed gracefully.")
 }
+
+// startRetentionJob : periodically purges the soft deleted items and revisions the retention policies no longer keep
+func startRetentionJob() {
+	log := logger.New(logger.Info)
+	interval := viper.GetInt(commonConstants.RETENTION_JOB_INTERVAL_IN_MINUTES)
+	if interval <= 0 {
+		log.Info("Retention job disabled")
+		return
+	}
+	utils.SafeGoRoutine(commonConstants.RETENTION_JOB_NAME, func() {
+		log.Info("Retention job running every ", interval, " minutes")
+		ticker := time.NewTicker(time.Duration(interval) * time.Minute)
+		defer ticker.Stop()
+		for range ticker.C {
+			report, errDto := factory.GetServices().InventoryService.EnforceRetentionPolicies(context.Background(), false)
+			if errDto != nil {
+				log.Error("Retention run failed: ", errDto.Message)
+				continue
+			}
+			log.Info("Retention run purged ", report.PurgedItems, " items and ", report.PurgedRevisions, " revisions across ", len(report.Inventories), " inventories with ", len(report.Failures), " failures")
+			for _, failure := range report.Failures {
+				log.Error("Retention run failure: ", failure)
+			}
+		}
+	}, viper.GetInt(commonConstants.MAX_STARTUP_ATTEMPT))
+}
+
+// runReconcileCommand : prints the drift report between inventory configurations and collections, -apply repairs the drift
+func runReconcileCommand(arguments []string) {
+	log := logger.New(logger.Info)
+	reconcileFlags := flag.NewFlagSet(portConstants.RECONCILE_COMMAND, flag.ExitOnError)
+	apply := reconcileFlags.Bool("apply", false, "repair the reported drift")
+	reconcileFlags.Usage = func() {
+		fmt.Println("Usage: server reconcile [-apply]")
+		os.Exit(1)
+	}
+	_ = reconcileFlags.Parse(arguments)
+
+	report, errDto := factory.GetServices().InventoryConfigurationService.ReconcileInventoryConfigurations(context.Background(), *apply)
+	if errDto != nil {
+		log.Error("reconcile failed: ", errDto.Message)
+		os.Exit(1)
+	}
+	reportJson, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Error("unable to print drift report: ", err.Error())
+		os.Exit(1)
+	}
+	fmt.Println(string(reportJson))
+	if len(report.RepairFailures) > 0 {
+		os.Exit(1)
+	}
+}